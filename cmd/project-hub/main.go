@@ -13,8 +13,11 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"project-hub/internal/app"
+	"project-hub/internal/cache"
 	"project-hub/internal/config"
 	"project-hub/internal/github"
+	"project-hub/internal/history"
+	"project-hub/internal/journal"
 	"project-hub/internal/state"
 )
 
@@ -56,6 +59,22 @@ func loadStartupConfig(errOut io.Writer) (config.Config, bool) {
 	return loadedCfg, config.Exists(configPath)
 }
 
+// newClient builds the GitHub backend selected at startup.
+func newClient(backend, ghPath, endpoint string) (github.Client, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", "cli":
+		return github.NewCLIClient(ghPath), nil
+	case "graphql", "http":
+		token, err := github.ResolveToken(context.Background(), ghPath)
+		if err != nil {
+			return nil, err
+		}
+		return github.NewGraphQLClient(endpoint, token), nil
+	default:
+		return nil, fmt.Errorf("unknown backend %q (expected \"cli\" or \"graphql\")", backend)
+	}
+}
+
 func main() {
 	projectArg := flag.String("project", "", "GitHub Project ID or URL")
 	projectShort := flag.String("p", "", "GitHub Project ID or URL (shorthand for --project)")
@@ -67,6 +86,10 @@ func main() {
 	itemLimitShort := flag.Int("il", 100, "Maximum number of items to fetch (shorthand for --item-limit)")
 	disableNotificationsFlag := flag.Bool("disable-notifications", false, "Suppress info-level notifications in the UI")
 	excludeDoneFlag := flag.Bool("exclude-done", false, "Exclude items with 'Done' status")
+	backendFlag := flag.String("backend", "cli", "GitHub backend: \"cli\" (gh subprocess) or \"graphql\" (direct HTTP)")
+	refreshIntervalFlag := flag.Duration("refresh-interval", 0, "Refresh project data automatically at this interval, e.g. 5m (default: off)")
+	graphqlEndpointFlag := flag.String("graphql-endpoint", github.DefaultGraphQLEndpoint, "GraphQL endpoint used by the graphql backend")
+	viewFlag := flag.String("view", "", "Open a saved view by name")
 	var iterationFlag multiValueFlag
 	var iterationShort multiValueFlag
 	flag.Var(&iterationFlag, "iteration", "Iteration filters (repeat flag or pass values after it)")
@@ -129,6 +152,12 @@ func main() {
 		excludeDone = true
 	}
 
+	refreshInterval, err := resolveRefreshInterval(*refreshIntervalFlag, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "warning: ignoring refresh interval:", err)
+		refreshInterval = 0
+	}
+
 	if len(iterationFilters) == 0 && len(cfg.DefaultIterationFilters) > 0 {
 		iterationFilters = cfg.DefaultIterationFilters
 	}
@@ -150,7 +179,11 @@ func main() {
 		projID = resolvedProject
 	}
 
-	client := github.NewCLIClient(cliGhPath)
+	client, err := newClient(*backendFlag, cliGhPath, *graphqlEndpointFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to initialize backend:", err)
+		os.Exit(1)
+	}
 
 	cardFieldVis := state.DefaultCardFieldVisibility()
 	if configExists {
@@ -160,21 +193,16 @@ func main() {
 			ShowSubIssueProgress: cfg.CardFieldVisibility.ShowSubIssueProgress,
 			ShowParentIssue:      cfg.CardFieldVisibility.ShowParentIssue,
 			ShowLabels:           cfg.CardFieldVisibility.ShowLabels,
+			ShowIteration:        cfg.CardFieldVisibility.ShowIteration,
 		}
 	}
 
 	initial := state.Model{
 		Project: state.Project{ID: projID, Owner: owner, Name: "GitHub Projects TUI"},
-		Items: []state.Item{
-			{ID: "1", Title: "Design board", Status: "Backlog", Labels: []string{"design"}},
-			{ID: "2", Title: "Wire table view", Status: "InProgress", Labels: []string{"ui"}},
-			{ID: "3", Title: "Add filtering", Status: "Review", Labels: []string{"feature"}},
-		},
 		View: state.ViewContext{
 			CurrentView:         state.ViewBoard,
 			Mode:                state.ModeNormal,
 			FocusedIndex:        0,
-			FocusedItemID:       "1",
 			CardFieldVisibility: cardFieldVis,
 		},
 		ItemLimit:           itemLimit,
@@ -186,31 +214,51 @@ func main() {
 		initial.CreateIssueRepoMode = state.CreateIssueRepoModeRequired
 	}
 	initial.View.Filter.Iterations = iterationFilters
+	initial.RefreshInterval = refreshInterval
+	initial.PlanningCapacity = cfg.PlanningCapacity
+	initial.SavedViews = config.StateViews(cfg.SavedViews)
 
-	// Try to load real project data via gh; fallback to sample on error.
-	if proj, items, err := client.FetchProject(context.Background(), projID, owner, github.BuildIterationQuery(iterationFilters), itemLimit); err == nil {
-		if proj.Name != "" {
-			initial.Project = proj
+	// Render instantly from the last snapshot; the app refreshes in the background on start.
+	if cachePath, err := cache.ResolvePath(projID, owner); err != nil {
+		fmt.Fprintln(os.Stderr, "warning: failed to resolve cache path:", err)
+	} else {
+		initial.CachePath = cachePath
+		if snap, err := cache.Load(cachePath); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: failed to load cached project:", err)
+		} else {
+			applySnapshot(&initial, snap)
 		}
-		if len(items) > 0 {
-			initial.Items = items
-			initial.View.FocusedIndex = 0
-			initial.View.FocusedItemID = items[0].ID
+	}
+
+	// Edits queued while offline are shown on top of the snapshot and replayed once a refresh succeeds.
+	if journalPath, err := journal.ResolvePath(projID, owner); err != nil {
+		fmt.Fprintln(os.Stderr, "warning: failed to resolve journal path:", err)
+	} else {
+		initial.JournalPath = journalPath
+		if pending, err := journal.Load(journalPath); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: failed to load queued changes:", err)
 		} else {
-			initial.Notifications = append(initial.Notifications, state.Notification{Message: "No items fetched (gh returned 0)", Level: "warn", At: time.Now()})
+			applyPending(&initial, pending)
 		}
+	}
+
+	// Refresh samples feed the insights charts; GitHub keeps no history of item statuses.
+	if historyPath, err := history.ResolvePath(projID, owner); err != nil {
+		fmt.Fprintln(os.Stderr, "warning: failed to resolve history path:", err)
 	} else {
-		fmt.Fprintln(os.Stderr, "warning: gh fetch failed, using sample data:", err)
+		initial.HistoryPath = historyPath
+		if samples, err := history.Load(historyPath); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: failed to load insights history:", err)
+		} else {
+			initial.History = samples
+		}
 	}
 
-	if excludeDone {
-		var filtered []state.Item
-		for _, item := range initial.Items {
-			if item.Status != "Done" {
-				filtered = append(filtered, item)
-			}
+	if *viewFlag != "" {
+		if err := applySavedView(&initial, *viewFlag); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-		initial.Items = filtered
 	}
 
 	p := tea.NewProgram(app.New(initial, client, itemLimit), tea.WithAltScreen())
@@ -220,6 +268,98 @@ func main() {
 	}
 }
 
+// applySavedView opens the view saved under name in the config file, or one
+// of the project's GitHub views from the cached snapshot. Any other name is
+// looked up among the GitHub views once the project is fetched. Iterations
+// given on the command line are kept unless the view filters by iteration
+// itself.
+func applySavedView(initial *state.Model, name string) error {
+	v, ok := state.FindSavedView(initial.SavedViews, name)
+	if !ok {
+		if v, ok = state.FindSavedView(initial.Project.SavedViews, name); !ok {
+			initial.PendingView = name
+			return nil
+		}
+		filter := state.ImportGitHubFilter(v.Query)
+		v.Query = filter.Query
+		if len(filter.ServerOnly) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: view %q: only GitHub evaluates %s\n", v.Name, strings.Join(filter.ServerOnly, " "))
+		}
+		if len(filter.Dropped) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: view %q: dropped %s\n", v.Name, strings.Join(filter.Dropped, " "))
+		}
+	}
+	fs := state.ParseFilter(v.Query)
+	if fs.Err != nil {
+		return fmt.Errorf("view %q has an invalid filter: %w", v.Name, fs.Err)
+	}
+	if len(fs.Iterations) == 0 {
+		fs.Iterations = initial.View.Filter.Iterations
+	}
+	initial.View.Filter = fs
+	if v.View != "" {
+		initial.View.CurrentView = v.View
+	}
+	if v.View == state.ViewRoadmap {
+		initial.View.RoadmapZoom = state.RoadmapMonth
+	}
+	initial.View.TableSort = v.TableSort
+	initial.View.TableGroupBy = v.TableGroupBy
+	if fs.GroupBy != "" {
+		initial.View.TableGroupBy = fs.GroupBy
+	}
+	if v.CardFieldVisibility != (state.CardFieldVisibility{}) {
+		initial.View.CardFieldVisibility = v.CardFieldVisibility
+	}
+	return nil
+}
+
+// applySnapshot seeds the initial model from a cached snapshot and marks it stale.
+func applySnapshot(initial *state.Model, snap cache.Snapshot) {
+	if snap.Empty() {
+		return
+	}
+	if snap.Project.Name != "" {
+		snap.Project.ID = initial.Project.ID
+		if snap.Project.Owner == "" {
+			snap.Project.Owner = initial.Project.Owner
+		}
+		initial.Project = snap.Project
+	}
+	for _, item := range snap.Items {
+		if initial.ExcludeDone && item.Status == "Done" {
+			continue
+		}
+		initial.Items = append(initial.Items, item)
+	}
+	if len(initial.Items) > 0 {
+		initial.View.FocusedIndex = 0
+		initial.View.FocusedItemID = initial.Items[0].ID
+	}
+	initial.Repos = snap.Repos
+	savedAt := snap.SavedAt
+	initial.CachedAt = &savedAt
+	initial.Stale = true
+	// Kept for the digest of what changed since the previous session.
+	initial.Baseline = append([]state.Item(nil), initial.Items...)
+	initial.BaselineAt = &savedAt
+}
+
+// applyPending restores queued mutations and applies them to the cached items.
+func applyPending(initial *state.Model, pending []state.Mutation) {
+	initial.PendingMutations = pending
+	for _, m := range pending {
+		if m.Kind == state.MutationComment {
+			continue
+		}
+		for i := range initial.Items {
+			if initial.Items[i].ID == m.ItemID() {
+				initial.Items[i] = m.Apply(initial.Items[i])
+			}
+		}
+	}
+}
+
 func parseProjectArg(arg string) (projectID string, owner string) {
 	u, err := url.Parse(arg)
 	if err == nil && u.Scheme != "" {
@@ -282,3 +422,20 @@ func normalizeIterationFilters(values []string) []string {
 	}
 	return filters
 }
+
+// resolveRefreshInterval prefers the --refresh-interval flag over the config
+// file. Zero disables auto-refresh.
+func resolveRefreshInterval(flagValue time.Duration, cfg config.Config) (time.Duration, error) {
+	interval := flagValue
+	if interval == 0 && strings.TrimSpace(cfg.RefreshInterval) != "" {
+		parsed, err := time.ParseDuration(strings.TrimSpace(cfg.RefreshInterval))
+		if err != nil {
+			return 0, fmt.Errorf("invalid refreshInterval %q in config: %w", cfg.RefreshInterval, err)
+		}
+		interval = parsed
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("refresh interval must not be negative, got %s", interval)
+	}
+	return interval, nil
+}
@@ -6,8 +6,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"project-hub/internal/cache"
 	"project-hub/internal/config"
+	"project-hub/internal/github"
+	"project-hub/internal/state"
 )
 
 func TestResolveStartupOptionsCLIWins(t *testing.T) {
@@ -168,3 +172,110 @@ func TestLoadStartupConfigMalformedConfigWarning(t *testing.T) {
 		t.Fatalf("expected malformed-config warning, got %q", stderr.String())
 	}
 }
+
+func TestNewClientSelectsBackend(t *testing.T) {
+	t.Setenv("GH_TOKEN", "env-token")
+
+	cliClient, err := newClient("cli", "/usr/local/bin/gh", "")
+	if err != nil {
+		t.Fatalf("cli backend: %v", err)
+	}
+	if c, ok := cliClient.(*github.CLIClient); !ok || c.GhPath != "/usr/local/bin/gh" {
+		t.Fatalf("expected CLI client with gh path, got %#v", cliClient)
+	}
+
+	gqlClient, err := newClient("graphql", "", "http://127.0.0.1:8080/graphql")
+	if err != nil {
+		t.Fatalf("graphql backend: %v", err)
+	}
+	c, ok := gqlClient.(*github.GraphQLClient)
+	if !ok {
+		t.Fatalf("expected GraphQL client, got %#v", gqlClient)
+	}
+	if c.Endpoint != "http://127.0.0.1:8080/graphql" || c.Token != "env-token" {
+		t.Fatalf("unexpected GraphQL client config: %+v", c)
+	}
+
+	if _, err := newClient("smoke-signals", "", ""); err == nil {
+		t.Fatalf("expected unknown backend to fail")
+	}
+}
+
+func TestApplySnapshotMarksModelStale(t *testing.T) {
+	savedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	initial := state.Model{Project: state.Project{ID: "5", Owner: "acme"}, ExcludeDone: true}
+	applySnapshot(&initial, cache.Snapshot{
+		Project: state.Project{ID: "5", Owner: "acme", Name: "Roadmap"},
+		Items:   []state.Item{{ID: "1", Status: "Done"}, {ID: "2", Status: "Todo"}},
+		SavedAt: savedAt,
+	})
+
+	if !initial.Stale || initial.CachedAt == nil || !initial.CachedAt.Equal(savedAt) {
+		t.Fatalf("expected stale model with cache time, got stale=%v cachedAt=%v", initial.Stale, initial.CachedAt)
+	}
+	if initial.Project.Name != "Roadmap" {
+		t.Fatalf("expected cached project metadata, got %+v", initial.Project)
+	}
+	if len(initial.Items) != 1 || initial.View.FocusedItemID != "2" {
+		t.Fatalf("expected done items excluded and focus on first item, got %+v", initial.Items)
+	}
+	if len(initial.Baseline) != 1 || initial.BaselineAt == nil || !initial.BaselineAt.Equal(savedAt) {
+		t.Fatalf("expected cached items kept as digest baseline, got %+v", initial.Baseline)
+	}
+}
+
+func TestApplySnapshotIgnoresEmptySnapshot(t *testing.T) {
+	initial := state.Model{Project: state.Project{ID: "5"}}
+	applySnapshot(&initial, cache.Snapshot{})
+	if initial.Stale || len(initial.Items) != 0 {
+		t.Fatalf("expected model untouched by empty snapshot, got %+v", initial)
+	}
+}
+
+func TestResolveRefreshInterval(t *testing.T) {
+	interval, err := resolveRefreshInterval(0, config.Config{RefreshInterval: "5m"})
+	if err != nil || interval != 5*time.Minute {
+		t.Fatalf("expected config interval, got %v, %v", interval, err)
+	}
+	interval, err = resolveRefreshInterval(30*time.Second, config.Config{RefreshInterval: "5m"})
+	if err != nil || interval != 30*time.Second {
+		t.Fatalf("expected flag to win, got %v, %v", interval, err)
+	}
+	if _, err := resolveRefreshInterval(0, config.Config{RefreshInterval: "often"}); err == nil {
+		t.Fatalf("expected invalid config interval to fail")
+	}
+	if _, err := resolveRefreshInterval(-time.Minute, config.Config{}); err == nil {
+		t.Fatalf("expected negative interval to fail")
+	}
+}
+
+func TestApplySavedView(t *testing.T) {
+	initial := state.Model{
+		View: state.ViewContext{CurrentView: state.ViewBoard, Filter: state.FilterState{Iterations: []string{"@current"}}},
+		SavedViews: []state.SavedView{{
+			Name:         "Bugs",
+			Query:        "label:bug",
+			View:         state.ViewTable,
+			TableSort:    state.TableSort{Field: "UpdatedAt"},
+			TableGroupBy: "status",
+		}},
+		Project: state.Project{SavedViews: []state.SavedView{{Name: "Timeline", View: state.ViewRoadmap}}},
+	}
+
+	if err := applySavedView(&initial, "bugs"); err != nil {
+		t.Fatalf("applySavedView returned error: %v", err)
+	}
+	if initial.View.CurrentView != state.ViewTable || initial.View.Filter.Raw != "label:bug" || initial.View.TableGroupBy != "status" || initial.View.TableSort.Field != "UpdatedAt" {
+		t.Fatalf("expected saved view applied, got %+v", initial.View)
+	}
+	if len(initial.View.Filter.Iterations) != 1 {
+		t.Fatalf("expected command line iterations kept, got %v", initial.View.Filter.Iterations)
+	}
+
+	if err := applySavedView(&initial, "Timeline"); err != nil || initial.View.CurrentView != state.ViewRoadmap || initial.View.RoadmapZoom != state.RoadmapMonth {
+		t.Fatalf("expected cached GitHub view applied, got %+v, %v", initial.View, err)
+	}
+	if err := applySavedView(&initial, "missing"); err != nil || initial.PendingView != "missing" {
+		t.Fatalf("expected an uncached view left for the fetched project, got %q, %v", initial.PendingView, err)
+	}
+}
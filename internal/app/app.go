@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -26,6 +27,9 @@ type App struct {
 	textArea         textarea.Model
 	statusSelector   components.StatusSelectorModel
 	fieldSelector    components.FieldSelectorModel
+	fieldEditor      components.FieldEditorModel
+	iterationPicker  components.IterationSelectorModel
+	checklist        components.ChecklistModel
 	settingsModel    settings.SettingsModel
 	detailPanel      components.DetailPanelModel
 	detailItem       state.Item
@@ -34,6 +38,8 @@ type App struct {
 	createIssueTitle string
 	createIssueBody  string
 	textAreaVimMode  string
+	spinner          spinner.Model
+	fetch            update.FetchState
 }
 
 func New(initial state.Model, client github.Client, itemLimit int) App {
@@ -42,7 +48,7 @@ func New(initial state.Model, client github.Client, itemLimit int) App {
 }
 
 func (a App) Init() tea.Cmd {
-	return tea.Batch(core.FetchProjectCmd(a.github, a.state.Project.ID, a.state.Project.Owner, a.itemLimit, a.state.View.Filter.Iterations), textinput.Blink)
+	return tea.Batch(func() tea.Msg { return update.RefreshMsg{} }, core.AutoRefreshCmd(a.state.RefreshInterval), textinput.Blink)
 }
 
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -52,7 +58,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (a *App) LoadInitialState(ctx context.Context, projectID string, owner string) error {
-	project, items, err := a.github.FetchProject(ctx, projectID, owner, github.BuildIterationQuery(a.state.View.Filter.Iterations), a.itemLimit)
+	project, items, err := a.github.FetchProject(ctx, projectID, owner, github.CompileFilter(a.state.View.Filter).Server, a.itemLimit)
 	if err != nil {
 		return err
 	}
@@ -75,6 +81,9 @@ func (a App) toUpdateState() update.State {
 		TextArea:         a.textArea,
 		StatusSelector:   a.statusSelector,
 		FieldSelector:    a.fieldSelector,
+		FieldEditor:      a.fieldEditor,
+		IterationPicker:  a.iterationPicker,
+		Checklist:        a.checklist,
 		SettingsModel:    a.settingsModel,
 		DetailPanel:      a.detailPanel,
 		DetailItem:       a.detailItem,
@@ -83,6 +92,8 @@ func (a App) toUpdateState() update.State {
 		CreateIssueTitle: a.createIssueTitle,
 		CreateIssueBody:  a.createIssueBody,
 		TextAreaVimMode:  a.textAreaVimMode,
+		Spinner:          a.spinner,
+		Fetch:            a.fetch,
 	}
 }
 
@@ -95,6 +106,9 @@ func (a App) applyUpdateState(s update.State) App {
 	a.textArea = s.TextArea
 	a.statusSelector = s.StatusSelector
 	a.fieldSelector = s.FieldSelector
+	a.fieldEditor = s.FieldEditor
+	a.iterationPicker = s.IterationPicker
+	a.checklist = s.Checklist
 	a.settingsModel = s.SettingsModel
 	a.detailPanel = s.DetailPanel
 	a.detailItem = s.DetailItem
@@ -103,6 +117,8 @@ func (a App) applyUpdateState(s update.State) App {
 	a.createIssueTitle = s.CreateIssueTitle
 	a.createIssueBody = s.CreateIssueBody
 	a.textAreaVimMode = s.TextAreaVimMode
+	a.spinner = s.Spinner
+	a.fetch = s.Fetch
 	return a
 }
 
@@ -116,6 +132,9 @@ func fromUpdateState(s update.State) App {
 		textArea:         s.TextArea,
 		statusSelector:   s.StatusSelector,
 		fieldSelector:    s.FieldSelector,
+		fieldEditor:      s.FieldEditor,
+		iterationPicker:  s.IterationPicker,
+		checklist:        s.Checklist,
 		settingsModel:    s.SettingsModel,
 		detailPanel:      s.DetailPanel,
 		detailItem:       s.DetailItem,
@@ -124,5 +143,7 @@ func fromUpdateState(s update.State) App {
 		createIssueTitle: s.CreateIssueTitle,
 		createIssueBody:  s.CreateIssueBody,
 		textAreaVimMode:  s.TextAreaVimMode,
+		spinner:          s.Spinner,
+		fetch:            s.Fetch,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"project-hub/internal/app/core"
+	"project-hub/internal/github"
 	"project-hub/internal/state"
 )
 
@@ -17,6 +18,12 @@ func (n *noopClient) FetchProject(ctx context.Context, projectID string, owner s
 func (n *noopClient) FetchItems(ctx context.Context, projectID string, owner string, filter string, limit int) ([]state.Item, error) {
 	return nil, nil
 }
+func (n *noopClient) FetchProjectMetadata(ctx context.Context, projectID string, owner string) (state.Project, error) {
+	return state.Project{}, nil
+}
+func (n *noopClient) FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (github.ItemsPage, error) {
+	return github.ItemsPage{}, nil
+}
 func (n *noopClient) CreateIssue(ctx context.Context, projectID string, owner string, repo string, title string, body string) (state.Item, error) {
 	return state.Item{ID: "PVTI_new", Repository: repo, Title: title, Description: body, Type: "Issue"}, nil
 }
@@ -26,17 +33,23 @@ func (n *noopClient) UpdateStatus(ctx context.Context, projectID string, owner s
 func (n *noopClient) UpdateField(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string, fieldName string) (state.Item, error) {
 	return state.Item{}, nil
 }
-func (n *noopClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, labels []string) (state.Item, error) {
+func (n *noopClient) UpdateFieldValue(ctx context.Context, projectID string, owner string, itemID string, fieldID string, fieldType state.FieldType, value string) (state.Item, error) {
+	return state.Item{}, nil
+}
+func (n *noopClient) UpdateIteration(ctx context.Context, projectID string, owner string, itemID string, fieldID string, iterationID string) (state.Item, error) {
+	return state.Item{}, nil
+}
+func (n *noopClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error) {
 	return state.Item{}, nil
 }
 func (n *noopClient) UpdateMilestone(ctx context.Context, projectID string, owner string, itemID string, milestone string) (state.Item, error) {
 	return state.Item{}, nil
 }
-func (n *noopClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, userLogins []string) (state.Item, error) {
+func (n *noopClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error) {
 	// Return an item with the assignees populated, simulate partial update.
 	return state.Item{ID: itemID, Assignees: userLogins}, nil
 }
-func (n *noopClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description string) (state.Item, error) {
+func (n *noopClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description *string) (state.Item, error) {
 	return item, nil
 }
 func (n *noopClient) UpdateIssueBody(ctx context.Context, repo string, number int, body string) error {
@@ -49,6 +62,10 @@ func (n *noopClient) FetchIssueDetail(ctx context.Context, repo string, number i
 	return state.Item{}, nil
 }
 
+func (n *noopClient) FetchRepoMetadata(ctx context.Context, repo string) (state.RepoMetadata, error) {
+	return state.RepoMetadata{}, nil
+}
+
 func TestAssignUpdateDoesNotRemapCards(t *testing.T) {
 	// Create 3 items in different statuses
 	items := []state.Item{
@@ -78,7 +95,7 @@ func TestAssignUpdateDoesNotRemapCards(t *testing.T) {
 	// Simulate updating assignee for item ID "2"
 	updated := state.Item{ID: "2", Assignees: []string{"alice"}, Status: "In Progress", Title: "B"}
 	// Call Update by invoking App.Update on the model interface
-	model, _ := app.Update(core.ItemUpdatedMsg{Index: 1, Item: updated})
+	model, _ := app.Update(core.ItemUpdatedMsg{ItemID: "2", Item: updated})
 	updatedApp := model.(App)
 
 	// After update, ensure item at index 1 has assignee
@@ -10,17 +10,136 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"project-hub/internal/cache"
+	"project-hub/internal/config"
 	"project-hub/internal/github"
+	"project-hub/internal/state"
 )
 
-func FetchProjectCmd(client github.Client, projectID, owner string, itemLimit int, iterationFilters []string) tea.Cmd {
+// ItemPageSize is the number of items requested per page while loading a project.
+const ItemPageSize = 100
+
+// HighlightDuration is how long cards changed by a refresh stay highlighted.
+const HighlightDuration = 5 * time.Second
+
+// AutoRefreshCmd schedules the next automatic refresh. It returns nil when
+// auto-refresh is disabled.
+func AutoRefreshCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return AutoRefreshMsg{}
+	})
+}
+
+// ClearHighlightsCmd ends the change highlighting of the refresh with the given generation.
+func ClearHighlightsCmd(generation int) tea.Cmd {
+	return tea.Tick(HighlightDuration, func(time.Time) tea.Msg {
+		return ClearHighlightsMsg{Generation: generation}
+	})
+}
+
+// FetchProjectCmd loads project metadata together with the first page of items.
+// Later pages are requested with FetchItemsPageCmd as each page arrives, with
+// the same project search query. The generation is echoed back so results of a superseded refresh can be dropped.
+func FetchProjectCmd(ctx context.Context, client github.Client, generation int, projectID, owner string, itemLimit int, query string) tea.Cmd {
+	return func() tea.Msg {
+		proj, err := client.FetchProjectMetadata(ctx, projectID, owner)
+		if err != nil {
+			return FetchFailedMsg{Generation: generation, Err: err}
+		}
+		page, err := client.FetchItemsPage(ctx, projectID, owner, query, "", pageSize(itemLimit, 0))
+		if err != nil {
+			return FetchFailedMsg{Generation: generation, Err: err}
+		}
+		return FetchProjectMsg{Generation: generation, Project: proj, Items: page.Items, NextCursor: page.NextCursor, QueryIgnored: page.QueryIgnored}
+	}
+}
+
+// FetchItemsPageCmd loads the page of items that follows cursor.
+func FetchItemsPageCmd(ctx context.Context, client github.Client, generation int, projectID, owner string, itemLimit int, fetched int, query string, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		page, err := client.FetchItemsPage(ctx, projectID, owner, query, cursor, pageSize(itemLimit, fetched))
+		if err != nil {
+			return FetchFailedMsg{Generation: generation, Err: err}
+		}
+		return ItemsPageMsg{Generation: generation, Items: page.Items, NextCursor: page.NextCursor, Fetched: fetched + len(page.Items)}
+	}
+}
+
+// FetchDigestCommentsCmd loads the comments of an issue for the digest view.
+func FetchDigestCommentsCmd(client github.Client, item state.Item) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := client.FetchIssueDetail(context.Background(), item.Repository, item.Number)
+		if err != nil {
+			return DigestCommentsMsg{ItemID: item.ID, Err: err}
+		}
+		return DigestCommentsMsg{ItemID: item.ID, Comments: detail.Comments}
+	}
+}
+
+// FetchSearchCommentsCmd loads the comments of an issue for full-text search.
+func FetchSearchCommentsCmd(client github.Client, item state.Item) tea.Cmd {
 	return func() tea.Msg {
-		proj, items, err := client.FetchProject(context.Background(), projectID, owner, github.BuildIterationQuery(iterationFilters), itemLimit)
+		detail, err := client.FetchIssueDetail(context.Background(), item.Repository, item.Number)
 		if err != nil {
+			return SearchCommentsMsg{ItemID: item.ID, Err: err}
+		}
+		return SearchCommentsMsg{ItemID: item.ID, Comments: detail.Comments}
+	}
+}
+
+// FetchRepoMetadataCmd loads the labels, milestones and assignable users of repo.
+func FetchRepoMetadataCmd(client github.Client, repo string) tea.Cmd {
+	return func() tea.Msg {
+		meta, err := client.FetchRepoMetadata(context.Background(), repo)
+		return RepoMetadataMsg{Repo: repo, Metadata: meta, Err: err}
+	}
+}
+
+// SaveSnapshotCmd persists the fetched project, items and repository metadata
+// so the next start can render immediately from disk.
+func SaveSnapshotCmd(path string, project state.Project, items []state.Item, repos map[string]state.RepoMetadata) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	items = append([]state.Item(nil), items...)
+	return func() tea.Msg {
+		snap := cache.Snapshot{Project: project, Items: items, Repos: repos, SavedAt: time.Now()}
+		if err := cache.Save(path, snap); err != nil {
 			return NewErrMsg(err)
 		}
-		return FetchProjectMsg{Project: proj, Items: items}
+		return nil
+	}
+}
+
+// SaveViewsCmd writes the saved views to the config file, keeping the other
+// settings.
+func SaveViewsCmd(views []state.SavedView) tea.Cmd {
+	views = append([]state.SavedView(nil), views...)
+	return func() tea.Msg {
+		path, err := config.ResolvePath()
+		if err != nil {
+			return NewErrMsg(err)
+		}
+		cfg, err := config.Load(path)
+		if err != nil {
+			return NewErrMsg(err)
+		}
+		cfg.SavedViews = config.ConfigViews(views)
+		if err := config.Save(path, cfg); err != nil {
+			return NewErrMsg(err)
+		}
+		return nil
+	}
+}
+
+func pageSize(itemLimit int, fetched int) int {
+	if itemLimit > 0 && itemLimit-fetched < ItemPageSize {
+		return itemLimit - fetched
 	}
+	return ItemPageSize
 }
 
 func DismissNotificationCmd(id int, duration time.Duration) tea.Cmd {
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/cache"
+	"project-hub/internal/history"
+	"project-hub/internal/state"
+)
+
+// SaveHistoryCmd persists the refresh samples the insights charts are built from.
+func SaveHistoryCmd(path string, samples []state.StatusSample) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	samples = append([]state.StatusSample(nil), samples...)
+	return func() tea.Msg {
+		if err := history.Save(path, samples); err != nil {
+			return NewErrMsg(err)
+		}
+		return nil
+	}
+}
+
+// ExportInsightsCmd writes the burndown and cumulative flow as CSV files in
+// dir, named after the project and the day of the export.
+func ExportInsightsCmd(dir string, project state.Project, burndown state.Burndown, flow state.CumulativeFlow, now time.Time) tea.Cmd {
+	prefix := cache.Key(project.ID, project.Owner)
+	return func() tea.Msg {
+		day := now.Format(state.DateLayout)
+		burndownPath := filepath.Join(dir, fmt.Sprintf("%s-burndown-%s.csv", prefix, day))
+		flowPath := filepath.Join(dir, fmt.Sprintf("%s-cumulative-flow-%s.csv", prefix, day))
+		if err := writeCSV(burndownPath, burndown.CSV()); err != nil {
+			return NewErrMsg(err)
+		}
+		if err := writeCSV(flowPath, flow.CSV()); err != nil {
+			return NewErrMsg(err)
+		}
+		return ActionResultMsg{Message: fmt.Sprintf("Exported %s and %s", burndownPath, flowPath)}
+	}
+}
+
+func writeCSV(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(records); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
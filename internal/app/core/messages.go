@@ -1,19 +1,108 @@
 package core
 
-import "project-hub/internal/state"
+import (
+	"time"
+
+	"project-hub/internal/state"
+)
 
 type FetchProjectMsg struct {
-	Project state.Project
-	Items   []state.Item
+	Generation   int
+	Project      state.Project
+	Items        []state.Item
+	NextCursor   string
+	QueryIgnored bool // GitHub does not accept the items query, so nothing was filtered on GitHub
+}
+
+// ItemsPageMsg carries a follow-up page of items. Fetched counts every item
+// received so far, including this page.
+type ItemsPageMsg struct {
+	Generation int
+	Items      []state.Item
+	NextCursor string
+	Fetched    int
 }
 
+// ItemUpdatedMsg carries GitHub's response to an edit of the item with the
+// given ID.
 type ItemUpdatedMsg struct {
-	Index int
-	Item  state.Item
+	ItemID string
+	Item   state.Item
+}
+
+// FetchFailedMsg reports a refresh that failed or was cancelled.
+type FetchFailedMsg struct {
+	Generation int
+	Err        error
+}
+
+// MutationQueuedMsg reports a mutation that could not reach GitHub and
+// should be queued for replay.
+type MutationQueuedMsg struct {
+	Mutation state.Mutation
+	Err      error
+}
+
+// MutationReplayedMsg reports the outcome of replaying a queued mutation.
+type MutationReplayedMsg struct {
+	Mutation state.Mutation
+	Item     state.Item
+	Err      error
+}
+
+// BulkMutationMsg reports the outcome of every mutation in a bulk edit at once.
+type BulkMutationMsg struct {
+	Results []BulkResult
+}
+
+// BulkResult is the outcome of one mutation of a bulk edit. Item is the item
+// as GitHub reports it when Err is nil.
+type BulkResult struct {
+	Mutation state.Mutation
+	Item     state.Item
+	Err      error
+}
+
+// AutoRefreshMsg fires when the auto-refresh interval elapses.
+type AutoRefreshMsg struct{}
+
+// ClearHighlightsMsg ends the change highlighting from the refresh with the given generation.
+type ClearHighlightsMsg struct {
+	Generation int
+}
+
+// DigestCommentsMsg carries the comments of an item listed in the digest.
+type DigestCommentsMsg struct {
+	ItemID   string
+	Comments []state.Comment
+	Err      error
+}
+
+// SearchCommentsMsg carries the comments of an item for full-text search.
+type SearchCommentsMsg struct {
+	ItemID   string
+	Comments []state.Comment
+	Err      error
+}
+
+// RepoMetadataMsg carries the labels, milestones and assignable users of a repository.
+type RepoMetadataMsg struct {
+	Repo     string
+	Metadata state.RepoMetadata
+	Err      error
+}
+
+// ReconnectMsg asks for a refresh to check whether GitHub is reachable again.
+// Since identifies the offline period that scheduled it.
+type ReconnectMsg struct {
+	Since time.Time
 }
 
 type ErrMsg struct {
 	Err error
+	// Rollback is the optimistically applied mutation that failed, if any.
+	// Its fields are reverted to the snapshot taken before the edit.
+	Rollback *state.Mutation
 }
 
 func NewErrMsg(err error) ErrMsg {
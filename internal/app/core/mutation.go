@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"project-hub/internal/github"
+	"project-hub/internal/journal"
+	"project-hub/internal/state"
+)
+
+// ReconnectInterval is how often a refresh is retried while offline.
+const ReconnectInterval = 30 * time.Second
+
+// BulkParallelism bounds how many mutations of a bulk edit are in flight at once.
+const BulkParallelism = 4
+
+// ExecuteMutation sends m to GitHub and returns the item as GitHub reports it afterwards.
+func ExecuteMutation(ctx context.Context, client github.Client, m state.Mutation) (state.Item, error) {
+	item := m.Before
+	switch m.Kind {
+	case state.MutationStatus:
+		updated, err := client.UpdateStatus(ctx, m.ProjectID, m.Owner, item.ID, m.FieldID, m.OptionID)
+		if err != nil {
+			return state.Item{}, err
+		}
+		if strings.EqualFold(strings.TrimSpace(updated.Status), "unknown") && strings.TrimSpace(m.OptionName) != "" {
+			updated.Status = m.OptionName
+		}
+		return updated, nil
+	case state.MutationField:
+		return client.UpdateField(ctx, m.ProjectID, m.Owner, item.ID, m.FieldID, m.OptionID, m.FieldName)
+	case state.MutationValue:
+		return client.UpdateFieldValue(ctx, m.ProjectID, m.Owner, item.ID, m.FieldID, m.FieldType, m.Text)
+	case state.MutationIteration:
+		iterationID := ""
+		if m.Iteration != nil {
+			iterationID = m.Iteration.ID
+		}
+		return client.UpdateIteration(ctx, m.ProjectID, m.Owner, item.ID, m.FieldID, iterationID)
+	case state.MutationItem:
+		var description *string
+		if m.SetsText() {
+			description = &m.Text
+		}
+		return client.UpdateItem(ctx, m.ProjectID, m.Owner, item, m.Title, description)
+	case state.MutationAssignees:
+		return client.UpdateAssignees(ctx, m.ProjectID, m.Owner, item.ID, item.Type, item.Repository, item.Number, item.Assignees, m.Values)
+	case state.MutationLabels:
+		return client.UpdateLabels(ctx, m.ProjectID, m.Owner, item.ID, item.Type, item.Repository, item.Number, item.Labels, m.Values)
+	case state.MutationMilestone:
+		return client.UpdateMilestone(ctx, m.ProjectID, m.Owner, item.ID, m.Text)
+	case state.MutationBody:
+		if err := client.UpdateIssueBody(ctx, item.Repository, item.Number, m.Text); err != nil {
+			return state.Item{}, err
+		}
+		item.Description = m.Text
+		return item, nil
+	case state.MutationComment:
+		if err := client.AddIssueComment(ctx, item.Repository, item.Number, m.Text); err != nil {
+			return state.Item{}, err
+		}
+		detail, err := client.FetchIssueDetail(ctx, item.Repository, item.Number)
+		if err != nil {
+			return state.Item{}, err
+		}
+		item.Description = detail.Description
+		item.Comments = append([]state.Comment(nil), detail.Comments...)
+		return item, nil
+	default:
+		return state.Item{}, fmt.Errorf("unknown mutation kind: %q", m.Kind)
+	}
+}
+
+// MutationCmd sends m to GitHub and reports success through done. When GitHub
+// cannot be reached the mutation comes back as MutationQueuedMsg instead so it
+// can be journaled and replayed later; any other failure is an ErrMsg that
+// asks for the optimistic change to be rolled back.
+func MutationCmd(client github.Client, m state.Mutation, done func(state.Item) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := ExecuteMutation(context.Background(), client, m)
+		if err != nil {
+			if github.IsConnectivityError(err) {
+				return MutationQueuedMsg{Mutation: m, Err: err}
+			}
+			return ErrMsg{Err: err, Rollback: &m}
+		}
+		return done(updated)
+	}
+}
+
+// BulkMutationCmd sends mutations to GitHub with at most BulkParallelism in
+// flight and reports every outcome together once all of them have finished.
+func BulkMutationCmd(client github.Client, mutations []state.Mutation) tea.Cmd {
+	return func() tea.Msg {
+		results := make([]BulkResult, len(mutations))
+		sem := make(chan struct{}, BulkParallelism)
+		var wg sync.WaitGroup
+		for i, m := range mutations {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				updated, err := ExecuteMutation(context.Background(), client, m)
+				results[i] = BulkResult{Mutation: m, Item: updated, Err: err}
+			}()
+		}
+		wg.Wait()
+		return BulkMutationMsg{Results: results}
+	}
+}
+
+// ReplayMutationCmd sends a queued mutation to GitHub.
+func ReplayMutationCmd(client github.Client, m state.Mutation) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := ExecuteMutation(context.Background(), client, m)
+		return MutationReplayedMsg{Mutation: m, Item: updated, Err: err}
+	}
+}
+
+// journalVersion orders journal rewrites. Each SaveJournalCmd takes the next
+// version when it is created, which is the order the pending mutations
+// changed in, so a rewrite that runs late cannot replace a newer one.
+var journalVersion atomic.Uint64
+
+// SaveJournalCmd durably rewrites the journal with the mutations still
+// awaiting replay.
+func SaveJournalCmd(path string, mutations []state.Mutation) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	mutations = append([]state.Mutation(nil), mutations...)
+	version := journalVersion.Add(1)
+	return func() tea.Msg {
+		if err := journal.SaveVersion(path, version, mutations); err != nil {
+			return NewErrMsg(err)
+		}
+		return nil
+	}
+}
+
+// ReconnectCmd schedules the next connectivity check for the offline period that began at since.
+func ReconnectCmd(since time.Time) tea.Cmd {
+	return tea.Tick(ReconnectInterval, func(time.Time) tea.Msg {
+		return ReconnectMsg{Since: since}
+	})
+}
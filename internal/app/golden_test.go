@@ -0,0 +1,187 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"project-hub/internal/state"
+)
+
+// Run `go test ./internal/app -run TestGolden -update` to rewrite the golden
+// files after an intended visual change, then review the diff.
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// goldenScript is a sequence of messages fed to App.Update before View is
+// compared. Commands returned by Update are not run, so scripts stay
+// deterministic and never reach the client.
+type goldenScript []tea.Msg
+
+// keys turns each rune of s into a key press.
+func keys(s string) goldenScript {
+	var msgs goldenScript
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return msgs
+}
+
+// key presses a named key such as "enter" or "esc".
+func key(name string) goldenScript {
+	switch name {
+	case "enter":
+		return goldenScript{tea.KeyMsg{Type: tea.KeyEnter}}
+	case "esc":
+		return goldenScript{tea.KeyMsg{Type: tea.KeyEsc}}
+	case "tab":
+		return goldenScript{tea.KeyMsg{Type: tea.KeyTab}}
+	default:
+		panic("golden: unknown key " + name)
+	}
+}
+
+func resize(width, height int) goldenScript {
+	return goldenScript{tea.WindowSizeMsg{Width: width, Height: height}}
+}
+
+func script(parts ...goldenScript) goldenScript {
+	var msgs goldenScript
+	for _, p := range parts {
+		msgs = append(msgs, p...)
+	}
+	return msgs
+}
+
+func goldenModel() state.Model {
+	created := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	return state.Model{
+		Project: state.Project{
+			ID:    "7",
+			Owner: "acme",
+			Name:  "Roadmap",
+			Fields: []state.Field{{
+				ID:   "PVTSSF_status",
+				Name: "Status",
+				Options: []state.Option{
+					{ID: "o1", Name: "Backlog"},
+					{ID: "o2", Name: "In Progress"},
+					{ID: "o3", Name: "Done"},
+				},
+			}},
+		},
+		Items: []state.Item{
+			{ID: "PVTI_1", Type: "Issue", Title: "Login page rejects valid passwords", Status: "Backlog", Repository: "acme/app", Number: 3, Assignees: []string{"alice"}, Labels: []string{"bug"}, Priority: "High", Milestone: "v1.0", CreatedAt: &created},
+			{ID: "PVTI_2", Type: "Issue", Title: "Add dark mode", Status: "Backlog", Repository: "acme/app", Number: 5, Labels: []string{"enhancement"}, Priority: "Low", CreatedAt: &created},
+			{ID: "PVTI_3", Type: "PullRequest", Title: "Cache project snapshots on disk", Status: "In Progress", Repository: "acme/app", Number: 8, Assignees: []string{"bob"}, Milestone: "v1.0", CreatedAt: &created},
+			{ID: "PVTI_4", Type: "DraftIssue", Title: "Write release notes", Status: "Done", Description: "Summarise the changes for v1.0.", CreatedAt: &created},
+		},
+		View: state.ViewContext{
+			CurrentView:         state.ViewBoard,
+			Mode:                state.ModeNormal,
+			FocusedItemID:       "PVTI_1",
+			CardFieldVisibility: state.DefaultCardFieldVisibility(),
+		},
+		SuppressHints: true,
+	}
+}
+
+func TestGolden(t *testing.T) {
+	prevProfile := lipgloss.ColorProfile()
+	prevDark := lipgloss.HasDarkBackground()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	lipgloss.SetHasDarkBackground(true)
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(prevProfile)
+		lipgloss.SetHasDarkBackground(prevDark)
+	})
+
+	tests := []struct {
+		name   string
+		script goldenScript
+	}{
+		{"board", resize(100, 30)},
+		{"board_navigate", script(resize(100, 30), keys("jl"))},
+		{"board_narrow", resize(60, 24)},
+		{"table", script(resize(120, 30), keys("2"))},
+		{"table_navigate", script(resize(120, 30), keys("2jj"))},
+		{"table_resized", script(resize(120, 30), keys("2"), resize(80, 20))},
+		{"detail", script(resize(100, 30), keys("o"))},
+		{"detail_draft", script(resize(100, 30), keys("lllo"))},
+		{"detail_closed", script(resize(100, 30), keys("o"), key("esc"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model tea.Model = New(goldenModel(), &noopClient{}, 100)
+			for _, msg := range tt.script {
+				model, _ = model.Update(msg)
+			}
+			view := model.View()
+			assertGolden(t, tt.name+".ansi.golden", view)
+			assertGolden(t, tt.name+".golden", stripANSI(view))
+		})
+	}
+}
+
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Fatalf("%s differs from golden file; run with -update if the change is intended\n%s", name, lineDiff(string(want), got))
+	}
+}
+
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// lineDiff reports the first differing lines so failures are readable
+// without an external diff tool.
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	var b strings.Builder
+	shown := 0
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w == g {
+			continue
+		}
+		fmt.Fprintf(&b, "line %d:\n  want: %q\n  got:  %q\n", i+1, w, g)
+		shown++
+		if shown == 5 {
+			b.WriteString("  ...\n")
+			break
+		}
+	}
+	return b.String()
+}
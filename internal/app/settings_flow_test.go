@@ -8,6 +8,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"project-hub/internal/config"
+	"project-hub/internal/github"
 	"project-hub/internal/state"
 	"project-hub/internal/ui/settings"
 )
@@ -22,6 +23,14 @@ func (m *mockClient) FetchItems(ctx context.Context, projectID string, owner str
 	return nil, nil
 }
 
+func (m *mockClient) FetchProjectMetadata(ctx context.Context, projectID string, owner string) (state.Project, error) {
+	return state.Project{}, nil
+}
+
+func (m *mockClient) FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (github.ItemsPage, error) {
+	return github.ItemsPage{}, nil
+}
+
 func (m *mockClient) CreateIssue(ctx context.Context, projectID string, owner string, repo string, title string, body string) (state.Item, error) {
 	return state.Item{}, nil
 }
@@ -34,7 +43,15 @@ func (m *mockClient) UpdateField(ctx context.Context, projectID string, owner st
 	return state.Item{}, nil
 }
 
-func (m *mockClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, labels []string) (state.Item, error) {
+func (m *mockClient) UpdateFieldValue(ctx context.Context, projectID string, owner string, itemID string, fieldID string, fieldType state.FieldType, value string) (state.Item, error) {
+	return state.Item{}, nil
+}
+
+func (m *mockClient) UpdateIteration(ctx context.Context, projectID string, owner string, itemID string, fieldID string, iterationID string) (state.Item, error) {
+	return state.Item{}, nil
+}
+
+func (m *mockClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error) {
 	return state.Item{}, nil
 }
 
@@ -42,11 +59,11 @@ func (m *mockClient) UpdateMilestone(ctx context.Context, projectID string, owne
 	return state.Item{}, nil
 }
 
-func (m *mockClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, userLogins []string) (state.Item, error) {
+func (m *mockClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error) {
 	return state.Item{}, nil
 }
 
-func (m *mockClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description string) (state.Item, error) {
+func (m *mockClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description *string) (state.Item, error) {
 	return state.Item{}, nil
 }
 
@@ -62,6 +79,10 @@ func (m *mockClient) FetchIssueDetail(ctx context.Context, repo string, number i
 	return state.Item{}, nil
 }
 
+func (m *mockClient) FetchRepoMetadata(ctx context.Context, repo string) (state.RepoMetadata, error) {
+	return state.RepoMetadata{}, nil
+}
+
 func TestSwitchToSettingsView(t *testing.T) {
 	initialState := state.Model{
 		Project: state.Project{ID: "1", Owner: "User"},
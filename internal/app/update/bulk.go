@@ -0,0 +1,291 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/github"
+	"project-hub/internal/state"
+	boardPkg "project-hub/internal/ui/board"
+)
+
+// ToggleSelection adds the focused item to the bulk selection, or removes it
+// when it is already selected. The item becomes the anchor of the next range.
+func ToggleSelection(s State) (State, tea.Cmd) {
+	id := s.Model.View.FocusedItemID
+	if id == "" {
+		return s, nil
+	}
+	selection := copySelection(s.Model.View.Selection)
+	if selection[id] {
+		delete(selection, id)
+	} else {
+		selection[id] = true
+	}
+	s.Model.View.Selection = selection
+	s.Model.View.SelectionAnchor = id
+	return s, nil
+}
+
+// SelectRange selects every item shown between the anchor and the focused
+// item, in the order the current view lists them. Without an anchor only the
+// focused item is selected.
+func SelectRange(s State) (State, tea.Cmd) {
+	focused := s.Model.View.FocusedItemID
+	if focused == "" {
+		return s, nil
+	}
+	anchor := s.Model.View.SelectionAnchor
+	if anchor == "" {
+		anchor = focused
+	}
+
+	ids := displayedItemIDs(s)
+	from, to := -1, -1
+	for i, id := range ids {
+		if id == anchor {
+			from = i
+		}
+		if id == focused {
+			to = i
+		}
+	}
+	if to < 0 {
+		return s, nil
+	}
+	if from < 0 {
+		from = to
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	selection := copySelection(s.Model.View.Selection)
+	for _, id := range ids[from : to+1] {
+		selection[id] = true
+	}
+	s.Model.View.Selection = selection
+	s.Model.View.SelectionAnchor = focused
+	return s, nil
+}
+
+// SelectAllMatching selects every item the current filter keeps.
+func SelectAllMatching(s State) (State, tea.Cmd) {
+	selection := copySelection(s.Model.View.Selection)
+	for _, id := range displayedItemIDs(s) {
+		selection[id] = true
+	}
+	s.Model.View.Selection = selection
+	if !s.Model.SuppressHints {
+		notif := state.Notification{Message: fmt.Sprintf("%d items selected", len(selection)), Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	return s, nil
+}
+
+// ClearSelection empties the bulk selection.
+func ClearSelection(s State) (State, tea.Cmd) {
+	s.Model.View.Selection = nil
+	s.Model.View.SelectionAnchor = ""
+	return s, nil
+}
+
+// copySelection returns a copy of selection that can be changed without
+// touching the state it came from.
+func copySelection(selection map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(selection)+1)
+	for id, selected := range selection {
+		if selected {
+			copied[id] = true
+		}
+	}
+	return copied
+}
+
+// displayedItemIDs lists the IDs of the items the board or table shows, top
+// to bottom and column by column.
+func displayedItemIDs(s State) []string {
+	var ids []string
+	if s.Model.View.CurrentView == state.ViewBoard {
+		for _, col := range s.BoardModel.Columns {
+			for _, card := range col.Cards {
+				ids = append(ids, card.ID)
+			}
+		}
+		return ids
+	}
+
+	items := state.ApplyFilter(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, time.Now())
+	items = state.ApplyTableSort(items, s.Model.Project.Fields, s.Model.View.TableSort)
+	var groups []boardPkg.GroupBucket
+	switch strings.ToLower(strings.TrimSpace(s.Model.View.TableGroupBy)) {
+	case core.GroupByStatus:
+		groups = boardPkg.GroupItemsByStatusBuckets(items, s.Model.Project.Fields)
+	case core.GroupByIteration:
+		groups = boardPkg.GroupItemsByIteration(items)
+	case core.GroupByAssignee:
+		groups = boardPkg.GroupItemsByAssignee(items)
+	default:
+		groups = []boardPkg.GroupBucket{{Items: items}}
+	}
+	for _, group := range groups {
+		for _, item := range group.Items {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// hasSelection reports whether edits should apply to the bulk selection
+// instead of the focused item.
+func hasSelection(s State) bool {
+	return len(s.Model.View.Selection) > 0
+}
+
+// validProjectItem checks that item can be mutated through the project API.
+func validProjectItem(item state.Item) error {
+	if item.ID == "" || !strings.HasPrefix(item.ID, "PVTI_") {
+		return fmt.Errorf("invalid item ID format: %s", item.ID)
+	}
+	return nil
+}
+
+// bulkEdit builds a mutation for every selected item and sends them together.
+// Items build rejects are reported as failures, and items the mutation would
+// not change are skipped. Offline, the mutations are queued as one batch.
+func bulkEdit(s State, build func(state.Item) (state.Mutation, error)) (State, tea.Cmd) {
+	var selected []state.Item
+	for _, item := range s.Model.Items {
+		if s.Model.View.Selection[item.ID] {
+			selected = append(selected, item)
+		}
+	}
+	return bulkEditItems(s, selected, build)
+}
+
+// bulkEditItems is bulkEdit for the given items instead of the selection.
+// The edits are undone together.
+func bulkEditItems(s State, items []state.Item, build func(state.Item) (state.Mutation, error)) (State, tea.Cmd) {
+	var mutations []state.Mutation
+	var rejected []core.BulkResult
+	for _, item := range items {
+		m, err := build(item)
+		if err != nil {
+			rejected = append(rejected, core.BulkResult{Mutation: state.Mutation{Before: item}, Err: err})
+			continue
+		}
+		if m.Value(item) == m.Value(m.Apply(item)) {
+			continue
+		}
+		mutations = append(mutations, m)
+	}
+	if len(mutations) > 0 {
+		s.Model.UndoStack = state.PushUndo(s.Model.UndoStack, state.UndoEntry{Mutations: mutations})
+		s.Model.RedoStack = nil
+	}
+	return sendBulk(s, mutations, rejected)
+}
+
+// sendBulk applies mutations locally and sends them to GitHub together, or
+// queues them as one batch when offline. rejected are reported alongside the
+// outcome as failures.
+func sendBulk(s State, mutations []state.Mutation, rejected []core.BulkResult) (State, tea.Cmd) {
+	if len(mutations) > 0 && (s.Model.Offline || len(s.Model.PendingMutations) > 0) {
+		var cmds []tea.Cmd
+		for _, m := range mutations {
+			var journalCmd tea.Cmd
+			s, journalCmd = enqueueMutation(s, m)
+			cmds = append(cmds, journalCmd)
+		}
+		message := fmt.Sprintf("Queued %d changes (%d pending)", len(mutations), len(s.Model.PendingMutations))
+		level := "warn"
+		if len(rejected) > 0 {
+			message += fmt.Sprintf("; %d failed: %v", len(rejected), rejected[0].Err)
+			level = "error"
+		}
+		notif := state.Notification{Message: message, Level: level, At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+
+		var replayCmd tea.Cmd
+		s, replayCmd = startReplay(s)
+		cmds = append(cmds, replayCmd)
+		return s, tea.Batch(cmds...)
+	}
+
+	for _, m := range mutations {
+		s = applyMutationLocally(s, m)
+	}
+	send := core.BulkMutationCmd(s.Github, mutations)
+	return s, func() tea.Msg {
+		msg := send().(core.BulkMutationMsg)
+		msg.Results = append(msg.Results, rejected...)
+		return msg
+	}
+}
+
+// BulkMutationDone applies the outcome of a bulk edit and reports it in one
+// notification. Mutations that could not reach GitHub are queued for replay;
+// any other failure is rolled back.
+func BulkMutationDone(s State, msg core.BulkMutationMsg) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	updated, queued := 0, 0
+	var failures []error
+	for _, r := range msg.Results {
+		switch {
+		case r.Err == nil:
+			for i := range s.Model.Items {
+				if s.Model.Items[i].ID == r.Mutation.ItemID() {
+					s.Model.Items[i] = mergeUpdatedItem(s.Model.Items[i], r.Item)
+				}
+			}
+			updated++
+		case r.Mutation.Kind != "" && github.IsConnectivityError(r.Err):
+			var offlineCmd, journalCmd tea.Cmd
+			s, offlineCmd = goOffline(s)
+			s, journalCmd = enqueueMutation(s, r.Mutation)
+			cmds = append(cmds, offlineCmd, journalCmd)
+			queued++
+		default:
+			if r.Mutation.Kind != "" {
+				s, _ = revertMutation(s, r.Mutation)
+			}
+			failures = append(failures, r.Err)
+		}
+	}
+	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+
+	total := len(msg.Results)
+	message, level := fmt.Sprintf("Updated %d items", updated), "info"
+	if total == 1 {
+		message = "Updated 1 item"
+	}
+	switch {
+	case len(failures) > 0:
+		message, level = fmt.Sprintf("Updated %d of %d items; %d failed: %v", updated, total, len(failures), failures[0]), "error"
+		if queued > 0 {
+			message += fmt.Sprintf("; %d queued", queued)
+		}
+	case queued > 0:
+		message, level = fmt.Sprintf("Updated %d of %d items; %d queued until GitHub is reachable", updated, total, queued), "warn"
+	case total == 0:
+		message = "Selected items already have that value"
+	}
+	if level != "info" || !s.Model.SuppressHints {
+		notif := state.Notification{Message: message, Level: level, At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	}
+
+	if queued > 0 {
+		var replayCmd tea.Cmd
+		s, replayCmd = startReplay(s)
+		cmds = append(cmds, replayCmd)
+	}
+	return s, tea.Batch(cmds...)
+}
@@ -0,0 +1,161 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+// bulkClient is safe for the concurrent calls of a bulk edit and records how
+// many of them overlapped.
+type bulkClient struct {
+	mockClient
+	mu       sync.Mutex
+	fail     map[string]error
+	calls    int
+	inFlight int
+	peak     int
+}
+
+func (c *bulkClient) UpdateStatus(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string) (state.Item, error) {
+	c.mu.Lock()
+	c.calls++
+	c.inFlight++
+	if c.inFlight > c.peak {
+		c.peak = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	return state.Item{ID: itemID, Status: "Done"}, c.fail[itemID]
+}
+
+func bulkTestState(t *testing.T, client *bulkClient, n int) State {
+	t.Helper()
+	model := state.Model{
+		Project:       state.Project{ID: "1", Owner: "acme"},
+		View:          state.ViewContext{CurrentView: state.ViewTable, Mode: state.ModeNormal},
+		JournalPath:   filepath.Join(t.TempDir(), "acme-1.jsonl"),
+		SuppressHints: true,
+	}
+	for i := 0; i < n; i++ {
+		id := "PVTI_" + string(rune('a'+i))
+		model.Items = append(model.Items, state.Item{ID: id, Title: "Item " + id, Status: "Todo", Type: "Issue", Repository: "acme/app", Number: i + 1})
+	}
+	model.View.FocusedItemID = model.Items[0].ID
+	return NewState(model, client, 100)
+}
+
+func focusItem(s State, idx int) State {
+	s.Model.View.FocusedIndex = idx
+	s.Model.View.FocusedItemID = s.Model.Items[idx].ID
+	return s
+}
+
+func TestSelectionKeys(t *testing.T) {
+	s := bulkTestState(t, &bulkClient{}, 5)
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeySpace})
+	s = focusItem(s, 3)
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	if len(s.Model.View.Selection) != 4 || s.Model.View.Selection["PVTI_e"] {
+		t.Fatalf("expected the range a-d selected, got %v", s.Model.View.Selection)
+	}
+
+	s = focusItem(s, 1)
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeySpace})
+	if len(s.Model.View.Selection) != 3 || s.Model.View.Selection["PVTI_b"] {
+		t.Fatalf("expected space to deselect b, got %v", s.Model.View.Selection)
+	}
+
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "PVTI_e"})
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if len(s.Model.View.Selection) != 4 || !s.Model.View.Selection["PVTI_e"] {
+		t.Fatalf("expected the matching item added to the selection, got %v", s.Model.View.Selection)
+	}
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(s.Model.View.Selection) != 0 {
+		t.Fatalf("expected esc to clear the selection, got %v", s.Model.View.Selection)
+	}
+	if s.Model.View.Filter.Query == "" {
+		t.Fatalf("expected the first esc to keep the filter")
+	}
+}
+
+func TestBulkStatusChangeReportsOnce(t *testing.T) {
+	client := &bulkClient{fail: map[string]error{"PVTI_c": errors.New("field is locked")}}
+	s := bulkTestState(t, client, 10)
+	s, _ = SelectAllMatching(s)
+
+	s, cmd := StatusSelectMode(s, components.StatusSelectedMsg{StatusFieldID: "F_status", OptionID: "opt-done", OptionName: "Done"})
+	for _, item := range s.Model.Items {
+		if item.Status != "Done" {
+			t.Fatalf("expected every selected item moved optimistically, got %+v", item)
+		}
+	}
+
+	msg, ok := cmd().(core.BulkMutationMsg)
+	if !ok {
+		t.Fatalf("expected BulkMutationMsg, got %T", cmd())
+	}
+	if len(msg.Results) != 10 || client.calls != 10 {
+		t.Fatalf("expected ten mutations, got %d results and %d calls", len(msg.Results), client.calls)
+	}
+	if client.peak > core.BulkParallelism || client.peak < 2 {
+		t.Fatalf("expected between 2 and %d concurrent calls, got %d", core.BulkParallelism, client.peak)
+	}
+
+	before := len(s.Model.Notifications)
+	s, _ = BulkMutationDone(s, msg)
+	if got := len(s.Model.Notifications) - before; got != 1 {
+		t.Fatalf("expected one aggregated notification, got %d", got)
+	}
+	notif := s.Model.Notifications[len(s.Model.Notifications)-1]
+	if notif.Level != "error" || !strings.Contains(notif.Message, "Updated 9 of 10 items; 1 failed: field is locked") {
+		t.Fatalf("unexpected report %+v", notif)
+	}
+	if s.Model.Items[2].Status != "Todo" {
+		t.Fatalf("expected the failed item rolled back, got %q", s.Model.Items[2].Status)
+	}
+}
+
+func TestBulkEditQueuesWhileOffline(t *testing.T) {
+	client := &bulkClient{}
+	s := bulkTestState(t, client, 3)
+	s.Model.Offline = true
+	s, _ = SelectAllMatching(s)
+
+	before := len(s.Model.Notifications)
+	s, _ = SaveLabelsInput(s, SaveLabelsInputMsg{Labels: "bug"})
+	if len(s.Model.PendingMutations) != 3 {
+		t.Fatalf("expected three queued mutations, got %d", len(s.Model.PendingMutations))
+	}
+	ids := map[string]bool{}
+	for _, m := range s.Model.PendingMutations {
+		ids[m.ID] = true
+	}
+	if len(ids) != 3 {
+		t.Fatalf("expected distinct mutation IDs, got %v", ids)
+	}
+	if got := len(s.Model.Notifications) - before; got != 1 || !strings.HasPrefix(s.Model.Notifications[before].Message, "Queued 3 changes") {
+		t.Fatalf("expected a single queued notice, got %+v", s.Model.Notifications[before:])
+	}
+	if client.calls != 0 {
+		t.Fatalf("expected no calls while offline, got %d", client.calls)
+	}
+}
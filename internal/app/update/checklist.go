@@ -0,0 +1,193 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+// openChecklist opens the labels or assignees checklist for the selected
+// items, or else the focused one, and starts loading the values their
+// repositories offer.
+func openChecklist(s State, kind state.MutationKind) (State, tea.Cmd) {
+	var targets []state.Item
+	if hasSelection(s) {
+		for _, item := range s.Model.Items {
+			if s.Model.View.Selection[item.ID] {
+				targets = append(targets, item)
+			}
+		}
+	} else {
+		idx := s.Model.View.FocusedIndex
+		if idx < 0 || idx >= len(s.Model.Items) {
+			return s, nil
+		}
+		item := s.Model.Items[idx]
+		if err := validValuesTarget(kind, item); err != nil {
+			notif := state.Notification{Message: err.Error(), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+			s.Model.Notifications = append(s.Model.Notifications, notif)
+			return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+		}
+		targets = []state.Item{item}
+	}
+
+	name := "Labels"
+	if kind == state.MutationAssignees {
+		name = "Assignees"
+	}
+	title := fmt.Sprintf("%s of '%s':", name, targets[0].Title)
+	if len(targets) > 1 {
+		title = fmt.Sprintf("%s of %d selected items:", name, len(targets))
+	}
+
+	var values [][]string
+	var repos []string
+	seen := map[string]bool{}
+	for _, item := range targets {
+		values = append(values, itemValues(kind, item))
+		if item.Repository != "" && !seen[item.Repository] {
+			seen[item.Repository] = true
+			repos = append(repos, item.Repository)
+		}
+	}
+
+	s.Checklist = components.NewChecklistModel(kind, title, values, repos, s.Model.Width)
+	s.Model.View.Mode = state.ModeChecklist
+	var cmds []tea.Cmd
+	for _, repo := range repos {
+		if meta, ok := s.Model.Repos[repo]; ok {
+			s.Checklist = s.Checklist.Loaded(repo, meta.Values(kind), nil)
+			if !meta.Stale(time.Now()) {
+				continue
+			}
+		}
+		cmds = append(cmds, core.FetchRepoMetadataCmd(s.Github, repo))
+	}
+	return s, tea.Batch(cmds...)
+}
+
+// ChecklistMode routes input to the open checklist and saves the values
+// ticked and cleared in it.
+func ChecklistMode(s State, msg tea.Msg) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	updated, checklistCmd := s.Checklist.Update(msg)
+	s.Checklist = updated.(components.ChecklistModel)
+	if checklistCmd != nil {
+		cmds = append(cmds, checklistCmd)
+	}
+
+	m, ok := msg.(components.ChecklistConfirmedMsg)
+	if !ok {
+		return s, tea.Batch(cmds...)
+	}
+	s.Model.View.Mode = state.ModeNormal
+	if m.Canceled || (len(m.Add) == 0 && len(m.Remove) == 0) {
+		return s, tea.Batch(cmds...)
+	}
+	updatedState, saveCmd := setValues(s, m.Kind, func(item state.Item) []string {
+		return state.PatchValues(itemValues(m.Kind, item), m.Add, m.Remove)
+	})
+	return updatedState, tea.Batch(append(cmds, saveCmd)...)
+}
+
+// RepoMetadataFetched caches the labels, milestones and assignable users of
+// a repository and adds them to the open checklist. While a refresh is
+// running the items are only partly loaded, so the snapshot is left for the
+// refresh to save once its last page is in.
+func RepoMetadataFetched(s State, msg core.RepoMetadataMsg) (State, tea.Cmd) {
+	if s.Model.View.Mode == state.ModeChecklist {
+		s.Checklist = s.Checklist.Loaded(msg.Repo, msg.Metadata.Values(s.Checklist.Kind), msg.Err)
+	}
+	if msg.Err != nil {
+		return s, nil
+	}
+	repos := make(map[string]state.RepoMetadata, len(s.Model.Repos)+1)
+	for repo, meta := range s.Model.Repos {
+		repos[repo] = meta
+	}
+	repos[msg.Repo] = msg.Metadata
+	s.Model.Repos = repos
+	if !canSaveSnapshot(s) {
+		return s, nil
+	}
+	return s, core.SaveSnapshotCmd(s.Model.CachePath, s.Model.Project, s.Model.Items, s.Model.Repos)
+}
+
+// fetchStaleRepoMetadata fetches the metadata of each repository with items
+// in the project that is not cached or has gone stale.
+func fetchStaleRepoMetadata(s State) tea.Cmd {
+	now := time.Now()
+	var cmds []tea.Cmd
+	for _, repo := range state.Repositories(s.Model.Items) {
+		if meta, ok := s.Model.Repos[repo]; ok && !meta.Stale(now) {
+			continue
+		}
+		cmds = append(cmds, core.FetchRepoMetadataCmd(s.Github, repo))
+	}
+	return tea.Batch(cmds...)
+}
+
+// setValues replaces the labels or assignees of the selected items, or else
+// the focused item, with what values returns for each. GitHub is sent the
+// difference from what the item has now.
+func setValues(s State, kind state.MutationKind, values func(state.Item) []string) (State, tea.Cmd) {
+	s.Model.View.Mode = state.ModeNormal
+	if hasSelection(s) {
+		return bulkEdit(s, func(item state.Item) (state.Mutation, error) {
+			m := newMutation(s, kind, item)
+			m.Values = values(item)
+			return m, validValuesTarget(kind, item)
+		})
+	}
+
+	idx := s.Model.View.FocusedIndex
+	if idx < 0 || idx >= len(s.Model.Items) {
+		return s, nil
+	}
+	item := s.Model.Items[idx]
+	if err := validValuesTarget(kind, item); err != nil {
+		return s, func() tea.Msg {
+			return core.NewErrMsg(err)
+		}
+	}
+
+	m := newMutation(s, kind, item)
+	m.Values = values(item)
+	if add, remove := state.DiffValues(itemValues(kind, item), m.Values); len(add) == 0 && len(remove) == 0 {
+		return s, nil
+	}
+	return dispatchMutation(s, m, func(updatedItem state.Item) tea.Msg {
+		return core.ItemUpdatedMsg{ItemID: m.ItemID(), Item: updatedItem}
+	})
+}
+
+// itemValues returns the labels or assignees of item.
+func itemValues(kind state.MutationKind, item state.Item) []string {
+	if kind == state.MutationAssignees {
+		return item.Assignees
+	}
+	return item.Labels
+}
+
+// validValuesTarget checks that item can have labels or assignees.
+func validValuesTarget(kind state.MutationKind, item state.Item) error {
+	isIssue := item.Type == "Issue" || item.Type == "PullRequest"
+	if kind == state.MutationAssignees {
+		if !isIssue {
+			return fmt.Errorf("cannot assign to item of type: %s (only Issues and PullRequests can be assigned)", item.Type)
+		}
+		return nil
+	}
+	if err := validProjectItem(item); err != nil {
+		return err
+	}
+	if !isIssue {
+		return fmt.Errorf("cannot edit labels for item of type: %s (only Issues and PullRequests can have labels)", item.Type)
+	}
+	return nil
+}
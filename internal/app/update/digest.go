@@ -0,0 +1,123 @@
+package update
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+// maxDigestCommentFetches caps how many issues are asked for comments each
+// time the digest is refreshed, so a long absence does not flood GitHub.
+const maxDigestCommentFetches = 20
+
+// EnterDigestView switches to the digest of changes since the last session.
+func EnterDigestView(s State) (State, tea.Cmd) {
+	s.Model.View.CurrentView = state.ViewDigest
+	s.Model.View.DigestIndex = 0
+	return refreshDigest(s)
+}
+
+// refreshDigest rebuilds the digest from the baseline and, while the digest is
+// shown, fetches comments for issues updated since the baseline. Items
+// narrowed by a filter query would show the rest of the project as removed,
+// so the digest built from the last full fetch is kept.
+func refreshDigest(s State) (State, tea.Cmd) {
+	if s.Model.ItemsNarrowed {
+		return s, nil
+	}
+	if s.Model.BaselineAt == nil {
+		s.Model.Digest = nil
+		return s, nil
+	}
+	since := *s.Model.BaselineAt
+	s.Model.Digest = state.BuildDigest(s.Model.Baseline, s.Model.Items, since, s.Model.DigestComments)
+	s.Model.View.DigestIndex = clampIndex(s.Model.View.DigestIndex, len(s.Model.Digest))
+	if s.Model.View.CurrentView != state.ViewDigest {
+		return s, nil
+	}
+
+	var cmds []tea.Cmd
+	for _, item := range s.Model.Items {
+		if len(cmds) >= maxDigestCommentFetches {
+			break
+		}
+		if item.Type != "Issue" || item.Repository == "" || item.Number <= 0 || !state.UpdatedSince(item, since) {
+			continue
+		}
+		if _, fetched := s.Model.DigestComments[item.ID]; fetched {
+			continue
+		}
+		if s.Model.DigestComments == nil {
+			s.Model.DigestComments = make(map[string][]state.Comment)
+		}
+		// Mark as requested so later refreshes do not ask again.
+		s.Model.DigestComments[item.ID] = nil
+		cmds = append(cmds, core.FetchDigestCommentsCmd(s.Github, item))
+	}
+	return s, tea.Batch(cmds...)
+}
+
+// DigestCommentsFetched adds an item's new comments to the digest. Failures
+// are dropped; the digest is best-effort and the item's other changes remain.
+func DigestCommentsFetched(s State, msg core.DigestCommentsMsg) State {
+	if msg.Err != nil {
+		return s
+	}
+	if s.Model.DigestComments == nil {
+		s.Model.DigestComments = make(map[string][]state.Comment)
+	}
+	s.Model.DigestComments[msg.ItemID] = msg.Comments
+	if s.Model.BaselineAt != nil {
+		s.Model.Digest = state.BuildDigest(s.Model.Baseline, s.Model.Items, *s.Model.BaselineAt, s.Model.DigestComments)
+		s.Model.View.DigestIndex = clampIndex(s.Model.View.DigestIndex, len(s.Model.Digest))
+	}
+	return s
+}
+
+// DigestKey handles navigation in the digest view. It reports false for keys
+// the global key handler should process instead.
+func DigestKey(s State, key string) (State, tea.Cmd, bool) {
+	switch key {
+	case "j", "down":
+		s.Model.View.DigestIndex = clampIndex(s.Model.View.DigestIndex+1, len(s.Model.Digest))
+	case "k", "up":
+		s.Model.View.DigestIndex = clampIndex(s.Model.View.DigestIndex-1, len(s.Model.Digest))
+	case "g":
+		s.Model.View.DigestIndex = 0
+	case "G", "shift+G":
+		s.Model.View.DigestIndex = clampIndex(len(s.Model.Digest)-1, len(s.Model.Digest))
+	case "o", "enter":
+		return openDigestEntry(s)
+	case "1", "b", "2", "t", "3", "4", "5", "6", "7", "R", "u", "ctrl+r", "!", "q", "ctrl+c":
+		return s, nil, false
+	}
+	return s, nil, true
+}
+
+func openDigestEntry(s State) (State, tea.Cmd, bool) {
+	idx := s.Model.View.DigestIndex
+	if idx < 0 || idx >= len(s.Model.Digest) {
+		return s, nil, true
+	}
+	id := s.Model.Digest[idx].Item.ID
+	for i, item := range s.Model.Items {
+		if item.ID == id {
+			s.Model.View.FocusedIndex = i
+			s.Model.View.FocusedItemID = id
+			s, cmd := EnterDetailMode(s)
+			return s, cmd, true
+		}
+	}
+	return s, nil, true
+}
+
+func clampIndex(idx, n int) int {
+	if idx >= n {
+		idx = n - 1
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	return idx
+}
@@ -0,0 +1,82 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+type digestClient struct {
+	mockClient
+	requested []int
+}
+
+func (d *digestClient) FetchIssueDetail(ctx context.Context, repo string, number int) (state.Item, error) {
+	d.requested = append(d.requested, number)
+	at := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	return state.Item{Comments: []state.Comment{{Author: "bob", Body: "Looks good", CreatedAt: &at}}}, nil
+}
+
+func digestTestState(client *digestClient) State {
+	since := time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC)
+	updated := since.Add(time.Hour)
+	model := state.Model{
+		Baseline:   []state.Item{{ID: "1", Status: "Todo", Type: "Issue"}, {ID: "2", Status: "Todo", Type: "Issue"}},
+		BaselineAt: &since,
+		Items: []state.Item{
+			{ID: "1", Status: "Done", Type: "Issue", Repository: "acme/app", Number: 1},
+			{ID: "2", Status: "Todo", Type: "Issue", Repository: "acme/app", Number: 2, UpdatedAt: &updated},
+		},
+		View:          state.ViewContext{CurrentView: state.ViewBoard, Mode: state.ModeNormal},
+		SuppressHints: true,
+	}
+	return NewState(model, client, 100)
+}
+
+func TestEnterDigestViewFetchesCommentsForUpdatedIssues(t *testing.T) {
+	client := &digestClient{}
+	s := digestTestState(client)
+
+	s, cmd := EnterDigestView(s)
+	if s.Model.View.CurrentView != state.ViewDigest {
+		t.Fatalf("expected digest view, got %s", s.Model.View.CurrentView)
+	}
+	if len(s.Model.Digest) != 1 || s.Model.Digest[0].Item.ID != "1" {
+		t.Fatalf("expected only the closed item before comments arrive, got %+v", s.Model.Digest)
+	}
+	if cmd == nil {
+		t.Fatalf("expected a comment fetch for the updated issue")
+	}
+
+	s = DigestCommentsFetched(s, cmd().(core.DigestCommentsMsg))
+	if len(client.requested) != 1 || client.requested[0] != 2 {
+		t.Fatalf("expected comments requested for #2 only, got %v", client.requested)
+	}
+	if len(s.Model.Digest) != 2 || s.Model.Digest[1].Changes[0].Kind != state.DigestComment {
+		t.Fatalf("expected new comment in digest, got %+v", s.Model.Digest)
+	}
+
+	if _, cmd := refreshDigest(s); cmd != nil {
+		t.Fatalf("expected comments not to be fetched twice")
+	}
+}
+
+func TestDigestOpenJumpsToDetail(t *testing.T) {
+	s := digestTestState(&digestClient{})
+	s, _ = EnterDigestView(s)
+
+	s, _, handled := DigestKey(s, "o")
+	if !handled || s.Model.View.Mode != state.ModeDetail {
+		t.Fatalf("expected detail mode, got %s", s.Model.View.Mode)
+	}
+	if s.Model.View.FocusedItemID != "1" || s.DetailItem.ID != "1" {
+		t.Fatalf("expected detail for the digest entry, got %q", s.DetailItem.ID)
+	}
+
+	if _, _, handled := DigestKey(s, "2"); handled {
+		t.Fatalf("expected view switching keys to fall through")
+	}
+}
@@ -150,61 +150,19 @@ func CancelEdit(s State, _ CancelEditMsg) (State, tea.Cmd) {
 	return s, nil
 }
 
+// EnterAssignMode opens the assignee checklist for the selected items, or
+// else the focused item.
 func EnterAssignMode(s State, _ EnterAssignModeMsg) (State, tea.Cmd) {
-	idx := s.Model.View.FocusedIndex
-	if idx < 0 || idx >= len(s.Model.Items) {
-		return s, nil
-	}
-	item := s.Model.Items[idx]
-
-	assignee := ""
-	if len(item.Assignees) > 0 {
-		assignee = item.Assignees[0]
-	}
-
-	s.TextInput.SetValue(assignee)
-	s.TextInput.Prompt = ""
-	s.TextInput.Placeholder = "Enter assignee..."
-	s.Model.View.Mode = "assign"
-	return s, s.TextInput.Focus()
+	return openChecklist(s, state.MutationAssignees)
 }
 
+// SaveAssign sets the assignees to the comma-separated logins in msg,
+// unassigning anyone not listed.
 func SaveAssign(s State, msg SaveAssignMsg) (State, tea.Cmd) {
-	idx := s.Model.View.FocusedIndex
-	if idx < 0 || idx >= len(s.Model.Items) {
-		return s, nil
-	}
-	item := s.Model.Items[idx]
-
-	if item.Type != "Issue" && item.Type != "PullRequest" {
-		return s, func() tea.Msg {
-			return core.NewErrMsg(fmt.Errorf("cannot assign to item of type: %s (only Issues and PullRequests can be assigned)", item.Type))
-		}
-	}
-
-	cmd := func() tea.Msg {
-		userLogins := []string{}
-		if msg.Assignee != "" {
-			userLogins = append(userLogins, msg.Assignee)
-		}
-		updatedItem, err := s.Github.UpdateAssignees(
-			context.Background(),
-			core.ProjectMutationID(s.Model.Project),
-			s.Model.Project.Owner,
-			item.ID,
-			item.Type,
-			item.Repository,
-			item.Number,
-			userLogins,
-		)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		return core.ItemUpdatedMsg{Index: idx, Item: updatedItem}
-	}
-
-	s.Model.View.Mode = "normal"
-	return s, tea.Batch(cmd)
+	userLogins := splitValues(msg.Assignee)
+	return setValues(s, state.MutationAssignees, func(state.Item) []string {
+		return userLogins
+	})
 }
 
 func CancelAssign(s State, _ CancelAssignMsg) (State, tea.Cmd) {
@@ -405,23 +363,14 @@ func SaveEdit(s State, msg SaveEditMsg) (State, tea.Cmd) {
 	}
 	item := s.Model.Items[idx]
 
-	updateCmd := func() tea.Msg {
-		updatedItem, err := s.Github.UpdateItem(
-			context.Background(),
-			core.ProjectMutationID(s.Model.Project),
-			s.Model.Project.Owner,
-			item,
-			msg.Title,
-			msg.Description,
-		)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		return core.ItemUpdatedMsg{Index: idx, Item: updatedItem}
-	}
+	m := newMutation(s, state.MutationItem, item)
+	m.Title = msg.Title
+	m.Text = msg.Description
 
 	s.Model.View.Mode = state.ModeNormal
-	return s, tea.Batch(updateCmd)
+	return dispatchMutation(s, m, func(updatedItem state.Item) tea.Msg {
+		return core.ItemUpdatedMsg{ItemID: m.ItemID(), Item: updatedItem}
+	})
 }
 
 func EnterDetailEditMode(s State) (State, tea.Cmd) {
@@ -446,19 +395,16 @@ func SaveDetailEdit(s State, msg SaveDetailEditMsg) (State, tea.Cmd) {
 		}
 	}
 
-	cmd := func() tea.Msg {
-		err := s.Github.UpdateIssueBody(context.Background(), item.Repository, item.Number, msg.Description)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		return DetailEditSavedMsg{Index: idx, ItemID: item.ID, Description: msg.Description}
-	}
+	m := newMutation(s, state.MutationBody, item)
+	m.Text = msg.Description
 
 	s.DetailItem.Description = msg.Description
 	s.DetailPanel = components.NewDetailPanelModel(s.DetailItem, s.Model.Width, s.Model.Height)
 	s.TextAreaVimMode = ""
 	s.Model.View.Mode = state.ModeDetail
-	return s, tea.Batch(cmd)
+	return dispatchMutation(s, m, func(state.Item) tea.Msg {
+		return DetailEditSavedMsg{Index: idx, ItemID: item.ID, Description: msg.Description}
+	})
 }
 
 func CancelDetailEdit(s State) (State, tea.Cmd) {
@@ -498,23 +444,14 @@ func SaveDetailComment(s State, msg SaveDetailCommentMsg) (State, tea.Cmd) {
 		}
 	}
 
-	cmd := func() tea.Msg {
-		err := s.Github.AddIssueComment(context.Background(), item.Repository, item.Number, msg.Body)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		detail, err := s.Github.FetchIssueDetail(context.Background(), item.Repository, item.Number)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		item.Description = detail.Description
-		item.Comments = append([]state.Comment(nil), detail.Comments...)
-		return DetailCommentAddedMsg{Item: item}
-	}
+	m := newMutation(s, state.MutationComment, item)
+	m.Text = msg.Body
 
 	s.Model.View.Mode = state.ModeDetail
 	s.TextAreaVimMode = ""
-	return s, tea.Batch(cmd)
+	return dispatchMutation(s, m, func(updatedItem state.Item) tea.Msg {
+		return DetailCommentAddedMsg{Item: updatedItem}
+	})
 }
 
 func CancelDetailComment(s State) (State, tea.Cmd) {
@@ -561,6 +498,8 @@ func ColumnEdit(s State, msg EnterEditModeMsg) (State, tea.Cmd) {
 		return EnterMilestoneInputMode(s, EnterMilestoneInputModeMsg{})
 	case state.ColumnPriority:
 		return EnterPrioritySelectMode(s, EnterPrioritySelectModeMsg{})
+	case state.ColumnIteration:
+		return EnterIterationSelectMode(s)
 	default:
 		return EnterEditMode(s, msg)
 	}
@@ -573,15 +512,7 @@ func EnterLabelSelectMode(s State, _ EnterLabelSelectModeMsg) (State, tea.Cmd) {
 	}
 	focusedItem := s.Model.Items[idx]
 
-	var labelField state.Field
-	found := false
-	for _, field := range s.Model.Project.Fields {
-		if field.Name == "Labels" {
-			labelField = field
-			found = true
-			break
-		}
-	}
+	labelField, found := state.FindField(s.Model.Project.Fields, state.FieldTypeLabels, "Labels")
 	if !found {
 		notif := state.Notification{Message: "Labels field not found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
 		s.Model.Notifications = append(s.Model.Notifications, notif)
@@ -611,15 +542,7 @@ func EnterMilestoneSelectMode(s State, _ EnterMilestoneSelectModeMsg) (State, te
 	}
 	focusedItem := s.Model.Items[idx]
 
-	var milestoneField state.Field
-	found := false
-	for _, field := range s.Model.Project.Fields {
-		if field.Name == "Milestone" {
-			milestoneField = field
-			found = true
-			break
-		}
-	}
+	milestoneField, found := state.FindField(s.Model.Project.Fields, state.FieldTypeMilestone, "Milestone")
 	if !found {
 		notif := state.Notification{Message: "Milestone field not found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
 		s.Model.Notifications = append(s.Model.Notifications, notif)
@@ -680,64 +603,30 @@ func EnterPrioritySelectMode(s State, _ EnterPrioritySelectModeMsg) (State, tea.
 	return s, s.FieldSelector.Init()
 }
 
+// EnterLabelsInputMode opens the label checklist for the selected items, or
+// else the focused item.
 func EnterLabelsInputMode(s State, _ EnterLabelsInputModeMsg) (State, tea.Cmd) {
-	idx := s.Model.View.FocusedIndex
-	if idx < 0 || idx >= len(s.Model.Items) {
-		return s, nil
-	}
-	item := s.Model.Items[idx]
-
-	s.TextInput.Width = s.Model.Width - 10
-	if s.TextInput.Width < 30 {
-		s.TextInput.Width = 30
-	}
-	s.TextInput.SetValue(strings.Join(item.Labels, ","))
-	s.TextInput.Prompt = ""
-	s.TextInput.Placeholder = "Enter labels (comma separated)..."
-	s.Model.View.Mode = "labelsInput"
-	return s, s.TextInput.Focus()
+	return openChecklist(s, state.MutationLabels)
 }
 
+// SaveLabelsInput sets the labels to the comma-separated names in msg,
+// removing any label not listed.
 func SaveLabelsInput(s State, msg SaveLabelsInputMsg) (State, tea.Cmd) {
-	idx := s.Model.View.FocusedIndex
-	if idx < 0 || idx >= len(s.Model.Items) {
-		return s, nil
-	}
-	item := s.Model.Items[idx]
+	labels := splitValues(msg.Labels)
+	return setValues(s, state.MutationLabels, func(state.Item) []string {
+		return labels
+	})
+}
 
-	if item.ID == "" || !strings.HasPrefix(item.ID, "PVTI_") {
-		return s, func() tea.Msg {
-			return core.NewErrMsg(fmt.Errorf("invalid item ID format: %s", item.ID))
+// splitValues parses a comma-separated list, dropping blank entries.
+func splitValues(input string) []string {
+	var values []string
+	for _, v := range strings.Split(input, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
 		}
 	}
-
-	cmd := func() tea.Msg {
-		labels := strings.Split(msg.Labels, ",")
-		var trimmedLabels []string
-		for _, l := range labels {
-			l = strings.TrimSpace(l)
-			if l != "" {
-				trimmedLabels = append(trimmedLabels, l)
-			}
-		}
-		updatedItem, err := s.Github.UpdateLabels(
-			context.Background(),
-			core.ProjectMutationID(s.Model.Project),
-			s.Model.Project.Owner,
-			item.ID,
-			item.Type,
-			item.Repository,
-			item.Number,
-			trimmedLabels,
-		)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		return core.ItemUpdatedMsg{Index: idx, Item: updatedItem}
-	}
-
-	s.Model.View.Mode = "normal"
-	return s, tea.Batch(cmd)
+	return values
 }
 
 func CancelLabelsInput(s State, _ CancelLabelsInputMsg) (State, tea.Cmd) {
@@ -766,6 +655,15 @@ func EnterMilestoneInputMode(s State, _ EnterMilestoneInputModeMsg) (State, tea.
 }
 
 func SaveMilestoneInput(s State, msg SaveMilestoneInputMsg) (State, tea.Cmd) {
+	if hasSelection(s) {
+		s.Model.View.Mode = "normal"
+		return bulkEdit(s, func(item state.Item) (state.Mutation, error) {
+			m := newMutation(s, state.MutationMilestone, item)
+			m.Text = msg.Milestone
+			return m, validProjectItem(item)
+		})
+	}
+
 	idx := s.Model.View.FocusedIndex
 	if idx < 0 || idx >= len(s.Model.Items) {
 		return s, nil
@@ -778,22 +676,13 @@ func SaveMilestoneInput(s State, msg SaveMilestoneInputMsg) (State, tea.Cmd) {
 		}
 	}
 
-	cmd := func() tea.Msg {
-		updatedItem, err := s.Github.UpdateMilestone(
-			context.Background(),
-			core.ProjectMutationID(s.Model.Project),
-			s.Model.Project.Owner,
-			item.ID,
-			msg.Milestone,
-		)
-		if err != nil {
-			return core.NewErrMsg(err)
-		}
-		return core.ItemUpdatedMsg{Index: idx, Item: updatedItem}
-	}
+	m := newMutation(s, state.MutationMilestone, item)
+	m.Text = msg.Milestone
 
 	s.Model.View.Mode = "normal"
-	return s, tea.Batch(cmd)
+	return dispatchMutation(s, m, func(updatedItem state.Item) tea.Msg {
+		return core.ItemUpdatedMsg{ItemID: m.ItemID(), Item: updatedItem}
+	})
 }
 
 func CancelMilestoneInput(s State, _ CancelMilestoneInputMsg) (State, tea.Cmd) {
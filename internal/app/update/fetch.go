@@ -0,0 +1,231 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/github"
+	"project-hub/internal/state"
+	boardPkg "project-hub/internal/ui/board"
+)
+
+// RefreshMsg starts a project refresh, cancelling any refresh already in flight.
+type RefreshMsg struct{}
+
+// StartFetch cancels the in-flight refresh, if any, and starts a new one
+// under a fresh generation.
+func StartFetch(s State) (State, tea.Cmd) {
+	if s.Fetch.Cancel != nil {
+		s.Fetch.Cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Fetch = FetchState{Generation: s.Fetch.Generation + 1, Ctx: ctx, Cancel: cancel}
+	fetchCmd := core.FetchProjectCmd(ctx, s.Github, s.Fetch.Generation, s.Model.Project.ID, s.Model.Project.Owner, s.ItemLimit, github.CompileFilter(s.Model.View.Filter).Server)
+	if s.Model.Loading {
+		// The spinner is already ticking for the refresh being replaced.
+		return s, fetchCmd
+	}
+	s.Model.Loading = true
+	return s, tea.Batch(fetchCmd, s.Spinner.Tick)
+}
+
+// FetchFailed ends the current refresh. Cancelled refreshes are silent.
+func FetchFailed(s State, msg core.FetchFailedMsg) (State, tea.Cmd) {
+	s = finishFetch(s)
+	if errors.Is(msg.Err, context.Canceled) {
+		return s, nil
+	}
+	return reportError(s, msg.Err)
+}
+
+func finishFetch(s State) State {
+	if s.Fetch.Cancel != nil {
+		s.Fetch.Cancel()
+	}
+	s.Fetch.Ctx = nil
+	s.Fetch.Cancel = nil
+	s.Fetch.Previous = nil
+	s.Model.Loading = false
+	return s
+}
+
+// ProjectFetched replaces the project and items with the first page of a
+// fresh fetch and requests the next page when more items are available. The
+// focused item is kept; it may only arrive with a later page. A view asked
+// for on the command line that was not cached is opened now.
+func ProjectFetched(s State, msg core.FetchProjectMsg) (State, tea.Cmd) {
+	s.Model.Project = msg.Project
+	s.Model.Offline = false
+	s.Model.OfflineSince = nil
+	s.Fetch.Previous = s.Model.Items
+	if s.Model.ItemsNarrowed {
+		// Items missing from a narrowed fetch were not removed from the project.
+		s.Fetch.Previous = nil
+	}
+	s, items, reconcileCmd := reconcilePending(s, excludeDoneItems(s, msg.Items))
+	s.Model.Items = items
+	if s.Model.View.FocusedItemID == "" && len(s.Model.Items) > 0 {
+		s.Model.View.FocusedItemID = s.Model.Items[0].ID
+	}
+	s.Model.Stale = false
+	s.Model.CachedAt = nil
+	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	s, rejectedCmd := itemQueryRejected(s, msg.QueryIgnored)
+	s.Model.ItemsNarrowed = github.CompileFilter(s.Model.View.Filter).Server != "" && !s.Model.ItemQueryRejected
+	s, pageCmd := afterPage(s, msg.NextCursor, len(msg.Items))
+	s, viewCmd := openPendingView(s)
+	return s, tea.Batch(reconcileCmd, rejectedCmd, pageCmd, viewCmd)
+}
+
+// itemQueryRejected remembers that GitHub ignored the items query and, the
+// first time, tells the user the whole filter runs locally.
+func itemQueryRejected(s State, ignored bool) (State, tea.Cmd) {
+	if !ignored || s.Model.ItemQueryRejected {
+		return s, nil
+	}
+	s.Model.ItemQueryRejected = true
+	notif := state.Notification{Message: "GitHub does not support filtering project items; filtered locally: " + s.Model.View.Filter.Raw, Level: "warn", At: time.Now(), DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+}
+
+// ItemsPageFetched appends a follow-up page so the board and table fill in as
+// data arrives, then requests the page after it.
+func ItemsPageFetched(s State, msg core.ItemsPageMsg) (State, tea.Cmd) {
+	seen := make(map[string]struct{}, len(s.Model.Items))
+	for _, item := range s.Model.Items {
+		seen[item.ID] = struct{}{}
+	}
+	s, items, reconcileCmd := reconcilePending(s, excludeDoneItems(s, msg.Items))
+	for _, item := range items {
+		if _, ok := seen[item.ID]; ok {
+			continue
+		}
+		seen[item.ID] = struct{}{}
+		s.Model.Items = append(s.Model.Items, item)
+	}
+	if s.Model.View.FocusedItemID == "" && len(s.Model.Items) > 0 {
+		s.Model.View.FocusedItemID = s.Model.Items[0].ID
+	}
+	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	s, pageCmd := afterPage(s, msg.NextCursor, msg.Fetched)
+	return s, tea.Batch(reconcileCmd, pageCmd)
+}
+
+// afterPage requests the next page. Once the last page is in it highlights
+// what changed, forgets outdated search comments, updates the digest, saves
+// the snapshot, records a history sample, refreshes stale repository
+// metadata and starts replaying any mutations queued while offline. Items
+// narrowed by a filter query are not the whole project, so they leave the
+// snapshot and history as they were.
+func afterPage(s State, cursor string, fetched int) (State, tea.Cmd) {
+	if cmd := nextPageCmd(s, cursor, fetched); cmd != nil {
+		return s, cmd
+	}
+	s, highlightCmd := highlightChanges(s)
+	s = pruneSearchComments(s)
+	s = finishFetch(s)
+	s, digestCmd := refreshDigest(s)
+	var saveCmd, historyCmd tea.Cmd
+	if canSaveSnapshot(s) {
+		saveCmd = core.SaveSnapshotCmd(s.Model.CachePath, s.Model.Project, s.Model.Items, s.Model.Repos)
+		s.Model.History = state.RecordSample(s.Model.History, state.NewStatusSample(s.Model.Items, time.Now()))
+		historyCmd = core.SaveHistoryCmd(s.Model.HistoryPath, s.Model.History)
+	}
+	metaCmd := fetchStaleRepoMetadata(s)
+	s, replayCmd := startReplay(s)
+	return s, tea.Batch(highlightCmd, digestCmd, saveCmd, historyCmd, metaCmd, replayCmd)
+}
+
+// canSaveSnapshot reports whether the items on screen are the whole project
+// as last fetched: not a cached copy, not a refresh still paging in and not
+// narrowed by a filter query.
+func canSaveSnapshot(s State) bool {
+	return !s.Model.Stale && !s.Model.Loading && !s.Model.ItemsNarrowed
+}
+
+// highlightChanges diffs the completed refresh against the items shown before
+// it and moves focus to the first item if the focused one is gone.
+func highlightChanges(s State) (State, tea.Cmd) {
+	if !containsItem(s.Model.Items, s.Model.View.FocusedItemID) {
+		s.Model.View.FocusedItemID = ""
+		if len(s.Model.Items) > 0 {
+			s.Model.View.FocusedItemID = s.Model.Items[0].ID
+		}
+		s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	}
+	s.Model.Highlights = nil
+	if len(s.Fetch.Previous) == 0 || s.Model.ItemsNarrowed {
+		return s, nil
+	}
+	changes := state.DiffItems(s.Fetch.Previous, s.Model.Items)
+	if len(changes) == 0 {
+		return s, nil
+	}
+	s.Model.Highlights = changes
+	return s, core.ClearHighlightsCmd(s.Fetch.Generation)
+}
+
+// AutoRefresh starts a refresh when the interval elapses and schedules the
+// next one. Refreshes are skipped while one is already running, while offline
+// (reconnect checks take over) and while a modal or editor is open.
+func AutoRefresh(s State) (State, tea.Cmd) {
+	if s.Model.RefreshInterval <= 0 {
+		return s, nil
+	}
+	next := core.AutoRefreshCmd(s.Model.RefreshInterval)
+	if s.Model.Loading || s.Model.Offline || s.Model.View.Mode != state.ModeNormal {
+		return s, next
+	}
+	s, fetchCmd := StartFetch(s)
+	return s, tea.Batch(fetchCmd, next)
+}
+
+// ClearHighlights ends the highlighting scheduled by the refresh with the
+// given generation; a newer refresh keeps its own highlights.
+func ClearHighlights(s State, msg core.ClearHighlightsMsg) State {
+	if msg.Generation == s.Fetch.Generation {
+		s.Model.Highlights = nil
+	}
+	return s
+}
+
+func containsItem(items []state.Item, id string) bool {
+	for _, item := range items {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func nextPageCmd(s State, cursor string, fetched int) tea.Cmd {
+	if cursor == "" {
+		return nil
+	}
+	if s.ItemLimit > 0 && fetched >= s.ItemLimit {
+		return nil
+	}
+	ctx := s.Fetch.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return core.FetchItemsPageCmd(ctx, s.Github, s.Fetch.Generation, s.Model.Project.ID, s.Model.Project.Owner, s.ItemLimit, fetched, github.CompileFilter(s.Model.View.Filter).Server, cursor)
+}
+
+func excludeDoneItems(s State, items []state.Item) []state.Item {
+	if !s.Model.ExcludeDone {
+		return items
+	}
+	var filtered []state.Item
+	for _, item := range items {
+		if item.Status != "Done" {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
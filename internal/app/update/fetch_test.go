@@ -0,0 +1,355 @@
+package update
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/cache"
+	"project-hub/internal/github"
+	"project-hub/internal/history"
+	"project-hub/internal/state"
+)
+
+type pagedClient struct {
+	mockClient
+	pages map[string][]state.Item
+	next  map[string]string
+}
+
+func (p *pagedClient) FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (github.ItemsPage, error) {
+	return github.ItemsPage{Items: p.pages[cursor], NextCursor: p.next[cursor]}, nil
+}
+
+func TestProjectFetchedRequestsFollowingPages(t *testing.T) {
+	client := &pagedClient{
+		pages: map[string][]state.Item{"c1": {{ID: "3", Status: "Todo"}, {ID: "2", Status: "Todo"}}},
+		next:  map[string]string{"c1": ""},
+	}
+	s := NewState(state.Model{}, client, 10)
+
+	s, cmd := ProjectFetched(s, core.FetchProjectMsg{
+		Project:    state.Project{ID: "1", Owner: "acme"},
+		Items:      []state.Item{{ID: "1", Status: "Todo"}, {ID: "2", Status: "Todo"}},
+		NextCursor: "c1",
+	})
+	if len(s.Model.Items) != 2 || s.Model.View.FocusedItemID != "1" {
+		t.Fatalf("expected first page to be applied, got %+v", s.Model.Items)
+	}
+	if cmd == nil {
+		t.Fatalf("expected a command for the next page")
+	}
+
+	page, ok := cmd().(core.ItemsPageMsg)
+	if !ok {
+		t.Fatalf("expected ItemsPageMsg, got %T", cmd())
+	}
+	if page.Fetched != 4 {
+		t.Fatalf("expected fetched count 4, got %d", page.Fetched)
+	}
+
+	s, cmd = ItemsPageFetched(s, page)
+	if len(s.Model.Items) != 3 {
+		t.Fatalf("expected duplicate item to be skipped, got %d items", len(s.Model.Items))
+	}
+	if s.Model.Items[2].ID != "3" {
+		t.Fatalf("expected page items appended in order, got %+v", s.Model.Items)
+	}
+	if cmd != nil {
+		t.Fatalf("expected no further page request after last page")
+	}
+}
+
+func TestItemsPageFetchedStopsAtItemLimit(t *testing.T) {
+	s := NewState(state.Model{Project: state.Project{ID: "1"}}, &mockClient{}, 2)
+
+	_, cmd := ItemsPageFetched(s, core.ItemsPageMsg{Items: []state.Item{{ID: "1"}, {ID: "2"}}, NextCursor: "more", Fetched: 2})
+	if cmd != nil {
+		t.Fatalf("expected paging to stop once the item limit is reached")
+	}
+}
+
+func TestItemsPageFetchedExcludesDone(t *testing.T) {
+	s := NewState(state.Model{ExcludeDone: true}, &mockClient{}, 100)
+
+	s, _ = ItemsPageFetched(s, core.ItemsPageMsg{Items: []state.Item{{ID: "1", Status: "Done"}, {ID: "2", Status: "Todo"}}})
+	if len(s.Model.Items) != 1 || s.Model.Items[0].ID != "2" {
+		t.Fatalf("expected done items to be excluded, got %+v", s.Model.Items)
+	}
+}
+
+func TestProjectFetchedClearsStaleAndSavesSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme-1.json")
+	s := NewState(state.Model{Stale: true, CachePath: path}, &mockClient{}, 100)
+
+	s, cmd := ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme", Name: "Roadmap"},
+		Items:   []state.Item{{ID: "1", Title: "Cached"}},
+	})
+	if s.Model.Stale {
+		t.Fatalf("expected stale flag to clear after refresh")
+	}
+	if cmd == nil {
+		t.Fatalf("expected snapshot save command")
+	}
+	if msg := cmd(); msg != nil {
+		t.Fatalf("expected snapshot save to succeed, got %v", msg)
+	}
+
+	snap, err := cache.Load(path)
+	if err != nil {
+		t.Fatalf("load snapshot: %v", err)
+	}
+	if snap.Project.Name != "Roadmap" || len(snap.Items) != 1 || snap.Items[0].Title != "Cached" {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+}
+
+func TestProjectFetchedRecordsHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme-1.json")
+	yesterday := state.StatusSample{At: time.Now().AddDate(0, 0, -1), Items: []state.SampleItem{{ID: "1", Status: "Todo"}}}
+	s := NewState(state.Model{HistoryPath: path, History: []state.StatusSample{yesterday}}, &mockClient{}, 100)
+
+	s, cmd := ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme"},
+		Items:   []state.Item{{ID: "1", Status: "Done"}},
+	})
+	if len(s.Model.History) != 2 || s.Model.History[1].Items[0].Status != "Done" {
+		t.Fatalf("expected today's sample after yesterday's, got %+v", s.Model.History)
+	}
+	if cmd == nil || cmd() != nil {
+		t.Fatalf("expected history save to succeed")
+	}
+
+	samples, err := history.Load(path)
+	if err != nil {
+		t.Fatalf("load history: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected both samples saved, got %+v", samples)
+	}
+}
+
+func TestNarrowedFetchKeepsSnapshotAndHistory(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache.json")
+	historyPath := filepath.Join(dir, "history.json")
+	full := []state.Item{{ID: "1", Title: "Bug", Labels: []string{"bug"}}, {ID: "2", Title: "Docs", Labels: []string{"docs"}}}
+	yesterday := state.StatusSample{At: time.Now().AddDate(0, 0, -1), Items: []state.SampleItem{{ID: "1"}, {ID: "2"}}}
+	s := NewState(state.Model{Items: full, CachePath: cachePath, HistoryPath: historyPath, History: []state.StatusSample{yesterday}}, &mockClient{}, 100)
+	s.Model.View.Filter = state.ParseFilter("label:bug")
+
+	s, cmd := ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme"},
+		Items:   full[:1],
+	})
+	if !s.Model.ItemsNarrowed {
+		t.Fatalf("expected the items marked as narrowed by the filter query")
+	}
+	runBatch(cmd)
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Fatalf("expected no snapshot saved for a narrowed fetch, got %v", err)
+	}
+	if _, err := os.Stat(historyPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no history saved for a narrowed fetch, got %v", err)
+	}
+	if len(s.Model.History) != 1 {
+		t.Fatalf("expected no sample recorded for a narrowed fetch, got %+v", s.Model.History)
+	}
+	if len(s.Model.Highlights) != 0 {
+		t.Fatalf("expected items outside the query not highlighted as removed, got %+v", s.Model.Highlights)
+	}
+
+	s.Model.View.Filter = state.FilterState{}
+	s, cmd = ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme"},
+		Items:   full,
+	})
+	if s.Model.ItemsNarrowed || len(s.Model.Highlights) != 0 {
+		t.Fatalf("expected a full fetch after a narrowed one without highlights, got %+v", s.Model.Highlights)
+	}
+	runBatch(cmd)
+	snap, err := cache.Load(cachePath)
+	if err != nil || len(snap.Items) != 2 {
+		t.Fatalf("expected the full fetch saved, got %+v (%v)", snap, err)
+	}
+}
+
+// runBatch runs cmd and every command batched inside it.
+func runBatch(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runBatch(c)
+		}
+	}
+}
+
+func TestStartFetchCancelsPreviousRefresh(t *testing.T) {
+	s := NewState(state.Model{Project: state.Project{ID: "1"}}, &mockClient{}, 100)
+
+	s, _ = StartFetch(s)
+	first := s.Fetch.Ctx
+	s, _ = StartFetch(s)
+	if first.Err() != context.Canceled {
+		t.Fatalf("expected previous refresh to be cancelled")
+	}
+	if s.Fetch.Generation != 2 || s.Fetch.Ctx.Err() != nil {
+		t.Fatalf("expected a live second generation, got %+v", s.Fetch)
+	}
+	if !s.Model.Loading {
+		t.Fatalf("expected loading while a refresh is in flight")
+	}
+}
+
+func TestUpdateDiscardsStaleFetchResults(t *testing.T) {
+	s := NewState(state.Model{Project: state.Project{ID: "1"}}, &mockClient{}, 100)
+	s, _ = StartFetch(s)
+	s, _ = StartFetch(s)
+
+	s, _ = Update(s, core.FetchProjectMsg{Generation: 1, Items: []state.Item{{ID: "old"}}})
+	if len(s.Model.Items) != 0 || !s.Model.Loading {
+		t.Fatalf("expected stale response to be discarded, got %+v", s.Model.Items)
+	}
+	s, _ = Update(s, core.FetchFailedMsg{Generation: 1, Err: context.Canceled})
+	if !s.Model.Loading {
+		t.Fatalf("expected stale failure to leave current refresh running")
+	}
+
+	s, _ = Update(s, core.FetchProjectMsg{Generation: 2, Items: []state.Item{{ID: "new"}}})
+	if len(s.Model.Items) != 1 || s.Model.Items[0].ID != "new" {
+		t.Fatalf("expected current response to be applied, got %+v", s.Model.Items)
+	}
+	if s.Model.Loading || s.Fetch.Cancel != nil {
+		t.Fatalf("expected refresh to finish after the last page")
+	}
+}
+
+func TestFetchFailedIgnoresCancellation(t *testing.T) {
+	s := NewState(state.Model{}, &mockClient{}, 100)
+	s, _ = StartFetch(s)
+
+	s, cmd := FetchFailed(s, core.FetchFailedMsg{Generation: s.Fetch.Generation, Err: context.Canceled})
+	if s.Model.Loading {
+		t.Fatalf("expected loading to stop")
+	}
+	if cmd != nil || len(s.Model.Notifications) != 0 {
+		t.Fatalf("expected cancellation to be silent, got %+v", s.Model.Notifications)
+	}
+}
+
+func TestRefreshKeepsFocusAndHighlightsChanges(t *testing.T) {
+	s := NewState(state.Model{
+		Items: []state.Item{{ID: "1", Status: "Todo"}, {ID: "2", Title: "Old", Status: "Todo"}},
+		View:  state.ViewContext{FocusedItemID: "2"},
+	}, &mockClient{}, 100)
+	s, _ = StartFetch(s)
+
+	s, cmd := Update(s, core.FetchProjectMsg{
+		Generation: s.Fetch.Generation,
+		Items:      []state.Item{{ID: "1", Status: "Done"}, {ID: "2", Title: "New", Status: "Todo"}, {ID: "3", Status: "Todo"}},
+	})
+	if s.Model.View.FocusedItemID != "2" {
+		t.Fatalf("expected focus to stay on item 2, got %q", s.Model.View.FocusedItemID)
+	}
+	want := map[string]state.ChangeKind{"1": state.ChangeMoved, "2": state.ChangeEdited, "3": state.ChangeAdded}
+	for id, kind := range want {
+		if s.Model.Highlights[id] != kind {
+			t.Fatalf("item %s: expected %s highlight, got %v", id, kind, s.Model.Highlights)
+		}
+	}
+	if cmd == nil {
+		t.Fatalf("expected highlights to be cleared later")
+	}
+
+	s = ClearHighlights(s, core.ClearHighlightsMsg{Generation: s.Fetch.Generation - 1})
+	if s.Model.Highlights == nil {
+		t.Fatalf("expected an older clear to keep current highlights")
+	}
+	s = ClearHighlights(s, core.ClearHighlightsMsg{Generation: s.Fetch.Generation})
+	if s.Model.Highlights != nil {
+		t.Fatalf("expected highlights to clear, got %v", s.Model.Highlights)
+	}
+}
+
+func TestRefreshMovesFocusWhenItemRemoved(t *testing.T) {
+	s := NewState(state.Model{
+		Items: []state.Item{{ID: "1"}, {ID: "2"}},
+		View:  state.ViewContext{FocusedItemID: "2"},
+	}, &mockClient{}, 100)
+
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{Items: []state.Item{{ID: "1"}}})
+	if s.Model.View.FocusedItemID != "1" {
+		t.Fatalf("expected focus on first item, got %q", s.Model.View.FocusedItemID)
+	}
+}
+
+func TestAutoRefreshSkipsWhileBusy(t *testing.T) {
+	s := NewState(state.Model{RefreshInterval: time.Minute, View: state.ViewContext{Mode: state.ModeNormal}}, &mockClient{}, 100)
+
+	s, cmd := AutoRefresh(s)
+	if !s.Model.Loading || s.Fetch.Generation != 1 || cmd == nil {
+		t.Fatalf("expected auto-refresh to start a fetch")
+	}
+	s, cmd = AutoRefresh(s)
+	if s.Fetch.Generation != 1 || cmd == nil {
+		t.Fatalf("expected in-flight refresh to be left alone and the next tick scheduled")
+	}
+
+	s = NewState(state.Model{}, &mockClient{}, 100)
+	if _, cmd := AutoRefresh(s); cmd != nil {
+		t.Fatalf("expected no ticks when auto-refresh is disabled")
+	}
+}
+
+func TestFetchStaleRepoMetadataSkipsFreshRepos(t *testing.T) {
+	mockRepoLabels = []string{"bug"}
+	defer func() { mockRepoLabels = nil }()
+	s := NewState(state.Model{
+		Items: []state.Item{{ID: "1", Repository: "acme/app"}, {ID: "2", Repository: "acme/web"}},
+		Repos: map[string]state.RepoMetadata{"acme/web": {FetchedAt: time.Now()}},
+	}, &mockClient{}, 100)
+
+	cmd := fetchStaleRepoMetadata(s)
+	if cmd == nil {
+		t.Fatalf("expected acme/app metadata to be fetched")
+	}
+	msg, ok := cmd().(core.RepoMetadataMsg)
+	if !ok || msg.Repo != "acme/app" {
+		t.Fatalf("expected only acme/app fetched, got %#v", cmd())
+	}
+
+	s, _ = RepoMetadataFetched(s, msg)
+	if meta := s.Model.Repos["acme/app"]; len(meta.Labels) != 1 || meta.Stale(time.Now()) {
+		t.Fatalf("expected fresh metadata cached, got %+v", s.Model.Repos)
+	}
+	if fetchStaleRepoMetadata(s) != nil {
+		t.Fatalf("expected nothing left to fetch")
+	}
+}
+
+func TestRepoMetadataFetchedLeavesSnapshotToRunningRefresh(t *testing.T) {
+	s := NewState(state.Model{Items: []state.Item{{ID: "1", Repository: "acme/app"}}, CachePath: filepath.Join(t.TempDir(), "cache.json")}, &mockClient{}, 100)
+	msg := core.RepoMetadataMsg{Repo: "acme/app", Metadata: state.RepoMetadata{Labels: []state.Label{{Name: "bug"}}, FetchedAt: time.Now()}}
+
+	s.Model.Loading = true
+	loading, cmd := RepoMetadataFetched(s, msg)
+	if cmd != nil {
+		t.Fatalf("expected no snapshot save while the refresh is paging in")
+	}
+	if len(loading.Model.Repos["acme/app"].Labels) != 1 {
+		t.Fatalf("expected metadata cached while loading, got %+v", loading.Model.Repos)
+	}
+
+	s.Model.Loading = false
+	if _, cmd := RepoMetadataFetched(s, msg); cmd == nil {
+		t.Fatalf("expected the snapshot saved once the refresh is done")
+	}
+}
@@ -0,0 +1,92 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+// EnterFieldEditMode opens the typed editor for the date, number and text
+// fields of the focused item, or of the item shown in the detail panel.
+func EnterFieldEditMode(s State) (State, tea.Cmd) {
+	origin := s.Model.View.Mode
+	var item state.Item
+	if origin == state.ModeDetail {
+		item = s.DetailItem
+	} else {
+		idx := s.Model.View.FocusedIndex
+		if idx < 0 || idx >= len(s.Model.Items) {
+			return s, nil
+		}
+		item = s.Model.Items[idx]
+	}
+
+	var fields []state.Field
+	for _, field := range s.Model.Project.Fields {
+		if field.HoldsValue() {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		notif := state.Notification{Message: "No date, number or text fields found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	if item.ID == "" || !strings.HasPrefix(item.ID, "PVTI_") {
+		notif := state.Notification{Message: fmt.Sprintf("Invalid item ID format: %s. Expected project item node ID.", item.ID), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+
+	s.FieldEditor = components.NewFieldEditorModel(item, fields, time.Now(), s.Model.Width)
+	s.FieldEditor.Origin = origin
+	s.Model.View.Mode = state.ModeFieldEdit
+	return s, s.FieldEditor.Init()
+}
+
+// FieldEditMode routes input to the field editor and saves the confirmed value.
+func FieldEditMode(s State, msg tea.Msg) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	updatedEditor, editorCmd := s.FieldEditor.Update(msg)
+	s.FieldEditor = updatedEditor.(components.FieldEditorModel)
+	if editorCmd != nil {
+		cmds = append(cmds, editorCmd)
+	}
+
+	m, ok := msg.(components.FieldEditedMsg)
+	if !ok {
+		return s, tea.Batch(cmds...)
+	}
+	s.Model.View.Mode = s.FieldEditor.Origin
+	if m.Canceled {
+		return s, tea.Batch(cmds...)
+	}
+
+	idx := -1
+	for i, item := range s.Model.Items {
+		if item.ID == m.ItemID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return s, tea.Batch(cmds...)
+	}
+
+	mutation := newMutation(s, state.MutationValue, s.Model.Items[idx])
+	mutation.FieldID = m.Field.ID
+	mutation.FieldName = m.Field.Name
+	mutation.FieldType = m.Field.Type
+	mutation.Text = m.Value
+
+	updated, updateCmd := dispatchMutation(s, mutation, func(updatedItem state.Item) tea.Msg {
+		return core.ItemUpdatedMsg{ItemID: mutation.ItemID(), Item: updatedItem}
+	})
+	return updated, tea.Batch(append(cmds, updateCmd)...)
+}
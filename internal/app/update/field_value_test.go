@@ -0,0 +1,73 @@
+package update
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+func fieldValueTestState() State {
+	model := state.Model{
+		Project: state.Project{ID: "1", NodeID: "PVT_1", Owner: "acme", Fields: []state.Field{
+			{ID: "PVTSSF_status", Name: "Status", Type: state.FieldTypeSingleSelect},
+			{ID: "PVTF_target", Name: "Target date", Type: state.FieldTypeDate},
+			{ID: "PVTF_estimate", Name: "Estimate", Type: state.FieldTypeNumber},
+		}},
+		Items: []state.Item{{ID: "PVTI_1", Title: "Launch", FieldValues: map[string][]string{"Estimate": {"3"}}}},
+		View:  state.ViewContext{CurrentView: state.ViewTable, Mode: state.ModeNormal, FocusedItemID: "PVTI_1"},
+	}
+	return NewState(model, &mockClient{}, 100)
+}
+
+func TestFieldEditSavesTypedValue(t *testing.T) {
+	s, _ := HandleKey(fieldValueTestState(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if s.Model.View.Mode != state.ModeFieldEdit {
+		t.Fatalf("expected field edit mode, got %q", s.Model.View.Mode)
+	}
+
+	field := s.Model.Project.Fields[2]
+	s, cmd := Update(s, components.FieldEditedMsg{ItemID: "PVTI_1", Field: field, Value: "5.5"})
+	if s.Model.View.Mode != state.ModeNormal {
+		t.Fatalf("expected to return to normal mode, got %q", s.Model.View.Mode)
+	}
+	if got := s.Model.Items[0].FieldValues["Estimate"]; len(got) != 1 || got[0] != "5.5" {
+		t.Fatalf("expected estimate applied optimistically, got %v", got)
+	}
+	if cmd == nil {
+		t.Fatalf("expected mutation command")
+	}
+	if _, ok := cmd().(core.ItemUpdatedMsg); !ok {
+		t.Fatalf("expected item update after mutation")
+	}
+}
+
+func TestFieldEditFromDetailReturnsToDetail(t *testing.T) {
+	s := fieldValueTestState()
+	s.Model.View.Mode = state.ModeDetail
+	s.DetailItem = s.Model.Items[0]
+
+	s, _ = Update(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if s.Model.View.Mode != state.ModeFieldEdit {
+		t.Fatalf("expected field edit mode from detail, got %q", s.Model.View.Mode)
+	}
+	s, _ = Update(s, components.FieldEditedMsg{ItemID: "PVTI_1", Field: s.Model.Project.Fields[1], Value: "2026-11-02"})
+	if s.Model.View.Mode != state.ModeDetail {
+		t.Fatalf("expected to return to detail mode, got %q", s.Model.View.Mode)
+	}
+	if got := s.DetailItem.FieldValues["Target date"]; len(got) != 1 || got[0] != "2026-11-02" {
+		t.Fatalf("expected detail item updated, got %v", got)
+	}
+}
+
+func TestFieldEditWithoutValueFields(t *testing.T) {
+	s := fieldValueTestState()
+	s.Model.Project.Fields = s.Model.Project.Fields[:1]
+	s, _ = EnterFieldEditMode(s)
+	if s.Model.View.Mode != state.ModeNormal || len(s.Model.Notifications) != 1 {
+		t.Fatalf("expected an error notification and no mode change, got %q %+v", s.Model.View.Mode, s.Model.Notifications)
+	}
+}
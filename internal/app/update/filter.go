@@ -1,8 +1,13 @@
 package update
 
 import (
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 
+	"project-hub/internal/app/core"
+	"project-hub/internal/github"
 	"project-hub/internal/state"
 	boardPkg "project-hub/internal/ui/board"
 )
@@ -29,8 +34,15 @@ func EnterFilterMode(s State, _ EnterFilterModeMsg) (State, tea.Cmd) {
 	return s, s.TextInput.Focus()
 }
 
+// ApplyFilter applies the query typed in filter mode. A query that cannot be
+// parsed is not applied; the footer shows why and editing continues. When
+// the part GitHub can search changes, the project is fetched again with it.
 func ApplyFilter(s State, msg ApplyFilterMsg) (State, tea.Cmd) {
 	fs := state.ParseFilter(msg.Query)
+	if fs.Err != nil {
+		return s, nil
+	}
+	previous := github.CompileFilter(s.Model.View.Filter)
 	s.Model.View.Filter = fs
 	if fs.GroupBy != "" {
 		s.Model.View.TableGroupBy = fs.GroupBy
@@ -38,10 +50,61 @@ func ApplyFilter(s State, msg ApplyFilterMsg) (State, tea.Cmd) {
 	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, fs, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
 	s.Model.View.Mode = state.ModeNormal
 	s.TextInput.Prompt = ""
-	return s, nil
+
+	compiled := github.CompileFilter(fs)
+	s, fetchCmd := refetchForFilter(s, previous, compiled)
+	cmds := []tea.Cmd{fetchCmd}
+	if compiled.Server != "" || len(compiled.Client) > 0 {
+		notif := state.Notification{Message: describeFilterQuery(compiled, s.Model.ItemQueryRejected), Level: "info", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	}
+	return s, tea.Batch(cmds...)
+}
+
+// refetchForFilter fetches the project again when the filter GitHub
+// evaluates has changed, since the items already loaded were fetched with
+// the previous one. Once GitHub has rejected the items query, every fetch
+// returns all items and there is nothing to fetch again.
+func refetchForFilter(s State, previous, current github.FilterQuery) (State, tea.Cmd) {
+	if current.Server == previous.Server || s.Model.Project.ID == "" || s.Model.ItemQueryRejected {
+		return s, nil
+	}
+	return StartFetch(s)
+}
+
+// describeFilterQuery tells which parts of a filter GitHub evaluates and
+// which are only evaluated on the fetched items. When GitHub rejects the
+// items query, every term is evaluated locally except those only GitHub can
+// evaluate, which are ignored.
+func describeFilterQuery(q github.FilterQuery, rejected bool) string {
+	var ignored []string
+	if rejected && q.Server != "" {
+		local := strings.TrimSpace(strings.TrimSuffix(q.Server, strings.Join(q.ServerOnly, " ")))
+		if local != "" {
+			q.Client = append([]string{local}, q.Client...)
+		}
+		q.Server = ""
+		ignored = q.ServerOnly
+	}
+	var parts []string
+	if q.Server != "" {
+		parts = append(parts, "on GitHub: "+q.Server)
+	}
+	if len(q.Client) > 0 {
+		parts = append(parts, "locally: "+strings.Join(q.Client, " "))
+	}
+	if len(ignored) == 0 {
+		return "Filtered " + strings.Join(parts, "; ")
+	}
+	if len(parts) == 0 {
+		return "Not filtered; ignored, GitHub only: " + strings.Join(ignored, " ")
+	}
+	return "Filtered " + strings.Join(parts, "; ") + "; ignored, GitHub only: " + strings.Join(ignored, " ")
 }
 
 func ClearFilter(s State, _ ClearFilterMsg) (State, tea.Cmd) {
+	previous := github.CompileFilter(s.Model.View.Filter)
 	s.Model.View.Filter = state.FilterState{}
 	s.Model.View.TableGroupBy = ""
 	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, state.FilterState{}, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
@@ -50,5 +113,5 @@ func ClearFilter(s State, _ ClearFilterMsg) (State, tea.Cmd) {
 	}
 	s.TextInput.SetValue("")
 	s.TextInput.Prompt = ""
-	return s, nil
+	return refetchForFilter(s, previous, github.FilterQuery{})
 }
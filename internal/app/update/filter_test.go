@@ -0,0 +1,69 @@
+package update
+
+import (
+	"testing"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+func TestApplyFilterKeepsEditingOnParseError(t *testing.T) {
+	s := NewState(state.Model{Items: []state.Item{{ID: "1", Title: "A"}}}, &mockClient{}, 100)
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:bug"})
+	s.Model.View.Mode = state.ModeFiltering
+
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "(label:bug OR"})
+	if s.Model.View.Mode != state.ModeFiltering {
+		t.Fatalf("expected filter mode to stay open, got %q", s.Model.View.Mode)
+	}
+	if s.Model.View.Filter.Raw != "label:bug" {
+		t.Fatalf("expected the previous filter kept, got %q", s.Model.View.Filter.Raw)
+	}
+}
+
+func TestApplyFilterRefetchesWhenGitHubQueryChanges(t *testing.T) {
+	s := NewState(state.Model{Project: state.Project{ID: "1", Owner: "acme"}}, &mockClient{}, 100)
+
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:bug (status:Todo OR status:Done)"})
+	if !s.Model.Loading || s.Fetch.Generation != 1 {
+		t.Fatalf("expected a refetch for the new GitHub query")
+	}
+	last := s.Model.Notifications[len(s.Model.Notifications)-1].Message
+	if last != "Filtered on GitHub: label:bug; locally: (status:Todo OR status:Done)" {
+		t.Fatalf("unexpected notification %q", last)
+	}
+
+	s = finishFetch(s)
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:bug fix"})
+	if s.Model.Loading || s.Fetch.Generation != 1 {
+		t.Fatalf("expected no refetch when only the local part changes")
+	}
+
+	s, _ = ClearFilter(s, ClearFilterMsg{})
+	if !s.Model.Loading || s.Fetch.Generation != 2 {
+		t.Fatalf("expected clearing the filter to refetch everything")
+	}
+}
+
+func TestRejectedItemQueryFiltersLocally(t *testing.T) {
+	s := NewState(state.Model{Project: state.Project{ID: "1", Owner: "acme"}}, &mockClient{}, 100)
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:bug"})
+
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{Generation: s.Fetch.Generation, Items: []state.Item{{ID: "1"}}, QueryIgnored: true})
+	if !s.Model.ItemQueryRejected {
+		t.Fatalf("expected the rejected items query remembered")
+	}
+	last := s.Model.Notifications[len(s.Model.Notifications)-1].Message
+	if last != "GitHub does not support filtering project items; filtered locally: label:bug" {
+		t.Fatalf("unexpected notification %q", last)
+	}
+
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:docs fix"})
+	if s.Model.Loading {
+		t.Fatalf("expected no refetch once GitHub rejected the items query")
+	}
+	last = s.Model.Notifications[len(s.Model.Notifications)-1].Message
+	if last != "Filtered locally: label:docs fix" {
+		t.Fatalf("unexpected notification %q", last)
+	}
+}
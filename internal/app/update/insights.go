@@ -0,0 +1,31 @@
+package update
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+// EnterInsightsView switches to the burndown and cumulative flow charts.
+func EnterInsightsView(s State) (State, tea.Cmd) {
+	s.Model.View.CurrentView = state.ViewInsights
+	return s, nil
+}
+
+// InsightsKey handles the insights view's keys. It reports false for keys the
+// global key handler should process instead.
+func InsightsKey(s State, key string) (State, tea.Cmd, bool) {
+	switch key {
+	case "x":
+		now := time.Now()
+		burndown := state.BuildBurndown(s.Model.Project, s.Model.Items, s.Model.History, now)
+		flow := state.BuildCumulativeFlow(s.Model.Project, s.Model.History)
+		return s, core.ExportInsightsCmd(".", s.Model.Project, burndown, flow, now), true
+	case "1", "b", "2", "t", "3", "4", "5", "6", "7", "R", "u", "ctrl+r", "!", "q", "ctrl+c":
+		return s, nil, false
+	}
+	return s, nil, true
+}
@@ -0,0 +1,56 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+func TestInsightsExportsCSV(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	now := time.Now()
+	start := now.AddDate(0, 0, -2)
+	end := start.AddDate(0, 0, 14)
+	model := state.Model{
+		Project: state.Project{ID: "1", Owner: "acme", Iterations: []state.Timeline{{ID: "it-1", Name: "Sprint 1", Start: &start, End: &end}}},
+		History: []state.StatusSample{
+			{At: now.AddDate(0, 0, -1), Items: []state.SampleItem{{ID: "PVTI_1", Status: "Todo", IterationID: "it-1"}}},
+			{At: now, Items: []state.SampleItem{{ID: "PVTI_1", Status: "Done", IterationID: "it-1"}}},
+		},
+		View: state.ViewContext{CurrentView: state.ViewBoard, Mode: state.ModeNormal},
+	}
+	s := NewState(model, &mockClient{}, 100)
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("7")})
+	if s.Model.View.CurrentView != state.ViewInsights {
+		t.Fatalf("expected insights view, got %q", s.Model.View.CurrentView)
+	}
+	_, cmd := HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatalf("expected export command")
+	}
+	if msg, ok := cmd().(core.ActionResultMsg); !ok || !strings.HasPrefix(msg.Message, "Exported ") {
+		t.Fatalf("expected export confirmation, got %#v", msg)
+	}
+
+	day := now.Format(state.DateLayout)
+	data, err := os.ReadFile(filepath.Join(dir, "acme-1-cumulative-flow-"+day+".csv"))
+	if err != nil {
+		t.Fatalf("read cumulative flow: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "date,Todo,Done\n") {
+		t.Fatalf("unexpected cumulative flow CSV:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "acme-1-burndown-"+day+".csv")); err != nil {
+		t.Fatalf("expected burndown CSV: %v", err)
+	}
+}
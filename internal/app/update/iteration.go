@@ -0,0 +1,107 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+// EnterIterationSelectMode opens the iteration picker for the focused item.
+func EnterIterationSelectMode(s State) (State, tea.Cmd) {
+	idx := s.Model.View.FocusedIndex
+	if idx < 0 || idx >= len(s.Model.Items) {
+		return s, nil
+	}
+	item := s.Model.Items[idx]
+
+	if _, ok := state.FindField(s.Model.Project.Fields, state.FieldTypeIteration, "Iteration"); !ok {
+		notif := state.Notification{Message: "Iteration field not found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	iterations := state.ProjectIterations(s.Model.Project, s.Model.Items)
+	if len(iterations) == 0 {
+		notif := state.Notification{Message: "No iterations found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	if item.ID == "" || !strings.HasPrefix(item.ID, "PVTI_") {
+		notif := state.Notification{Message: fmt.Sprintf("Invalid item ID format: %s. Expected project item node ID.", item.ID), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+
+	s.IterationPicker = components.NewIterationSelectorModel(item, iterations, time.Now(), s.Model.Width)
+	s.IterationPicker.Origin = s.Model.View.Mode
+	s.Model.View.Mode = state.ModeIterationSelect
+	return s, s.IterationPicker.Init()
+}
+
+// IterationSelectMode routes input to the iteration picker and moves the item
+// to the chosen iteration.
+func IterationSelectMode(s State, msg tea.Msg) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	updatedPicker, pickerCmd := s.IterationPicker.Update(msg)
+	s.IterationPicker = updatedPicker.(components.IterationSelectorModel)
+	if pickerCmd != nil {
+		cmds = append(cmds, pickerCmd)
+	}
+
+	m, ok := msg.(components.IterationSelectedMsg)
+	if !ok {
+		return s, tea.Batch(cmds...)
+	}
+	s.Model.View.Mode = s.IterationPicker.Origin
+	if m.Canceled {
+		return s, tea.Batch(cmds...)
+	}
+	if hasSelection(s) {
+		field, _ := state.FindField(s.Model.Project.Fields, state.FieldTypeIteration, "Iteration")
+		updated, bulkCmd := bulkEdit(s, func(item state.Item) (state.Mutation, error) {
+			mutation := newMutation(s, state.MutationIteration, item)
+			mutation.FieldID = field.ID
+			mutation.FieldName = field.Name
+			mutation.Iteration = m.Iteration
+			return mutation, validProjectItem(item)
+		})
+		return updated, tea.Batch(append(cmds, bulkCmd)...)
+	}
+
+	idx := -1
+	for i, item := range s.Model.Items {
+		if item.ID == m.ItemID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return s, tea.Batch(cmds...)
+	}
+	updated, updateCmd := moveToIteration(s, idx, m.Iteration)
+	return updated, tea.Batch(append(cmds, updateCmd)...)
+}
+
+// moveToIteration assigns the item at idx to iteration t, or clears its
+// iteration when t is nil. Items already there are left alone.
+func moveToIteration(s State, idx int, t *state.Timeline) (State, tea.Cmd) {
+	current := s.Model.Items[idx]
+	if (t == nil && current.IterationID == "") || (t != nil && t.ID == current.IterationID) {
+		return s, nil
+	}
+
+	field, _ := state.FindField(s.Model.Project.Fields, state.FieldTypeIteration, "Iteration")
+	mutation := newMutation(s, state.MutationIteration, current)
+	mutation.FieldID = field.ID
+	mutation.FieldName = field.Name
+	mutation.Iteration = t
+
+	return dispatchMutation(s, mutation, func(updatedItem state.Item) tea.Msg {
+		return core.ItemUpdatedMsg{ItemID: mutation.ItemID(), Item: updatedItem}
+	})
+}
@@ -0,0 +1,76 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+func iterationTestState() State {
+	start := time.Now().AddDate(0, 0, -3)
+	next := start.AddDate(0, 0, 14)
+	model := state.Model{
+		Project: state.Project{ID: "1", NodeID: "PVT_1", Owner: "acme",
+			Fields: []state.Field{{ID: "PVTIF_sprint", Name: "Sprint", Type: state.FieldTypeIteration}},
+			Iterations: []state.Timeline{
+				{ID: "it-2", Name: "Sprint 2", Start: &next},
+				{ID: "it-1", Name: "Sprint 1", Start: &start},
+			},
+		},
+		Items: []state.Item{{ID: "PVTI_1", Title: "Launch", IterationID: "it-1", IterationName: "Sprint 1", IterationStart: &start, IterationDurationDays: 14}},
+		View:  state.ViewContext{CurrentView: state.ViewBoard, Mode: state.ModeNormal, FocusedItemID: "PVTI_1"},
+	}
+	return NewState(model, &mockClient{}, 100)
+}
+
+func TestIterationSelectMovesItem(t *testing.T) {
+	s, _ := HandleKey(iterationTestState(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	if s.Model.View.Mode != state.ModeIterationSelect {
+		t.Fatalf("expected iteration select mode, got %q", s.Model.View.Mode)
+	}
+
+	target := s.Model.Project.Iterations[0]
+	s, cmd := Update(s, components.IterationSelectedMsg{ItemID: "PVTI_1", Iteration: &target})
+	if s.Model.View.Mode != state.ModeNormal {
+		t.Fatalf("expected to return to normal mode, got %q", s.Model.View.Mode)
+	}
+	if got := s.Model.Items[0]; got.IterationID != "it-2" || got.IterationName != "Sprint 2" {
+		t.Fatalf("expected item moved optimistically, got %+v", got)
+	}
+	if cmd == nil {
+		t.Fatalf("expected mutation command")
+	}
+	if _, ok := cmd().(core.ItemUpdatedMsg); !ok {
+		t.Fatalf("expected item update after mutation")
+	}
+}
+
+func TestIterationColumnOpensPicker(t *testing.T) {
+	s := iterationTestState()
+	s.Model.View.CurrentView = state.ViewTable
+	s.Model.View.CardFieldVisibility = state.CardFieldVisibility{ShowIteration: true}
+	visible := tableVisibleColumns(s.Model.View.CardFieldVisibility)
+	s.Model.View.FocusedColumnIndex = len(visible) - 2
+	if visible[s.Model.View.FocusedColumnIndex] != state.ColumnIteration {
+		t.Fatalf("expected iteration just before assignees, got %v", visible)
+	}
+
+	s, _ = ColumnEdit(s, EnterEditModeMsg{})
+	if s.Model.View.Mode != state.ModeIterationSelect {
+		t.Fatalf("expected iteration select mode from the table column, got %q", s.Model.View.Mode)
+	}
+}
+
+func TestIterationSelectWithoutField(t *testing.T) {
+	s := iterationTestState()
+	s.Model.Project.Fields = nil
+	s, _ = EnterIterationSelectMode(s)
+	if s.Model.View.Mode != state.ModeNormal || len(s.Model.Notifications) != 1 {
+		t.Fatalf("expected an error notification and no mode change, got %q %+v", s.Model.View.Mode, s.Model.Notifications)
+	}
+}
@@ -1,7 +1,6 @@
 package update
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
@@ -97,7 +96,11 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 		}
 	}
 
-	if s.Model.View.Mode == "edit" || s.Model.View.Mode == "assign" || s.Model.View.Mode == "labelsInput" || s.Model.View.Mode == "milestoneInput" || s.Model.View.Mode == state.ModeFiltering || s.Model.View.Mode == state.ModeCreateIssueRepo || s.Model.View.Mode == state.ModeCreateIssueTitle || s.Model.View.Mode == state.ModeCreateIssueBody {
+	if s.Model.View.Mode == state.ModeSearch {
+		return SearchKey(s, k)
+	}
+
+	if s.Model.View.Mode == "edit" || s.Model.View.Mode == "assign" || s.Model.View.Mode == "labelsInput" || s.Model.View.Mode == "milestoneInput" || s.Model.View.Mode == state.ModeFiltering || s.Model.View.Mode == state.ModeCreateIssueRepo || s.Model.View.Mode == state.ModeCreateIssueTitle || s.Model.View.Mode == state.ModeCreateIssueBody || s.Model.View.Mode == state.ModeSaveView || s.Model.View.Mode == state.ModeSortInput {
 		switch k.String() {
 		case "enter":
 			if s.Model.View.Mode == "edit" {
@@ -112,6 +115,10 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 				return ApplyFilter(s, ApplyFilterMsg{Query: s.TextInput.Value()})
 			} else if s.Model.View.Mode == state.ModeCreateIssueRepo || s.Model.View.Mode == state.ModeCreateIssueTitle || s.Model.View.Mode == state.ModeCreateIssueBody {
 				return SaveCreateIssue(s, SaveCreateIssueMsg{Value: s.TextInput.Value()})
+			} else if s.Model.View.Mode == state.ModeSaveView {
+				return SaveView(s, s.TextInput.Value())
+			} else if s.Model.View.Mode == state.ModeSortInput {
+				return SaveSortInput(s, s.TextInput.Value())
 			}
 		case "esc":
 			if s.Model.View.Mode == "edit" {
@@ -126,7 +133,21 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 				return ClearFilter(s, ClearFilterMsg{})
 			} else if s.Model.View.Mode == state.ModeCreateIssueRepo || s.Model.View.Mode == state.ModeCreateIssueTitle || s.Model.View.Mode == state.ModeCreateIssueBody {
 				return CancelCreateIssue(s, CancelCreateIssueMsg{})
+			} else if s.Model.View.Mode == state.ModeSaveView {
+				return CancelSaveView(s)
+			} else if s.Model.View.Mode == state.ModeSortInput {
+				return CancelSortInput(s)
 			}
+		case "tab":
+			value := s.TextInput.Value()
+			if start, matches := Suggestions(s.Model, value); len(matches) > 0 {
+				s.TextInput.SetValue(value[:start] + matches[0])
+				s.TextInput.CursorEnd()
+				return s, nil
+			}
+			var cmd tea.Cmd
+			s.TextInput, cmd = s.TextInput.Update(k)
+			return s, cmd
 		default:
 			var cmd tea.Cmd
 			s.TextInput, cmd = s.TextInput.Update(k)
@@ -138,6 +159,38 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 		return FieldToggle(s, k.String())
 	}
 
+	if s.Model.View.Mode == state.ModeConflicts {
+		return ConflictKey(s, k.String())
+	}
+
+	if s.Model.View.Mode == state.ModeViewPicker {
+		return ViewPickerKey(s, k.String())
+	}
+
+	if s.Model.View.CurrentView == state.ViewDigest && s.Model.View.Mode == state.ModeNormal {
+		if updated, cmd, handled := DigestKey(s, k.String()); handled {
+			return updated, cmd
+		}
+	}
+
+	if s.Model.View.CurrentView == state.ViewPlanning && s.Model.View.Mode == state.ModeNormal {
+		if updated, cmd, handled := PlanningKey(s, k.String()); handled {
+			return updated, cmd
+		}
+	}
+
+	if s.Model.View.CurrentView == state.ViewRoadmap && s.Model.View.Mode == state.ModeNormal {
+		if updated, cmd, handled := RoadmapKey(s, k.String()); handled {
+			return updated, cmd
+		}
+	}
+
+	if s.Model.View.CurrentView == state.ViewInsights && s.Model.View.Mode == state.ModeNormal {
+		if updated, cmd, handled := InsightsKey(s, k.String()); handled {
+			return updated, cmd
+		}
+	}
+
 	if s.Model.View.Mode == state.ModeSort {
 		switch k.String() {
 		case "t", "T":
@@ -160,6 +213,8 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 			s.Model.View.TableSort = toggleSort(s.Model.View.TableSort, "CreatedAt")
 		case "u", "U":
 			s.Model.View.TableSort = toggleSort(s.Model.View.TableSort, "UpdatedAt")
+		case ":":
+			return EnterSortInput(s)
 		case "j", "down":
 			return MoveFocus(s, MoveFocusMsg{Delta: 1})
 		case "k", "up":
@@ -190,26 +245,8 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 			return s, nil
 		}
 
-		if len(s.Model.Items) > 0 {
-			s.Model.View.FocusedIndex = 0
-			s.Model.View.FocusedItemID = s.Model.Items[0].ID
-		}
 		s.Model.View.Mode = state.ModeNormal
-		if !s.Model.SuppressHints {
-			notif := state.Notification{Message: fmt.Sprintf("Sort: %s %s", s.Model.View.TableSort.Field, func() string {
-				if s.Model.View.TableSort.Asc {
-					return "↑"
-				}
-				return "↓"
-			}()), Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
-			s.Model.Notifications = append(s.Model.Notifications, notif)
-			return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
-		}
-		// Always return after handling Sort mode so that top-level key handlers
-		// do not also process the same key press (which could re-enter assign
-		// mode when SuppressHints is true and the notification branch above
-		// doesn't return).
-		return s, nil
+		return sortApplied(s)
 	}
 
 	// Handle board-specific keys, including g/G
@@ -274,8 +311,26 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 		return SwitchView(s, SwitchViewMsg{View: state.ViewTable})
 	case "3":
 		return SwitchView(s, SwitchViewMsg{View: state.ViewSettings})
-	case "R", "ctrl+r":
-		return s, core.FetchProjectCmd(s.Github, s.Model.Project.ID, s.Model.Project.Owner, s.ItemLimit, s.Model.View.Filter.Iterations)
+	case "4":
+		return EnterDigestView(s)
+	case "5":
+		return EnterPlanningView(s)
+	case "6":
+		return EnterRoadmapView(s)
+	case "7":
+		return EnterInsightsView(s)
+	case "R":
+		return StartFetch(s)
+	case "u":
+		if s.Model.View.Mode == state.ModeNormal {
+			return Undo(s)
+		}
+		return s, nil
+	case "ctrl+r":
+		if s.Model.View.Mode == state.ModeNormal {
+			return Redo(s)
+		}
+		return s, nil
 	case "j":
 		return MoveFocus(s, MoveFocusMsg{Delta: 1})
 	case "k":
@@ -334,7 +389,34 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 		}
 		return s, nil
 	case "esc":
+		if hasSelection(s) {
+			return ClearSelection(s)
+		}
 		return ClearFilter(s, ClearFilterMsg{})
+	case " ":
+		if s.Model.View.Mode != state.ModeNormal {
+			return s, nil
+		}
+		if s.Model.View.CurrentView == state.ViewBoard || s.Model.View.CurrentView == state.ViewTable {
+			return ToggleSelection(s)
+		}
+		return s, nil
+	case "V":
+		if s.Model.View.Mode != state.ModeNormal {
+			return s, nil
+		}
+		if s.Model.View.CurrentView == state.ViewBoard || s.Model.View.CurrentView == state.ViewTable {
+			return SelectRange(s)
+		}
+		return s, nil
+	case "A":
+		if s.Model.View.Mode != state.ModeNormal {
+			return s, nil
+		}
+		if s.Model.View.CurrentView == state.ViewBoard || s.Model.View.CurrentView == state.ViewTable {
+			return SelectAllMatching(s)
+		}
+		return s, nil
 	case "i", "enter":
 		if s.Model.View.Mode != state.ModeNormal {
 			return s, nil
@@ -369,6 +451,22 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 			return EnterFieldToggleMode(s)
 		}
 		return s, nil
+	case "e":
+		if s.Model.View.Mode != state.ModeNormal {
+			return s, nil
+		}
+		if s.Model.View.CurrentView == state.ViewBoard || s.Model.View.CurrentView == state.ViewTable {
+			return EnterFieldEditMode(s)
+		}
+		return s, nil
+	case "I":
+		if s.Model.View.Mode != state.ModeNormal {
+			return s, nil
+		}
+		if s.Model.View.CurrentView == state.ViewBoard || s.Model.View.CurrentView == state.ViewTable {
+			return EnterIterationSelectMode(s)
+		}
+		return s, nil
 	case "m":
 		if s.Model.View.Mode != state.ModeNormal {
 			return s, nil
@@ -379,6 +477,24 @@ func HandleKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
 		return s, nil
 	case "o":
 		return EnterDetailMode(s)
+	case "!":
+		if s.Model.View.Mode == state.ModeNormal {
+			return EnterConflictMode(s)
+		}
+		return s, nil
+	case "v":
+		if s.Model.View.Mode == state.ModeNormal {
+			return EnterViewPicker(s)
+		}
+		return s, nil
+	case "?":
+		if s.Model.View.Mode != state.ModeNormal {
+			return s, nil
+		}
+		if s.Model.View.CurrentView == state.ViewBoard || s.Model.View.CurrentView == state.ViewTable || s.Model.View.CurrentView == state.ViewRoadmap {
+			return EnterSearchMode(s)
+		}
+		return s, nil
 	case "O":
 		// Open the focused item's URL in the browser if available
 		idx := s.Model.View.FocusedIndex
@@ -409,7 +525,7 @@ func moveTableFocusToTop(s State) State {
 		return s
 	}
 	filteredItems := state.ApplyFilter(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, time.Now())
-	filteredItems = state.ApplyTableSort(filteredItems, s.Model.View.TableSort)
+	filteredItems = state.ApplyTableSort(filteredItems, s.Model.Project.Fields, s.Model.View.TableSort)
 	if len(filteredItems) == 0 {
 		s.Model.View.FocusedItemID = ""
 		s.Model.View.FocusedIndex = -1
@@ -434,7 +550,7 @@ func moveTableFocusToBottom(s State) State {
 		return s
 	}
 	filteredItems := state.ApplyFilter(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, time.Now())
-	filteredItems = state.ApplyTableSort(filteredItems, s.Model.View.TableSort)
+	filteredItems = state.ApplyTableSort(filteredItems, s.Model.Project.Fields, s.Model.View.TableSort)
 	if len(filteredItems) == 0 {
 		s.Model.View.FocusedItemID = ""
 		s.Model.View.FocusedIndex = -1
@@ -474,7 +590,7 @@ func moveTableFocusToGroupedEdge(s State, top bool) State {
 		return s
 	}
 	filteredItems := state.ApplyFilter(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, time.Now())
-	filteredItems = state.ApplyTableSort(filteredItems, s.Model.View.TableSort)
+	filteredItems = state.ApplyTableSort(filteredItems, s.Model.Project.Fields, s.Model.View.TableSort)
 
 	var groups []boardPkg.GroupBucket
 	switch groupBy {
@@ -571,6 +687,9 @@ func tableVisibleColumns(vis state.CardFieldVisibility) []int {
 	if vis.ShowParentIssue {
 		columns = append(columns, state.ColumnParentIssue)
 	}
+	if vis.ShowIteration {
+		columns = append(columns, state.ColumnIteration)
+	}
 	columns = append(columns, state.ColumnAssignees)
 	return columns
 }
@@ -0,0 +1,424 @@
+package update
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/github"
+	"project-hub/internal/state"
+	boardPkg "project-hub/internal/ui/board"
+	"project-hub/internal/ui/components"
+)
+
+// mutationSeq tells apart mutations started within the same clock tick.
+var mutationSeq atomic.Uint64
+
+// mutationID returns a new mutation ID, unique for this process.
+func mutationID(now time.Time) string {
+	return strconv.FormatInt(now.UnixNano(), 36) + "-" + strconv.FormatUint(mutationSeq.Add(1), 36)
+}
+
+// newMutation starts a mutation of item, snapshotting the item as it is now.
+func newMutation(s State, kind state.MutationKind, item state.Item) state.Mutation {
+	now := time.Now()
+	return state.Mutation{
+		ID:        mutationID(now),
+		Kind:      kind,
+		ProjectID: core.ProjectMutationID(s.Model.Project),
+		Owner:     s.Model.Project.Owner,
+		Before:    item,
+		QueuedAt:  now,
+	}
+}
+
+// dispatchMutation records m so it can be undone and sends it.
+func dispatchMutation(s State, m state.Mutation, done func(state.Item) tea.Msg) (State, tea.Cmd) {
+	s, undoCmd := recordUndo(s, m)
+	s, sendCmd := sendMutation(s, m, done)
+	if undoCmd == nil {
+		return s, sendCmd
+	}
+	return s, tea.Batch(undoCmd, sendCmd)
+}
+
+// sendMutation applies m locally and sends it to GitHub, or queues it behind
+// earlier mutations when offline or when queued mutations are still waiting
+// to be replayed. Comments are only shown once GitHub accepts them.
+func sendMutation(s State, m state.Mutation, done func(state.Item) tea.Msg) (State, tea.Cmd) {
+	if s.Model.Offline || len(s.Model.PendingMutations) > 0 {
+		return queueMutation(s, m)
+	}
+	if m.Kind != state.MutationComment {
+		s = applyMutationLocally(s, m)
+	}
+	return s, core.MutationCmd(s.Github, m, done)
+}
+
+// rollbackMutation reverts the fields a failed mutation changed optimistically
+// and reports the failure.
+func rollbackMutation(s State, m state.Mutation, err error) (State, tea.Cmd) {
+	s, reverted := revertMutation(s, m)
+
+	message := fmt.Sprintf("Error: %v", err)
+	if reverted {
+		message = fmt.Sprintf("Reverted %s: %v", m.Describe(), err)
+	}
+	notif := state.Notification{Message: message, Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+}
+
+// revertMutation puts back the fields m changed optimistically. Fields that
+// have since been changed again by a later edit are left alone.
+func revertMutation(s State, m state.Mutation) (State, bool) {
+	reverted := false
+	for _, item := range s.Model.Items {
+		if m.Kind != state.MutationComment && item.ID == m.ItemID() && m.Value(item) == m.Value(m.Apply(item)) {
+			reverted = true
+		}
+	}
+	if reverted {
+		s = restoreMutationLocally(s, m, m.Before)
+	}
+	s.Model.UndoStack = state.DropUndo(s.Model.UndoStack, m.ID)
+	s.Model.RedoStack = state.DropUndo(s.Model.RedoStack, m.ID)
+	return s, reverted
+}
+
+// MutationQueued switches to offline mode after a mutation failed to reach GitHub.
+func MutationQueued(s State, msg core.MutationQueuedMsg) (State, tea.Cmd) {
+	s, offlineCmd := goOffline(s)
+	s, queueCmd := queueMutation(s, msg.Mutation)
+	return s, tea.Batch(offlineCmd, queueCmd)
+}
+
+// queueMutation journals m and applies it to the local items until it can be replayed.
+func queueMutation(s State, m state.Mutation) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	s, journalCmd := enqueueMutation(s, m)
+	cmds = append(cmds, journalCmd)
+
+	notif := state.Notification{Message: fmt.Sprintf("Queued %s (%d pending)", m.Describe(), len(s.Model.PendingMutations)), Level: "warn", At: time.Now(), DismissAfter: 3 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+
+	s, replayCmd := startReplay(s)
+	cmds = append(cmds, replayCmd)
+	return s, tea.Batch(cmds...)
+}
+
+// enqueueMutation adds m to the pending mutations and the journal without
+// reporting it or starting a replay.
+func enqueueMutation(s State, m state.Mutation) (State, tea.Cmd) {
+	s.Model.PendingMutations = append(s.Model.PendingMutations, m)
+	s = applyMutationLocally(s, m)
+	return s, core.SaveJournalCmd(s.Model.JournalPath, journalEntries(s))
+}
+
+// goOffline marks GitHub as unreachable and schedules periodic reconnect attempts.
+func goOffline(s State) (State, tea.Cmd) {
+	s.Model.Syncing = false
+	if s.Model.Offline {
+		return s, nil
+	}
+	now := time.Now()
+	s.Model.Offline = true
+	s.Model.OfflineSince = &now
+	notif := state.Notification{Message: "Offline: edits will be queued and replayed when GitHub is reachable", Level: "warn", At: now, DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, tea.Batch(core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter), core.ReconnectCmd(now))
+}
+
+// Reconnect retries the project fetch while offline. A successful fetch ends
+// the offline period and replays the queued mutations.
+func Reconnect(s State, msg core.ReconnectMsg) (State, tea.Cmd) {
+	if !s.Model.Offline || s.Model.OfflineSince == nil || !s.Model.OfflineSince.Equal(msg.Since) {
+		return s, nil
+	}
+	if s.Model.Loading {
+		return s, core.ReconnectCmd(msg.Since)
+	}
+	s, fetchCmd := StartFetch(s)
+	return s, tea.Batch(fetchCmd, core.ReconnectCmd(msg.Since))
+}
+
+// reconcilePending checks queued mutations against freshly fetched items.
+// Mutations whose field changed on GitHub since they were queued are moved to
+// the conflict list; the rest are re-applied on top of the fetched items.
+func reconcilePending(s State, items []state.Item) (State, []state.Item, tea.Cmd) {
+	if len(s.Model.PendingMutations) == 0 {
+		return s, items, nil
+	}
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[item.ID] = i
+	}
+
+	var pending []state.Mutation
+	conflicts := 0
+	for _, m := range s.Model.PendingMutations {
+		i, ok := index[m.ItemID()]
+		if !ok {
+			pending = append(pending, m)
+			continue
+		}
+		if !s.Model.Syncing && m.Conflicts(items[i]) {
+			s.Model.Conflicts = append(s.Model.Conflicts, state.MutationConflict{Mutation: m, Remote: items[i], Reason: "changed on GitHub since it was queued"})
+			conflicts++
+			continue
+		}
+		if m.Kind != state.MutationComment {
+			items[i] = m.Apply(items[i])
+		}
+		pending = append(pending, m)
+	}
+	s.Model.PendingMutations = pending
+	if conflicts == 0 {
+		return s, items, nil
+	}
+
+	notif := state.Notification{Message: fmt.Sprintf("%d queued change(s) conflict with GitHub; press ! to resolve", conflicts), Level: "warn", At: time.Now(), DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, items, tea.Batch(
+		core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter),
+		core.SaveJournalCmd(s.Model.JournalPath, journalEntries(s)),
+	)
+}
+
+// startReplay sends the oldest queued mutation once items are fresh and GitHub is reachable.
+func startReplay(s State) (State, tea.Cmd) {
+	if s.Model.Offline || s.Model.Stale || s.Model.Syncing || len(s.Model.PendingMutations) == 0 {
+		return s, nil
+	}
+	s.Model.Syncing = true
+	return s, core.ReplayMutationCmd(s.Github, s.Model.PendingMutations[0])
+}
+
+// MutationReplayed records the outcome of a replayed mutation and moves on to the next one.
+func MutationReplayed(s State, msg core.MutationReplayedMsg) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	s.Model.Syncing = false
+	if msg.Err != nil && github.IsConnectivityError(msg.Err) {
+		return goOffline(s)
+	}
+
+	m := msg.Mutation
+	s.Model.PendingMutations = removeMutation(s.Model.PendingMutations, m.ID)
+	if msg.Err != nil {
+		s = restoreMutationLocally(s, m, m.Before)
+		s.Model.UndoStack = state.DropUndo(s.Model.UndoStack, m.ID)
+		s.Model.Conflicts = append(s.Model.Conflicts, state.MutationConflict{Mutation: m, Remote: m.Before, Reason: msg.Err.Error()})
+		notif := state.Notification{Message: fmt.Sprintf("Could not replay %s: %v; press ! to resolve", m.Describe(), msg.Err), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	} else {
+		if m.Kind != state.MutationComment {
+			for i := range s.Model.Items {
+				if s.Model.Items[i].ID == m.ItemID() {
+					s.Model.Items[i] = mergeUpdatedItem(s.Model.Items[i], msg.Item)
+				}
+			}
+			s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+		}
+		if (m.Kind == state.MutationComment || m.Kind == state.MutationBody) && s.DetailItem.ID == m.ItemID() {
+			s.DetailItem.Description = msg.Item.Description
+			s.DetailItem.Comments = msg.Item.Comments
+			s.DetailPanel = components.NewDetailPanelModel(s.DetailItem, s.Model.Width, s.Model.Height)
+		}
+		if len(s.Model.PendingMutations) == 0 && !s.Model.SuppressHints {
+			notif := state.Notification{Message: "Queued changes synced", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+			s.Model.Notifications = append(s.Model.Notifications, notif)
+			cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+		}
+	}
+	cmds = append(cmds, core.SaveJournalCmd(s.Model.JournalPath, journalEntries(s)))
+
+	s, replayCmd := startReplay(s)
+	cmds = append(cmds, replayCmd)
+	return s, tea.Batch(cmds...)
+}
+
+// EnterConflictMode opens the conflict resolution panel.
+func EnterConflictMode(s State) (State, tea.Cmd) {
+	if len(s.Model.Conflicts) == 0 {
+		notif := state.Notification{Message: "No conflicting changes", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	s.Model.View.ConflictIndex = 0
+	s.Model.View.Mode = state.ModeConflicts
+	return s, nil
+}
+
+// ConflictKey handles keys in the conflict resolution panel.
+func ConflictKey(s State, key string) (State, tea.Cmd) {
+	switch key {
+	case "j", "down":
+		if s.Model.View.ConflictIndex < len(s.Model.Conflicts)-1 {
+			s.Model.View.ConflictIndex++
+		}
+	case "k", "up":
+		if s.Model.View.ConflictIndex > 0 {
+			s.Model.View.ConflictIndex--
+		}
+	case "m":
+		return resolveConflict(s, true)
+	case "t":
+		return resolveConflict(s, false)
+	case "esc", "q":
+		s.Model.View.Mode = state.ModeNormal
+	}
+	return s, nil
+}
+
+// resolveConflict either re-queues the selected mutation on top of the remote
+// item (keep mine) or drops it and shows the remote value (keep theirs).
+func resolveConflict(s State, keepMine bool) (State, tea.Cmd) {
+	idx := s.Model.View.ConflictIndex
+	if idx < 0 || idx >= len(s.Model.Conflicts) {
+		return s, nil
+	}
+	c := s.Model.Conflicts[idx]
+	s.Model.Conflicts = append(append([]state.MutationConflict(nil), s.Model.Conflicts[:idx]...), s.Model.Conflicts[idx+1:]...)
+	if s.Model.View.ConflictIndex >= len(s.Model.Conflicts) {
+		s.Model.View.ConflictIndex = len(s.Model.Conflicts) - 1
+	}
+	if len(s.Model.Conflicts) == 0 {
+		s.Model.View.Mode = state.ModeNormal
+	}
+
+	var cmds []tea.Cmd
+	message := fmt.Sprintf("Discarded %s", c.Mutation.Describe())
+	if keepMine {
+		m := c.Mutation
+		if c.Remote.ID != "" {
+			m.Before = c.Remote
+		}
+		s.Model.PendingMutations = append(s.Model.PendingMutations, m)
+		s = applyMutationLocally(s, m)
+		message = fmt.Sprintf("Re-queued %s", m.Describe())
+	} else if c.Remote.ID != "" {
+		s = restoreMutationLocally(s, c.Mutation, c.Remote)
+	}
+	cmds = append(cmds, core.SaveJournalCmd(s.Model.JournalPath, journalEntries(s)))
+	if !s.Model.SuppressHints {
+		notif := state.Notification{Message: message, Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	}
+
+	s, replayCmd := startReplay(s)
+	cmds = append(cmds, replayCmd)
+	return s, tea.Batch(cmds...)
+}
+
+// applyMutationLocally shows m's change on the board, table and detail panel.
+// Queued comments only appear in the detail panel.
+func applyMutationLocally(s State, m state.Mutation) State {
+	if m.Kind != state.MutationComment {
+		for i := range s.Model.Items {
+			if s.Model.Items[i].ID == m.ItemID() {
+				s.Model.Items[i] = m.Apply(s.Model.Items[i])
+			}
+		}
+		s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	}
+	if s.DetailItem.ID != "" && s.DetailItem.ID == m.ItemID() {
+		s.DetailItem = m.Apply(s.DetailItem)
+		s.DetailPanel = components.NewDetailPanelModel(s.DetailItem, s.Model.Width, s.Model.Height)
+	}
+	return s
+}
+
+// restoreMutationLocally puts back the fields m touched using their values in source.
+func restoreMutationLocally(s State, m state.Mutation, source state.Item) State {
+	if m.Kind != state.MutationComment {
+		for i := range s.Model.Items {
+			if s.Model.Items[i].ID == m.ItemID() {
+				s.Model.Items[i] = m.Restore(s.Model.Items[i], source)
+			}
+		}
+		s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	}
+	if s.DetailItem.ID != "" && s.DetailItem.ID == m.ItemID() {
+		s.DetailItem = m.Restore(s.DetailItem, source)
+		s.DetailPanel = components.NewDetailPanelModel(s.DetailItem, s.Model.Width, s.Model.Height)
+	}
+	return s
+}
+
+// journalEntries lists every mutation that still has to reach GitHub,
+// including unresolved conflicts so they survive a restart.
+func journalEntries(s State) []state.Mutation {
+	entries := append([]state.Mutation(nil), s.Model.PendingMutations...)
+	for _, c := range s.Model.Conflicts {
+		entries = append(entries, c.Mutation)
+	}
+	return entries
+}
+
+func removeMutation(mutations []state.Mutation, id string) []state.Mutation {
+	var remaining []state.Mutation
+	for _, m := range mutations {
+		if m.ID != id {
+			remaining = append(remaining, m)
+		}
+	}
+	return remaining
+}
+
+// ItemUpdated merges GitHub's response to an edit into the edited item. The
+// item is looked up by ID, since a refresh or replay may have rebuilt the
+// items while the edit was in flight; a response for an item no longer
+// loaded is dropped.
+func ItemUpdated(s State, msg core.ItemUpdatedMsg) (State, tea.Cmd) {
+	idx := -1
+	for i := range s.Model.Items {
+		if s.Model.Items[i].ID == msg.ItemID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return s, nil
+	}
+	s.Model.Items[idx] = mergeUpdatedItem(s.Model.Items[idx], msg.Item)
+	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	if s.Model.SuppressHints {
+		return s, nil
+	}
+	notif := state.Notification{Message: "Item updated successfully", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+}
+
+// mergeUpdatedItem copies the fields GitHub reported back after a mutation onto existing.
+func mergeUpdatedItem(existing state.Item, updated state.Item) state.Item {
+	if len(updated.Assignees) > 0 {
+		existing.Assignees = updated.Assignees
+	}
+	if len(updated.Labels) > 0 {
+		existing.Labels = updated.Labels
+	}
+	if updated.Title != "" {
+		existing.Title = updated.Title
+	}
+	if updated.Status != "" && updated.Status != "Unknown" {
+		existing.Status = updated.Status
+	}
+	if updated.Priority != "" {
+		existing.Priority = updated.Priority
+	}
+	if updated.Repository != "" {
+		existing.Repository = updated.Repository
+	}
+	if updated.Number != 0 {
+		existing.Number = updated.Number
+	}
+	return existing
+}
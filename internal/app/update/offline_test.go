@@ -0,0 +1,304 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/journal"
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+type offlineClient struct {
+	mockClient
+	err    error
+	calls  int
+	status string
+}
+
+func (c *offlineClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error) {
+	c.calls++
+	return state.Item{ID: itemID, Labels: labels}, c.err
+}
+
+func (c *offlineClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error) {
+	c.calls++
+	return state.Item{ID: itemID, Assignees: userLogins}, c.err
+}
+
+func (c *offlineClient) UpdateStatus(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string) (state.Item, error) {
+	c.calls++
+	return state.Item{ID: itemID, Status: c.status}, c.err
+}
+
+func offlineTestState(t *testing.T, client *offlineClient) State {
+	t.Helper()
+	model := state.Model{
+		Project:       state.Project{ID: "1", Owner: "acme"},
+		Items:         []state.Item{{ID: "PVTI_1", Title: "Login", Status: "Todo", Type: "Issue", Repository: "acme/app", Number: 3}},
+		View:          state.ViewContext{Mode: state.ModeNormal, FocusedIndex: 0, FocusedItemID: "PVTI_1"},
+		JournalPath:   filepath.Join(t.TempDir(), "acme-1.jsonl"),
+		SuppressHints: true,
+	}
+	return NewState(model, client, 100)
+}
+
+func TestMutationIDsDifferWithinOneClockTick(t *testing.T) {
+	now := time.Now()
+	if a, b := mutationID(now), mutationID(now); a == b {
+		t.Fatalf("expected distinct IDs for the same instant, got %q twice", a)
+	}
+}
+
+func TestMutationQueuedWhenGitHubUnreachable(t *testing.T) {
+	client := &offlineClient{err: errors.New("exit status 1: error connecting to api.github.com")}
+	s := offlineTestState(t, client)
+
+	s, cmd := SaveLabelsInput(s, SaveLabelsInputMsg{Labels: "bug, ui"})
+	msg, ok := cmd().(core.MutationQueuedMsg)
+	if !ok {
+		t.Fatalf("expected MutationQueuedMsg, got %T", cmd())
+	}
+
+	s, _ = MutationQueued(s, msg)
+	if !s.Model.Offline || s.Model.OfflineSince == nil {
+		t.Fatalf("expected offline mode after connectivity failure")
+	}
+	if len(s.Model.PendingMutations) != 1 {
+		t.Fatalf("expected one pending mutation, got %d", len(s.Model.PendingMutations))
+	}
+	if got := s.Model.Items[0].Labels; len(got) != 2 || got[0] != "bug" {
+		t.Fatalf("expected labels applied optimistically, got %v", got)
+	}
+}
+
+func TestOfflineEditsAreJournaledWithoutCallingGitHub(t *testing.T) {
+	client := &offlineClient{}
+	s := offlineTestState(t, client)
+	s.Model.Offline = true
+
+	s, cmd := SaveAssign(s, SaveAssignMsg{Assignee: "alice"})
+	if len(s.Model.PendingMutations) != 1 || s.Model.Items[0].Assignees[0] != "alice" {
+		t.Fatalf("expected queued assignment applied locally, got %+v", s.Model.Items[0])
+	}
+	if cmd == nil {
+		t.Fatalf("expected journal command")
+	}
+	if msg := core.SaveJournalCmd(s.Model.JournalPath, journalEntries(s))(); msg != nil {
+		t.Fatalf("expected journal write to succeed, got %v", msg)
+	}
+	entries, err := journal.Load(s.Model.JournalPath)
+	if err != nil || len(entries) != 1 || entries[0].Values[0] != "alice" {
+		t.Fatalf("expected journaled assignment, got %+v (err %v)", entries, err)
+	}
+	if client.calls != 0 {
+		t.Fatalf("expected no GitHub calls while offline, got %d", client.calls)
+	}
+}
+
+func TestProjectFetchedReplaysQueuedMutations(t *testing.T) {
+	client := &offlineClient{status: "Done"}
+	s := offlineTestState(t, client)
+	s.Model.Offline = true
+	m := newMutation(s, state.MutationStatus, s.Model.Items[0])
+	m.OptionName = "Done"
+	s.Model.PendingMutations = []state.Mutation{m}
+	s.Model.Items[0] = m.Apply(s.Model.Items[0])
+
+	s, cmd := ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme"},
+		Items:   []state.Item{{ID: "PVTI_1", Title: "Login", Status: "Todo"}},
+	})
+	if s.Model.Offline || !s.Model.Syncing {
+		t.Fatalf("expected replay to start once online, got offline=%v syncing=%v", s.Model.Offline, s.Model.Syncing)
+	}
+	if s.Model.Items[0].Status != "Done" {
+		t.Fatalf("expected queued status re-applied over fetched item, got %q", s.Model.Items[0].Status)
+	}
+
+	replayed, ok := cmd().(core.MutationReplayedMsg)
+	if !ok {
+		t.Fatalf("expected MutationReplayedMsg, got %T", cmd())
+	}
+	s, _ = MutationReplayed(s, replayed)
+	if len(s.Model.PendingMutations) != 0 || s.Model.Syncing {
+		t.Fatalf("expected queue drained, got %+v", s.Model.PendingMutations)
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected one replayed call, got %d", client.calls)
+	}
+}
+
+func TestProjectFetchedHoldsBackConflicts(t *testing.T) {
+	s := offlineTestState(t, &offlineClient{status: "Done"})
+	m := newMutation(s, state.MutationStatus, s.Model.Items[0])
+	m.OptionName = "Done"
+	s.Model.PendingMutations = []state.Mutation{m}
+
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme"},
+		Items:   []state.Item{{ID: "PVTI_1", Title: "Login", Status: "In Progress"}},
+	})
+	if len(s.Model.Conflicts) != 1 || len(s.Model.PendingMutations) != 0 {
+		t.Fatalf("expected mutation moved to conflicts, got pending=%d conflicts=%d", len(s.Model.PendingMutations), len(s.Model.Conflicts))
+	}
+	if s.Model.Items[0].Status != "In Progress" {
+		t.Fatalf("expected remote value shown while conflicted, got %q", s.Model.Items[0].Status)
+	}
+
+	s, _ = EnterConflictMode(s)
+	if s.Model.View.Mode != state.ModeConflicts {
+		t.Fatalf("expected conflict mode, got %q", s.Model.View.Mode)
+	}
+	s, _ = ConflictKey(s, "m")
+	if len(s.Model.Conflicts) != 0 || len(s.Model.PendingMutations) != 1 {
+		t.Fatalf("expected keep-mine to re-queue the mutation")
+	}
+	if s.Model.PendingMutations[0].Before.Status != "In Progress" {
+		t.Fatalf("expected re-queued mutation to be based on the remote item")
+	}
+	if s.Model.Items[0].Status != "Done" || !s.Model.Syncing || s.Model.View.Mode != state.ModeNormal {
+		t.Fatalf("expected mine applied and replay started, got %+v", s.Model.Items[0])
+	}
+}
+
+func TestConflictKeepTheirsDropsMutation(t *testing.T) {
+	s := offlineTestState(t, &offlineClient{})
+	m := newMutation(s, state.MutationStatus, s.Model.Items[0])
+	m.OptionName = "Done"
+	remote := s.Model.Items[0]
+	remote.Status = "In Progress"
+	s.Model.Items[0].Status = "Done"
+	s.Model.Conflicts = []state.MutationConflict{{Mutation: m, Remote: remote, Reason: "changed"}}
+	s.Model.View.Mode = state.ModeConflicts
+
+	s, _ = ConflictKey(s, "t")
+	if len(s.Model.Conflicts) != 0 || len(s.Model.PendingMutations) != 0 {
+		t.Fatalf("expected conflict discarded")
+	}
+	if s.Model.Items[0].Status != "In Progress" {
+		t.Fatalf("expected remote value restored, got %q", s.Model.Items[0].Status)
+	}
+}
+
+func TestStatusChangeAppliesBeforeGitHubResponds(t *testing.T) {
+	client := &offlineClient{status: "Done"}
+	s := offlineTestState(t, client)
+	s.Model.Project.Fields = []state.Field{{ID: "F_status", Name: "Status", Options: []state.Option{{ID: "opt-done", Name: "Done"}}}}
+	s.Model.View.Mode = state.ModeStatusSelect
+
+	s, cmd := StatusSelectMode(s, components.StatusSelectedMsg{StatusFieldID: "F_status", OptionID: "opt-done", OptionName: "Done"})
+	if s.Model.Items[0].Status != "Done" {
+		t.Fatalf("expected status applied immediately, got %q", s.Model.Items[0].Status)
+	}
+	if client.calls != 0 {
+		t.Fatalf("expected GitHub to be called only when the command runs")
+	}
+	if _, ok := cmd().(core.ItemUpdatedMsg); !ok {
+		t.Fatalf("expected ItemUpdatedMsg from the mutation command")
+	}
+}
+
+func TestEditResultFollowsItemAcrossRefresh(t *testing.T) {
+	s := offlineTestState(t, &offlineClient{})
+	s, cmd := SaveLabelsInput(s, SaveLabelsInputMsg{Labels: "bug"})
+	msg, ok := cmd().(core.ItemUpdatedMsg)
+	if !ok {
+		t.Fatalf("expected ItemUpdatedMsg from the mutation command, got %#v", cmd())
+	}
+
+	// The refresh reorders the items and has not seen the new label yet.
+	other := state.Item{ID: "PVTI_2", Title: "Signup", Status: "Todo", Type: "Issue", Repository: "acme/web", Number: 9}
+	edited := s.Model.Items[0]
+	edited.Labels = nil
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{Project: s.Model.Project, Items: []state.Item{other, edited}})
+	s, _ = Update(s, msg)
+	if got := s.Model.Items[0]; got.ID != "PVTI_2" || len(got.Labels) != 0 || got.Repository != "acme/web" || got.Number != 9 {
+		t.Fatalf("expected the item now at the edited position untouched, got %+v", got)
+	}
+	if got := s.Model.Items[1].Labels; len(got) != 1 || got[0] != "bug" {
+		t.Fatalf("expected the result applied to the edited item, got %v", got)
+	}
+
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{Project: s.Model.Project, Items: []state.Item{other}})
+	s, _ = Update(s, msg)
+	if len(s.Model.Items) != 1 || len(s.Model.Items[0].Labels) != 0 {
+		t.Fatalf("expected the result for an item no longer loaded dropped, got %+v", s.Model.Items)
+	}
+}
+
+func TestEditResultsReachUpdateBehindModals(t *testing.T) {
+	modes := []state.ViewMode{state.ModeStatusSelect, state.ModeLabelSelect, state.ModeFieldEdit, state.ModeIterationSelect}
+	for _, mode := range modes {
+		t.Run(string(mode), func(t *testing.T) {
+			s := offlineTestState(t, &offlineClient{})
+			label := newMutation(s, state.MutationLabels, s.Model.Items[0])
+			label.Values = []string{"bug"}
+			s, _ = sendMutation(s, label, func(state.Item) tea.Msg { return nil })
+			s.Model.View.Mode = mode
+
+			s, _ = Update(s, core.ErrMsg{Err: errors.New("graphql error: label not found"), Rollback: &label})
+			if len(s.Model.Items[0].Labels) != 0 || s.Model.View.Mode != mode {
+				t.Fatalf("expected the failed edit rolled back with the modal still open, got %v in %q", s.Model.Items[0].Labels, s.Model.View.Mode)
+			}
+
+			s, _ = Update(s, core.MutationQueuedMsg{Mutation: label})
+			if len(s.Model.PendingMutations) != 1 || !s.Model.Offline {
+				t.Fatalf("expected the edit queued for replay, got %d pending", len(s.Model.PendingMutations))
+			}
+
+			s, _ = Update(s, core.ItemUpdatedMsg{ItemID: "PVTI_1", Item: state.Item{ID: "PVTI_1", Title: "Login page"}})
+			if s.Model.Items[0].Title != "Login page" {
+				t.Fatalf("expected the edit result applied, got %q", s.Model.Items[0].Title)
+			}
+		})
+	}
+}
+
+func TestFailedMutationIsRolledBack(t *testing.T) {
+	client := &offlineClient{err: errors.New("graphql error: label not found")}
+	s := offlineTestState(t, client)
+	s.Model.Items[0].Labels = []string{"bug"}
+	s.Model.Items[0].Priority = "P1"
+
+	s, cmd := SaveLabelsInput(s, SaveLabelsInputMsg{Labels: "missing"})
+	if got := s.Model.Items[0].Labels; len(got) != 1 || got[0] != "missing" {
+		t.Fatalf("expected optimistic labels, got %v", got)
+	}
+	s.Model.Items[0].Priority = "P0"
+
+	errMsg, ok := cmd().(core.ErrMsg)
+	if !ok || errMsg.Rollback == nil {
+		t.Fatalf("expected ErrMsg carrying the mutation to roll back, got %#v", cmd())
+	}
+	s, _ = Update(s, errMsg)
+	if got := s.Model.Items[0].Labels; len(got) != 1 || got[0] != "bug" {
+		t.Fatalf("expected labels rolled back, got %v", got)
+	}
+	if s.Model.Items[0].Priority != "P0" {
+		t.Fatalf("expected untouched fields to be kept, got %q", s.Model.Items[0].Priority)
+	}
+	last := s.Model.Notifications[len(s.Model.Notifications)-1]
+	if last.Level != "error" || !strings.Contains(last.Message, "Reverted") || !strings.Contains(last.Message, "label not found") {
+		t.Fatalf("expected revert notification, got %+v", last)
+	}
+}
+
+func TestRollbackKeepsNewerEdits(t *testing.T) {
+	s := offlineTestState(t, &offlineClient{})
+	m := newMutation(s, state.MutationStatus, s.Model.Items[0])
+	m.OptionName = "Done"
+	s.Model.Items[0].Status = "In Progress"
+
+	s, _ = rollbackMutation(s, m, errors.New("boom"))
+	if s.Model.Items[0].Status != "In Progress" {
+		t.Fatalf("expected newer edit to survive rollback, got %q", s.Model.Items[0].Status)
+	}
+}
@@ -0,0 +1,152 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+// EnterPlanningView switches to sprint planning for the next iteration.
+func EnterPlanningView(s State) (State, tea.Cmd) {
+	s.Model.View.CurrentView = state.ViewPlanning
+	s.Model.View.PlanningPane = 0
+	s.Model.View.PlanningIndex = 0
+	return s, nil
+}
+
+// planningPaneItems returns the items listed in the focused planning pane.
+func planningPaneItems(s State, plan state.SprintPlan) []state.Item {
+	if s.Model.View.PlanningPane == 1 {
+		return plan.Planned
+	}
+	return plan.Backlog
+}
+
+// PlanningKey handles the planning view's keys. It reports false for keys the
+// global key handler should process instead.
+func PlanningKey(s State, key string) (State, tea.Cmd, bool) {
+	plan := state.BuildSprintPlan(s.Model.Project, s.Model.Items, time.Now())
+	items := planningPaneItems(s, plan)
+	switch key {
+	case "j", "down":
+		s.Model.View.PlanningIndex = clampIndex(s.Model.View.PlanningIndex+1, len(items))
+	case "k", "up":
+		s.Model.View.PlanningIndex = clampIndex(s.Model.View.PlanningIndex-1, len(items))
+	case "h", "left":
+		s.Model.View.PlanningPane = 0
+		s.Model.View.PlanningIndex = clampIndex(s.Model.View.PlanningIndex, len(plan.Backlog))
+	case "l", "right":
+		s.Model.View.PlanningPane = 1
+		s.Model.View.PlanningIndex = clampIndex(s.Model.View.PlanningIndex, len(plan.Planned))
+	case "g":
+		s.Model.View.PlanningIndex = 0
+	case "G", "shift+G":
+		s.Model.View.PlanningIndex = clampIndex(len(items)-1, len(items))
+	case " ", "enter":
+		s, cmd := togglePlanned(s, plan, items)
+		return s, cmd, true
+	case "C":
+		s, cmd := CarryOver(s)
+		return s, cmd, true
+	case "o":
+		s, cmd := openPlanningItem(s, items)
+		return s, cmd, true
+	case "1", "b", "2", "t", "3", "4", "5", "6", "7", "R", "u", "ctrl+r", "!", "q", "ctrl+c":
+		return s, nil, false
+	}
+	return s, nil, true
+}
+
+// togglePlanned moves the selected backlog item into the next iteration, or
+// the selected planned item back to the backlog.
+func togglePlanned(s State, plan state.SprintPlan, items []state.Item) (State, tea.Cmd) {
+	idx := s.Model.View.PlanningIndex
+	if idx < 0 || idx >= len(items) {
+		return s, nil
+	}
+	if plan.Next == nil {
+		notif := state.Notification{Message: "No upcoming iteration to plan", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	if _, ok := state.FindField(s.Model.Project.Fields, state.FieldTypeIteration, "Iteration"); !ok {
+		notif := state.Notification{Message: "Iteration field not found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	target := plan.Next
+	if s.Model.View.PlanningPane == 1 {
+		target = nil
+	}
+	for i, item := range s.Model.Items {
+		if item.ID == items[idx].ID {
+			s, cmd := moveToIteration(s, i, target)
+			s.Model.View.PlanningIndex = clampIndex(idx, len(items)-1)
+			return s, cmd
+		}
+	}
+	return s, nil
+}
+
+// CarryOver moves every unfinished item of the current iteration to the next
+// one as a single bulk edit.
+func CarryOver(s State) (State, tea.Cmd) {
+	now := time.Now()
+	next := state.NextIteration(state.ProjectIterations(s.Model.Project, s.Model.Items), now)
+	if next == nil {
+		notif := state.Notification{Message: "No upcoming iteration to carry items over to", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	field, ok := state.FindField(s.Model.Project.Fields, state.FieldTypeIteration, "Iteration")
+	if !ok {
+		notif := state.Notification{Message: "Iteration field not found in project", Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+
+	carry := state.CarryOverItems(s.Model.Items, now)
+	var cmds []tea.Cmd
+	if len(carry) > 0 {
+		var bulkCmd tea.Cmd
+		s, bulkCmd = bulkEditItems(s, carry, func(item state.Item) (state.Mutation, error) {
+			mutation := newMutation(s, state.MutationIteration, item)
+			mutation.FieldID = field.ID
+			mutation.FieldName = field.Name
+			mutation.Iteration = next
+			return mutation, validProjectItem(item)
+		})
+		cmds = append(cmds, bulkCmd)
+	}
+
+	message := fmt.Sprintf("Carried %d unfinished items over to %s", len(carry), next.Name)
+	switch len(carry) {
+	case 0:
+		message = "Nothing to carry over from the current iteration"
+	case 1:
+		message = fmt.Sprintf("Carried 1 unfinished item over to %s", next.Name)
+	}
+	notif := state.Notification{Message: message, Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	return s, tea.Batch(cmds...)
+}
+
+func openPlanningItem(s State, items []state.Item) (State, tea.Cmd) {
+	idx := s.Model.View.PlanningIndex
+	if idx < 0 || idx >= len(items) {
+		return s, nil
+	}
+	for i, item := range s.Model.Items {
+		if item.ID == items[idx].ID {
+			s.Model.View.FocusedIndex = i
+			s.Model.View.FocusedItemID = item.ID
+			return EnterDetailMode(s)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,71 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+func planningTestState() State {
+	now := time.Now()
+	start := now.AddDate(0, 0, -3)
+	currentEnd := start.AddDate(0, 0, 14)
+	nextEnd := currentEnd.AddDate(0, 0, 14)
+	model := state.Model{
+		Project: state.Project{ID: "1", NodeID: "PVT_1", Owner: "acme",
+			Fields: []state.Field{{ID: "PVTIF_sprint", Name: "Sprint", Type: state.FieldTypeIteration}},
+			Iterations: []state.Timeline{
+				{ID: "it-1", Name: "Sprint 1", Start: &start, End: &currentEnd},
+				{ID: "it-2", Name: "Sprint 2", Start: &currentEnd, End: &nextEnd},
+			},
+		},
+		Items: []state.Item{
+			{ID: "PVTI_1", Title: "Backlog item", Status: "Todo"},
+			{ID: "PVTI_2", Title: "Unfinished", Status: "In Progress", IterationID: "it-1", IterationName: "Sprint 1", IterationStart: &start, IterationDurationDays: 14},
+			{ID: "PVTI_3", Title: "Finished", Status: "Done", State: "CLOSED", IterationID: "it-1", IterationName: "Sprint 1", IterationStart: &start, IterationDurationDays: 14},
+		},
+		View: state.ViewContext{CurrentView: state.ViewBoard, Mode: state.ModeNormal},
+	}
+	return NewState(model, &mockClient{}, 100)
+}
+
+func TestPlanningMovesBacklogItemIntoNextIteration(t *testing.T) {
+	s, _ := HandleKey(planningTestState(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	if s.Model.View.CurrentView != state.ViewPlanning {
+		t.Fatalf("expected planning view, got %q", s.Model.View.CurrentView)
+	}
+
+	s, cmd := HandleKey(s, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if got := s.Model.Items[0]; got.IterationID != "it-2" {
+		t.Fatalf("expected backlog item planned into Sprint 2, got %+v", got)
+	}
+	if cmd == nil {
+		t.Fatalf("expected mutation command")
+	}
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if got := s.Model.Items[0]; got.IterationID != "" {
+		t.Fatalf("expected planned item moved back to the backlog, got %+v", got)
+	}
+}
+
+func TestPlanningCarryOver(t *testing.T) {
+	s, _ := EnterPlanningView(planningTestState())
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	if got := s.Model.Items[1]; got.IterationID != "it-2" {
+		t.Fatalf("expected unfinished item carried over, got %+v", got)
+	}
+	if got := s.Model.Items[2]; got.IterationID != "it-1" {
+		t.Fatalf("expected finished item to stay, got %+v", got)
+	}
+	if len(s.Model.UndoStack) != 1 || len(s.Model.UndoStack[0].Mutations) != 1 {
+		t.Fatalf("expected the carry-over undone as one bulk edit, got %+v", s.Model.UndoStack)
+	}
+	if len(s.Model.Notifications) != 1 || s.Model.Notifications[0].Message != "Carried 1 unfinished item over to Sprint 2" {
+		t.Fatalf("unexpected notifications: %+v", s.Model.Notifications)
+	}
+}
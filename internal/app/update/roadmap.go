@@ -0,0 +1,74 @@
+package update
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+// EnterRoadmapView switches to the roadmap, scrolled back to today.
+func EnterRoadmapView(s State) (State, tea.Cmd) {
+	s.Model.View.CurrentView = state.ViewRoadmap
+	s.Model.View.RoadmapIndex = 0
+	s.Model.View.RoadmapOffset = 0
+	if s.Model.View.RoadmapZoom == "" {
+		s.Model.View.RoadmapZoom = state.RoadmapMonth
+	}
+	return s, nil
+}
+
+// roadmapRows returns the roadmap rows for the items the board filter keeps.
+func roadmapRows(s State) []state.RoadmapRow {
+	items := state.ApplyFilter(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, time.Now())
+	return state.BuildRoadmap(items, s.Model.Project.Fields)
+}
+
+// RoadmapKey handles the roadmap view's keys. It reports false for keys the
+// global key handler should process instead.
+func RoadmapKey(s State, key string) (State, tea.Cmd, bool) {
+	rows := roadmapRows(s)
+	zoom := s.Model.View.RoadmapZoom
+	switch key {
+	case "j", "down":
+		s.Model.View.RoadmapIndex = clampIndex(s.Model.View.RoadmapIndex+1, len(rows))
+	case "k", "up":
+		s.Model.View.RoadmapIndex = clampIndex(s.Model.View.RoadmapIndex-1, len(rows))
+	case "g":
+		s.Model.View.RoadmapIndex = 0
+	case "G", "shift+G":
+		s.Model.View.RoadmapIndex = clampIndex(len(rows)-1, len(rows))
+	case "h", "left":
+		s.Model.View.RoadmapOffset -= zoom.StepDays()
+	case "l", "right":
+		s.Model.View.RoadmapOffset += zoom.StepDays()
+	case "T":
+		s.Model.View.RoadmapOffset = 0
+	case "+", "=":
+		s.Model.View.RoadmapZoom = zoom.ZoomIn()
+	case "-":
+		s.Model.View.RoadmapZoom = zoom.ZoomOut()
+	case "o", "enter":
+		s, cmd := openRoadmapItem(s, rows)
+		return s, cmd, true
+	case "1", "b", "2", "t", "3", "4", "5", "6", "7", "/", "?", "esc", "R", "u", "ctrl+r", "!", "q", "ctrl+c":
+		return s, nil, false
+	}
+	return s, nil, true
+}
+
+func openRoadmapItem(s State, rows []state.RoadmapRow) (State, tea.Cmd) {
+	idx := s.Model.View.RoadmapIndex
+	if idx < 0 || idx >= len(rows) {
+		return s, nil
+	}
+	for i, item := range s.Model.Items {
+		if item.ID == rows[idx].Item.ID {
+			s.Model.View.FocusedIndex = i
+			s.Model.View.FocusedItemID = item.ID
+			return EnterDetailMode(s)
+		}
+	}
+	return s, nil
+}
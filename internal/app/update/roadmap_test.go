@@ -0,0 +1,52 @@
+package update
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+func TestRoadmapFollowsBoardFilterAndZooms(t *testing.T) {
+	model := state.Model{
+		Project: state.Project{ID: "1", Owner: "acme", Fields: []state.Field{
+			{Name: "Start date", Type: state.FieldTypeDate},
+			{Name: "Target date", Type: state.FieldTypeDate},
+		}},
+		Items: []state.Item{
+			{ID: "PVTI_1", Title: "Later", Status: "Todo", FieldValues: map[string][]string{"Start date": {"2026-11-02"}}},
+			{ID: "PVTI_2", Title: "Sooner", Status: "Todo", FieldValues: map[string][]string{"Start date": {"2026-10-05"}}},
+			{ID: "PVTI_3", Title: "Hidden", Status: "Done", FieldValues: map[string][]string{"Start date": {"2026-10-01"}}},
+		},
+		View: state.ViewContext{CurrentView: state.ViewBoard, Mode: state.ModeNormal, Filter: state.ParseFilter("status:Todo")},
+	}
+	s := NewState(model, &mockClient{}, 100)
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	if s.Model.View.CurrentView != state.ViewRoadmap || s.Model.View.RoadmapZoom != state.RoadmapMonth {
+		t.Fatalf("expected roadmap at month zoom, got %q at %q", s.Model.View.CurrentView, s.Model.View.RoadmapZoom)
+	}
+
+	for _, key := range []string{"j", "j", "j"} {
+		s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	if s.Model.View.RoadmapIndex != 1 {
+		t.Fatalf("expected the filter to leave two rows, got index %d", s.Model.View.RoadmapIndex)
+	}
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if s.Model.View.RoadmapZoom != state.RoadmapQuarter || s.Model.View.RoadmapOffset != 28 {
+		t.Fatalf("expected quarter zoom scrolled one step, got %q by %d", s.Model.View.RoadmapZoom, s.Model.View.RoadmapOffset)
+	}
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if s.Model.View.RoadmapOffset != 0 {
+		t.Fatalf("expected T to scroll back to today, got %d", s.Model.View.RoadmapOffset)
+	}
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if s.Model.View.Mode != state.ModeDetail || s.Model.View.FocusedItemID != "PVTI_1" {
+		t.Fatalf("expected detail for the later item, got mode %q on %q", s.Model.View.Mode, s.Model.View.FocusedItemID)
+	}
+}
@@ -0,0 +1,244 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	boardPkg "project-hub/internal/ui/board"
+)
+
+// EnterViewPicker opens the saved view picker. It lists the views from the
+// config file followed by the project's views on GitHub that have not been
+// saved under the same name.
+func EnterViewPicker(s State) (State, tea.Cmd) {
+	s.Model.View.ViewPickerIndex = 0
+	s.Model.View.Mode = state.ModeViewPicker
+	return s, nil
+}
+
+// GitHubViews returns the project's GitHub views not already saved in the
+// config file.
+func GitHubViews(m state.Model) []state.SavedView {
+	var views []state.SavedView
+	for _, v := range m.Project.SavedViews {
+		if _, ok := state.FindSavedView(m.SavedViews, v.Name); !ok {
+			views = append(views, v)
+		}
+	}
+	return views
+}
+
+// ViewPickerKey handles keys in the saved view picker.
+func ViewPickerKey(s State, key string) (State, tea.Cmd) {
+	github := GitHubViews(s.Model)
+	total := len(s.Model.SavedViews) + len(github)
+	idx := s.Model.View.ViewPickerIndex
+	switch key {
+	case "j", "down":
+		if idx < total-1 {
+			s.Model.View.ViewPickerIndex++
+		}
+	case "k", "up":
+		if idx > 0 {
+			s.Model.View.ViewPickerIndex--
+		}
+	case "enter":
+		if idx < 0 || idx >= total {
+			return s, nil
+		}
+		s.Model.View.Mode = state.ModeNormal
+		if idx < len(s.Model.SavedViews) {
+			return applySavedView(s, s.Model.SavedViews[idx])
+		}
+		return applyGitHubView(s, github[idx-len(s.Model.SavedViews)])
+	case "n":
+		return enterSaveView(s)
+	case "d":
+		if idx < 0 || idx >= len(s.Model.SavedViews) {
+			return s, nil
+		}
+		name := s.Model.SavedViews[idx].Name
+		s.Model.SavedViews = append(append([]state.SavedView(nil), s.Model.SavedViews[:idx]...), s.Model.SavedViews[idx+1:]...)
+		if s.Model.View.ViewPickerIndex >= total-1 && s.Model.View.ViewPickerIndex > 0 {
+			s.Model.View.ViewPickerIndex--
+		}
+		return savedViewsChanged(s, fmt.Sprintf("Deleted view %q", name))
+	case "i":
+		if len(github) == 0 {
+			return s, nil
+		}
+		var cmds []tea.Cmd
+		for _, v := range github {
+			imported, filter := githubView(s, v)
+			s.Model.SavedViews = state.PutSavedView(s.Model.SavedViews, imported)
+			var reportCmd tea.Cmd
+			s, reportCmd = reportImportedFilter(s, v.Name, filter)
+			cmds = append(cmds, reportCmd)
+		}
+		s, savedCmd := savedViewsChanged(s, fmt.Sprintf("Imported %d views from GitHub", len(github)))
+		return s, tea.Batch(append(cmds, savedCmd)...)
+	case "esc", "q":
+		s.Model.View.Mode = state.ModeNormal
+	}
+	return s, nil
+}
+
+// githubView fills in what a GitHub view does not carry with the current
+// sort, grouping and card fields, and adapts its filter to run here.
+func githubView(s State, v state.SavedView) (state.SavedView, state.ImportedFilter) {
+	filter := state.ImportGitHubFilter(v.Query)
+	v.Query = filter.Query
+	v.TableSort = s.Model.View.TableSort
+	v.TableGroupBy = s.Model.View.TableGroupBy
+	v.CardFieldVisibility = s.Model.View.CardFieldVisibility
+	return v, filter
+}
+
+// applyGitHubView applies a view saved on GitHub and reports the filter
+// terms only GitHub evaluates.
+func applyGitHubView(s State, v state.SavedView) (State, tea.Cmd) {
+	imported, filter := githubView(s, v)
+	s, reportCmd := reportImportedFilter(s, v.Name, filter)
+	s, applyCmd := applySavedView(s, imported)
+	return s, tea.Batch(reportCmd, applyCmd)
+}
+
+// reportImportedFilter warns about the terms of a GitHub view's filter that
+// only GitHub evaluates and those dropped because nothing can.
+func reportImportedFilter(s State, name string, filter state.ImportedFilter) (State, tea.Cmd) {
+	var parts []string
+	if len(filter.ServerOnly) > 0 {
+		parts = append(parts, "only GitHub evaluates "+strings.Join(filter.ServerOnly, " "))
+	}
+	if len(filter.Dropped) > 0 {
+		parts = append(parts, "dropped "+strings.Join(filter.Dropped, " "))
+	}
+	if len(parts) == 0 {
+		return s, nil
+	}
+	notif := state.Notification{Message: fmt.Sprintf("View %q: %s", name, strings.Join(parts, "; ")), Level: "warn", At: time.Now(), DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+}
+
+// openPendingView opens the view asked for on the command line once the
+// project's GitHub views have been fetched.
+func openPendingView(s State) (State, tea.Cmd) {
+	name := s.Model.PendingView
+	if name == "" {
+		return s, nil
+	}
+	s.Model.PendingView = ""
+	v, ok := state.FindSavedView(s.Model.Project.SavedViews, name)
+	if !ok {
+		notif := state.Notification{Message: fmt.Sprintf("Unknown view %q", name), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	return applyGitHubView(s, v)
+}
+
+// enterSaveView asks for the name to save the current view under.
+func enterSaveView(s State) (State, tea.Cmd) {
+	s.TextInput.Width = s.Model.Width - 10
+	if s.TextInput.Width < 30 {
+		s.TextInput.Width = 30
+	}
+	s.TextInput.SetValue("")
+	s.TextInput.Prompt = "SAVE VIEW AS "
+	s.TextInput.Placeholder = "Enter a name..."
+	s.Model.View.Mode = state.ModeSaveView
+	return s, s.TextInput.Focus()
+}
+
+// SaveView saves the current filter, view, sort, grouping and card fields
+// under name, replacing a view saved under the same name.
+func SaveView(s State, name string) (State, tea.Cmd) {
+	name = strings.TrimSpace(name)
+	s.TextInput.Prompt = ""
+	if name == "" {
+		s.Model.View.Mode = state.ModeViewPicker
+		return s, nil
+	}
+	v := state.SavedView{
+		Name:                name,
+		Query:               s.Model.View.Filter.Raw,
+		View:                s.Model.View.CurrentView,
+		TableSort:           s.Model.View.TableSort,
+		TableGroupBy:        s.Model.View.TableGroupBy,
+		CardFieldVisibility: s.Model.View.CardFieldVisibility,
+	}
+	s.Model.SavedViews = state.PutSavedView(s.Model.SavedViews, v)
+	s.Model.View.Mode = state.ModeNormal
+	return savedViewsChanged(s, fmt.Sprintf("Saved view %q", name))
+}
+
+// CancelSaveView returns to the picker without saving.
+func CancelSaveView(s State) (State, tea.Cmd) {
+	s.TextInput.Prompt = ""
+	s.Model.View.Mode = state.ModeViewPicker
+	return s, nil
+}
+
+// savedViewsChanged writes the saved views to the config file.
+func savedViewsChanged(s State, message string) (State, tea.Cmd) {
+	cmds := []tea.Cmd{core.SaveViewsCmd(s.Model.SavedViews)}
+	if !s.Model.SuppressHints {
+		notif := state.Notification{Message: message, Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	}
+	return s, tea.Batch(cmds...)
+}
+
+// applySavedView applies the filter of v and switches to its view with its
+// sort, grouping and card fields. A view written to the config file without
+// card fields keeps the current ones. A filter that no longer parses is
+// reported and nothing changes.
+func applySavedView(s State, v state.SavedView) (State, tea.Cmd) {
+	if fs := state.ParseFilter(v.Query); fs.Err != nil {
+		notif := state.Notification{Message: fmt.Sprintf("View %q has an invalid filter: %v", v.Name, fs.Err), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+
+	var filterCmd tea.Cmd
+	if strings.TrimSpace(v.Query) == "" {
+		s, filterCmd = ClearFilter(s, ClearFilterMsg{})
+	} else {
+		s, filterCmd = ApplyFilter(s, ApplyFilterMsg{Query: v.Query})
+	}
+	s.Model.View.TableSort = v.TableSort
+	if v.TableGroupBy != "" || s.Model.View.Filter.GroupBy == "" {
+		s.Model.View.TableGroupBy = v.TableGroupBy
+	}
+	if v.CardFieldVisibility != (state.CardFieldVisibility{}) {
+		s.Model.View.CardFieldVisibility = v.CardFieldVisibility
+	}
+	s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+
+	s, viewCmd := switchToView(s, v.View)
+	return s, tea.Batch(filterCmd, viewCmd)
+}
+
+// switchToView shows view the same way its number key does.
+func switchToView(s State, view state.ViewType) (State, tea.Cmd) {
+	switch view {
+	case state.ViewDigest:
+		return EnterDigestView(s)
+	case state.ViewPlanning:
+		return EnterPlanningView(s)
+	case state.ViewRoadmap:
+		return EnterRoadmapView(s)
+	case state.ViewInsights:
+		return EnterInsightsView(s)
+	case state.ViewBoard, state.ViewTable:
+		return SwitchView(s, SwitchViewMsg{View: view})
+	}
+	return s, nil
+}
@@ -0,0 +1,111 @@
+package update
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+func TestSaveViewAndApplyIt(t *testing.T) {
+	s := NewState(state.Model{Items: []state.Item{{ID: "1", Title: "A", Labels: []string{"bug"}}}}, &mockClient{}, 100)
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:bug"})
+	s.Model.View.CurrentView = state.ViewTable
+	s.Model.View.TableSort = state.TableSort{Field: "Title", Asc: true}
+	s.Model.View.CardFieldVisibility = state.CardFieldVisibility{ShowLabels: true}
+
+	s, _ = EnterViewPicker(s)
+	s, _ = ViewPickerKey(s, "n")
+	if s.Model.View.Mode != state.ModeSaveView {
+		t.Fatalf("expected name input, got %q", s.Model.View.Mode)
+	}
+	s, cmd := SaveView(s, " Bugs ")
+	if cmd == nil || len(s.Model.SavedViews) != 1 {
+		t.Fatalf("expected the view saved and written to config, got %+v", s.Model.SavedViews)
+	}
+	want := state.SavedView{Name: "Bugs", Query: "label:bug", View: state.ViewTable, TableSort: state.TableSort{Field: "Title", Asc: true}, CardFieldVisibility: state.CardFieldVisibility{ShowLabels: true}}
+	if !reflect.DeepEqual(s.Model.SavedViews[0], want) {
+		t.Fatalf("saved %+v, want %+v", s.Model.SavedViews[0], want)
+	}
+
+	s, _ = ClearFilter(s, ClearFilterMsg{})
+	s.Model.View.CurrentView = state.ViewBoard
+	s.Model.View.TableSort = state.TableSort{}
+	s, _ = EnterViewPicker(s)
+	s, _ = ViewPickerKey(s, "enter")
+	if s.Model.View.Mode != state.ModeNormal || s.Model.View.CurrentView != state.ViewTable || s.Model.View.Filter.Raw != "label:bug" || s.Model.View.TableSort.Field != "Title" {
+		t.Fatalf("expected the saved view applied, got %+v", s.Model.View)
+	}
+}
+
+func TestViewPickerImportsAndDeletesViews(t *testing.T) {
+	s := NewState(state.Model{
+		Project:    state.Project{SavedViews: []state.SavedView{{Name: "Bugs", Query: "label:bug"}, {Name: "Board", View: state.ViewBoard}}},
+		SavedViews: []state.SavedView{{Name: "bugs", Query: "label:bug -status:Done"}},
+	}, &mockClient{}, 100)
+	s.Model.View.TableGroupBy = "status"
+
+	if got := GitHubViews(s.Model); len(got) != 1 || got[0].Name != "Board" {
+		t.Fatalf("expected GitHub views not saved by name, got %+v", got)
+	}
+	s, _ = EnterViewPicker(s)
+	s, _ = ViewPickerKey(s, "i")
+	if len(s.Model.SavedViews) != 2 || s.Model.SavedViews[1].Name != "Board" || s.Model.SavedViews[1].TableGroupBy != "status" {
+		t.Fatalf("expected Board imported with the current grouping, got %+v", s.Model.SavedViews)
+	}
+
+	s, _ = ViewPickerKey(s, "j")
+	s, _ = ViewPickerKey(s, "d")
+	if len(s.Model.SavedViews) != 1 || s.Model.View.ViewPickerIndex != 0 {
+		t.Fatalf("expected the focused view deleted, got %+v at %d", s.Model.SavedViews, s.Model.View.ViewPickerIndex)
+	}
+}
+
+func TestApplySavedViewReportsInvalidFilter(t *testing.T) {
+	s := NewState(state.Model{SavedViews: []state.SavedView{{Name: "Broken", Query: "(label:bug", View: state.ViewTable}}}, &mockClient{}, 100)
+	s, _ = EnterViewPicker(s)
+	s, _ = ViewPickerKey(s, "enter")
+	if s.Model.View.CurrentView == state.ViewTable || len(s.Model.Notifications) != 1 || s.Model.Notifications[0].Level != "error" {
+		t.Fatalf("expected an error and no view change, got view %q notifications %+v", s.Model.View.CurrentView, s.Model.Notifications)
+	}
+}
+
+func TestApplyGitHubViewReportsGitHubOnlyTerms(t *testing.T) {
+	s := NewState(state.Model{
+		Items:   []state.Item{{ID: "1", Title: "A", Labels: []string{"bug"}}, {ID: "2", Title: "B"}},
+		Project: state.Project{SavedViews: []state.SavedView{{Name: "Mine", Query: "assignee:@me (reason:completed OR label:bug)", View: state.ViewTable}}},
+	}, &mockClient{}, 100)
+
+	s, _ = EnterViewPicker(s)
+	s, _ = ViewPickerKey(s, "enter")
+	if s.Model.View.Filter.Raw != "assignee:@me label:bug" || s.Model.View.CurrentView != state.ViewTable {
+		t.Fatalf("expected the view applied without the dropped term, got %q in %q", s.Model.View.Filter.Raw, s.Model.View.CurrentView)
+	}
+	if got := state.ApplyFilter(s.Model.Items, nil, s.Model.View.Filter, time.Now()); len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("expected assignee:@me left to GitHub, got %+v", got)
+	}
+	want := `View "Mine": only GitHub evaluates assignee:@me; dropped reason:completed`
+	if len(s.Model.Notifications) == 0 || s.Model.Notifications[0].Message != want || s.Model.Notifications[0].Level != "warn" {
+		t.Fatalf("expected the GitHub-only terms reported, got %+v", s.Model.Notifications)
+	}
+}
+
+func TestPendingViewOpensOnceProjectIsFetched(t *testing.T) {
+	s := NewState(state.Model{PendingView: "mine"}, &mockClient{}, 100)
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{
+		Project: state.Project{ID: "1", Owner: "acme", SavedViews: []state.SavedView{{Name: "Mine", Query: "label:bug", View: state.ViewTable}}},
+		Items:   []state.Item{{ID: "1", Labels: []string{"bug"}}},
+	})
+	if s.Model.PendingView != "" || s.Model.View.Filter.Raw != "label:bug" || s.Model.View.CurrentView != state.ViewTable {
+		t.Fatalf("expected the fetched GitHub view opened, got %q in %q", s.Model.View.Filter.Raw, s.Model.View.CurrentView)
+	}
+
+	s.Model.PendingView = "missing"
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{Project: s.Model.Project})
+	last := s.Model.Notifications[len(s.Model.Notifications)-1]
+	if last.Level != "error" || last.Message != `Unknown view "missing"` {
+		t.Fatalf("expected an unknown view reported, got %+v", last)
+	}
+}
@@ -0,0 +1,203 @@
+package update
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+	boardPkg "project-hub/internal/ui/board"
+)
+
+// maxSearchCommentFetches bounds how many items' comments are fetched at
+// once while search mode is open.
+const maxSearchCommentFetches = 4
+
+// maxSearchCommentResults is how many of the best results of the query have
+// their comments fetched.
+const maxSearchCommentResults = 20
+
+// EnterSearchMode opens full-text search. Comments are fetched in the
+// background for the results of the query as it is typed.
+func EnterSearchMode(s State) (State, tea.Cmd) {
+	s.TextInput.Width = s.Model.Width - 10
+	if s.TextInput.Width < 30 {
+		s.TextInput.Width = 30
+	}
+	s.TextInput.SetValue("")
+	s.TextInput.Prompt = "SEARCH "
+	s.TextInput.Placeholder = "Search titles, bodies, comments, labels, #numbers..."
+	s.Model.View.SearchIndex = 0
+	s.Model.View.Mode = state.ModeSearch
+	return s, s.TextInput.Focus()
+}
+
+// SearchResults ranks the project's items against query.
+func SearchResults(m state.Model, query string) []state.SearchResult {
+	return state.Search(m.Items, m.SearchComments, query)
+}
+
+// SearchCommentsPending counts the items whose comments are being fetched.
+func SearchCommentsPending(m state.Model) int {
+	pending := 0
+	for _, comments := range m.SearchComments {
+		if comments == nil {
+			pending++
+		}
+	}
+	return pending
+}
+
+// fetchSearchComments starts fetching comments for the best results of the
+// current query that have none yet, issues and pull requests alike, keeping
+// at most maxSearchCommentFetches requests in flight.
+func fetchSearchComments(s State) (State, tea.Cmd) {
+	results := SearchResults(s.Model, s.TextInput.Value())
+	if len(results) > maxSearchCommentResults {
+		results = results[:maxSearchCommentResults]
+	}
+	var cmds []tea.Cmd
+	pending := SearchCommentsPending(s.Model)
+	for _, result := range results {
+		if pending >= maxSearchCommentFetches {
+			break
+		}
+		item := result.Item
+		if (item.Type != "Issue" && item.Type != "PullRequest") || item.Repository == "" || item.Number <= 0 {
+			continue
+		}
+		if _, fetched := s.Model.SearchComments[item.ID]; fetched {
+			continue
+		}
+		if s.Model.SearchComments == nil {
+			s.Model.SearchComments = make(map[string][]state.Comment)
+		}
+		s.Model.SearchComments[item.ID] = nil
+		pending++
+		cmds = append(cmds, core.FetchSearchCommentsCmd(s.Github, item))
+	}
+	return s, tea.Batch(cmds...)
+}
+
+// SearchCommentsFetched stores an item's comments and, while search mode is
+// open, fetches the next ones. Failures are stored as no comments so the
+// item is not asked for again; the rest of the item is still searched.
+func SearchCommentsFetched(s State, msg core.SearchCommentsMsg) (State, tea.Cmd) {
+	if s.Model.SearchComments == nil {
+		s.Model.SearchComments = make(map[string][]state.Comment)
+	}
+	comments := msg.Comments
+	if msg.Err != nil || comments == nil {
+		comments = []state.Comment{}
+	}
+	s.Model.SearchComments[msg.ItemID] = comments
+	if s.Model.View.Mode != state.ModeSearch {
+		return s, nil
+	}
+	return fetchSearchComments(s)
+}
+
+// SearchKey handles keys in search mode. Typing refines the query; the
+// arrow keys move through the results.
+func SearchKey(s State, k tea.KeyMsg) (State, tea.Cmd) {
+	results := SearchResults(s.Model, s.TextInput.Value())
+	switch k.String() {
+	case "down", "ctrl+n":
+		s.Model.View.SearchIndex = clampIndex(s.Model.View.SearchIndex+1, len(results))
+		return s, nil
+	case "up", "ctrl+p":
+		s.Model.View.SearchIndex = clampIndex(s.Model.View.SearchIndex-1, len(results))
+		return s, nil
+	case "enter":
+		idx := s.Model.View.SearchIndex
+		if idx < 0 || idx >= len(results) {
+			return s, nil
+		}
+		return jumpToItem(closeSearch(s), results[idx].Item)
+	case "esc":
+		return closeSearch(s), nil
+	}
+	var cmd tea.Cmd
+	s.TextInput, cmd = s.TextInput.Update(k)
+	s.Model.View.SearchIndex = 0
+	s, fetchCmd := fetchSearchComments(s)
+	return s, tea.Batch(cmd, fetchCmd)
+}
+
+// pruneSearchComments forgets the comments fetched for items that are gone or
+// have changed since the previous refresh, so search fetches them again.
+func pruneSearchComments(s State) State {
+	if len(s.Model.SearchComments) == 0 {
+		return s
+	}
+	before := make(map[string]state.Item, len(s.Fetch.Previous))
+	for _, item := range s.Fetch.Previous {
+		before[item.ID] = item
+	}
+	comments := make(map[string][]state.Comment, len(s.Model.SearchComments))
+	for _, item := range s.Model.Items {
+		fetched, ok := s.Model.SearchComments[item.ID]
+		if !ok {
+			continue
+		}
+		if old, seen := before[item.ID]; fetched != nil && (!seen || !sameUpdate(old, item)) {
+			continue
+		}
+		comments[item.ID] = fetched
+	}
+	s.Model.SearchComments = comments
+	return s
+}
+
+func sameUpdate(a, b state.Item) bool {
+	if a.UpdatedAt == nil || b.UpdatedAt == nil {
+		return a.UpdatedAt == b.UpdatedAt
+	}
+	return a.UpdatedAt.Equal(*b.UpdatedAt)
+}
+
+func closeSearch(s State) State {
+	s.Model.View.Mode = state.ModeNormal
+	s.TextInput.Prompt = ""
+	s.TextInput.SetValue("")
+	return s
+}
+
+// jumpToItem focuses item in the current view, clearing the filter first if
+// it hides the item.
+func jumpToItem(s State, item state.Item) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	visible := state.ApplyFilter([]state.Item{item}, s.Model.Project.Fields, s.Model.View.Filter, time.Now())
+	if len(visible) == 0 {
+		var clearCmd tea.Cmd
+		s, clearCmd = ClearFilter(s, ClearFilterMsg{})
+		cmds = append(cmds, clearCmd)
+		if !s.Model.SuppressHints {
+			notif := state.Notification{Message: fmt.Sprintf("Cleared the filter to show %q", item.Title), Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+			s.Model.Notifications = append(s.Model.Notifications, notif)
+			cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+		}
+	}
+
+	for i := range s.Model.Items {
+		if s.Model.Items[i].ID == item.ID {
+			s.Model.View.FocusedIndex = i
+			s.Model.View.FocusedItemID = item.ID
+			break
+		}
+	}
+	switch s.Model.View.CurrentView {
+	case state.ViewBoard:
+		s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
+	case state.ViewRoadmap:
+		for i, row := range roadmapRows(s) {
+			if row.Item.ID == item.ID {
+				s.Model.View.RoadmapIndex = i
+				break
+			}
+		}
+	}
+	return s, tea.Batch(cmds...)
+}
@@ -0,0 +1,115 @@
+package update
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+func searchTestState() State {
+	var items []state.Item
+	for i := 1; i <= 6; i++ {
+		items = append(items, state.Item{ID: fmt.Sprintf("PVTI_%d", i), Type: "Issue", Title: fmt.Sprintf("Issue %d", i), Repository: "acme/app", Number: i})
+	}
+	items = append(items, state.Item{ID: "PVTI_draft", Type: "DraftIssue", Title: "Draft", Labels: []string{"bug"}})
+	items = append(items, state.Item{ID: "PVTI_pr", Type: "PullRequest", Title: "Fix login", Repository: "acme/app", Number: 7})
+	model := state.Model{
+		Items:         items,
+		View:          state.ViewContext{CurrentView: state.ViewTable, Mode: state.ModeNormal},
+		SuppressHints: true,
+	}
+	return NewState(model, &mockClient{}, 100)
+}
+
+func typeSearch(s State, query string) State {
+	for _, r := range query {
+		s, _ = SearchKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return s
+}
+
+func TestSearchFetchesCommentsOfResultsInBatches(t *testing.T) {
+	s, _ := EnterSearchMode(searchTestState())
+	if s.Model.View.Mode != state.ModeSearch || len(s.Model.SearchComments) != 0 {
+		t.Fatalf("expected no comments fetched before a query, got %d", len(s.Model.SearchComments))
+	}
+
+	s = typeSearch(s, "issue")
+	if SearchCommentsPending(s.Model) != maxSearchCommentFetches {
+		t.Fatalf("expected %d comment fetches in flight, got %d", maxSearchCommentFetches, SearchCommentsPending(s.Model))
+	}
+	if _, requested := s.Model.SearchComments["PVTI_pr"]; requested {
+		t.Fatalf("expected no comment fetch for an item the query does not match")
+	}
+
+	s, cmd := SearchCommentsFetched(s, core.SearchCommentsMsg{ItemID: "PVTI_2", Comments: []state.Comment{{Author: "bob", Body: "Reproduced on the staging cluster"}}})
+	if cmd == nil || SearchCommentsPending(s.Model) != maxSearchCommentFetches || len(s.Model.SearchComments) != maxSearchCommentFetches+1 {
+		t.Fatalf("expected the next issue fetched once one arrives, got %d pending of %d", SearchCommentsPending(s.Model), len(s.Model.SearchComments))
+	}
+
+	results := SearchResults(s.Model, "staging")
+	if len(results) != 1 || results[0].Item.Number != 2 || results[0].Field != "comment" {
+		t.Fatalf("expected a match in the fetched comment, got %+v", results)
+	}
+
+	s = closeSearch(s)
+	if _, cmd := SearchCommentsFetched(s, core.SearchCommentsMsg{ItemID: "PVTI_1", Err: fmt.Errorf("boom")}); cmd != nil {
+		t.Fatalf("expected no more fetches once search is closed")
+	}
+}
+
+func TestSearchFetchesPullRequestComments(t *testing.T) {
+	s, _ := EnterSearchMode(searchTestState())
+	s = typeSearch(s, "login")
+	if comments, requested := s.Model.SearchComments["PVTI_pr"]; !requested || comments != nil {
+		t.Fatalf("expected the pull request's comments requested, got %+v", s.Model.SearchComments)
+	}
+	if _, requested := s.Model.SearchComments["PVTI_draft"]; requested {
+		t.Fatalf("expected no comment fetch for draft issues")
+	}
+}
+
+func TestRefreshForgetsOutdatedSearchComments(t *testing.T) {
+	s := searchTestState()
+	earlier := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	for i := range s.Model.Items {
+		s.Model.Items[i].UpdatedAt = &earlier
+	}
+	s.Model.SearchComments = map[string][]state.Comment{
+		"PVTI_1":    {{Body: "unchanged"}},
+		"PVTI_2":    {{Body: "edited since"}},
+		"PVTI_3":    nil,
+		"PVTI_gone": {{Body: "removed"}},
+	}
+
+	fresh := append([]state.Item(nil), s.Model.Items...)
+	fresh[1].UpdatedAt = &later
+	s, _ = ProjectFetched(s, core.FetchProjectMsg{Project: state.Project{ID: "1", Owner: "acme"}, Items: fresh})
+	if _, kept := s.Model.SearchComments["PVTI_1"]; !kept || len(s.Model.SearchComments) != 2 {
+		t.Fatalf("expected only unchanged and in-flight entries kept, got %+v", s.Model.SearchComments)
+	}
+	if comments, kept := s.Model.SearchComments["PVTI_3"]; !kept || comments != nil {
+		t.Fatalf("expected the fetch in flight kept, got %+v", s.Model.SearchComments)
+	}
+}
+
+func TestSearchJumpFocusesResultAndClearsHidingFilter(t *testing.T) {
+	s := searchTestState()
+	s, _ = ApplyFilter(s, ApplyFilterMsg{Query: "label:bug"})
+	s, _ = EnterSearchMode(s)
+	s = typeSearch(s, "#5")
+	s, _ = SearchKey(s, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if s.Model.View.Mode != state.ModeNormal || s.Model.View.FocusedItemID != "PVTI_5" || s.Model.View.FocusedIndex != 4 {
+		t.Fatalf("expected #5 focused, got %q at %d", s.Model.View.FocusedItemID, s.Model.View.FocusedIndex)
+	}
+	if s.Model.View.Filter.Raw != "" {
+		t.Fatalf("expected the filter hiding #5 cleared, got %q", s.Model.View.Filter.Raw)
+	}
+}
@@ -1,7 +1,6 @@
 package update
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -27,6 +26,17 @@ func StatusSelectMode(s State, msg tea.Msg) (State, tea.Cmd) {
 			s.Model.View.Mode = state.ModeNormal
 			return s, tea.Batch(cmds...)
 		}
+		if hasSelection(s) {
+			s.Model.View.Mode = state.ModeNormal
+			updated, bulkCmd := bulkEdit(s, func(item state.Item) (state.Mutation, error) {
+				mutation := newMutation(s, state.MutationStatus, item)
+				mutation.FieldID = m.StatusFieldID
+				mutation.OptionID = m.OptionID
+				mutation.OptionName = m.OptionName
+				return mutation, validProjectItem(item)
+			})
+			return updated, tea.Batch(append(cmds, bulkCmd)...)
+		}
 		idx := s.Model.View.FocusedIndex
 		if idx < 0 || idx >= len(s.Model.Items) {
 			s.Model.View.Mode = state.ModeNormal
@@ -46,26 +56,16 @@ func StatusSelectMode(s State, msg tea.Msg) (State, tea.Cmd) {
 			return s, tea.Batch(append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))...)
 		}
 
-		updateCmd := func() tea.Msg {
-			updatedItem, err := s.Github.UpdateStatus(
-				context.Background(),
-				core.ProjectMutationID(s.Model.Project),
-				s.Model.Project.Owner,
-				item.ID,
-				m.StatusFieldID,
-				m.OptionID,
-			)
-			if err != nil {
-				return core.NewErrMsg(err)
-			}
-			if strings.EqualFold(strings.TrimSpace(updatedItem.Status), "unknown") && strings.TrimSpace(m.OptionName) != "" {
-				updatedItem.Status = m.OptionName
-			}
-			return core.ItemUpdatedMsg{Index: idx, Item: updatedItem}
-		}
+		mutation := newMutation(s, state.MutationStatus, item)
+		mutation.FieldID = m.StatusFieldID
+		mutation.OptionID = m.OptionID
+		mutation.OptionName = m.OptionName
 
 		s.Model.View.Mode = state.ModeNormal
-		return s, tea.Batch(append(cmds, updateCmd)...)
+		updated, updateCmd := dispatchMutation(s, mutation, func(updatedItem state.Item) tea.Msg {
+			return core.ItemUpdatedMsg{ItemID: mutation.ItemID(), Item: updatedItem}
+		})
+		return updated, tea.Batch(append(cmds, updateCmd)...)
 	default:
 		return s, tea.Batch(cmds...)
 	}
@@ -85,6 +85,18 @@ func FieldSelectMode(s State, msg tea.Msg) (State, tea.Cmd) {
 			s.Model.View.Mode = state.ModeNormal
 			return s, tea.Batch(cmds...)
 		}
+		if hasSelection(s) {
+			s.Model.View.Mode = state.ModeNormal
+			updated, bulkCmd := bulkEdit(s, func(item state.Item) (state.Mutation, error) {
+				mutation := newMutation(s, state.MutationField, item)
+				mutation.FieldID = m.FieldID
+				mutation.FieldName = m.FieldName
+				mutation.OptionID = m.OptionID
+				mutation.OptionName = m.OptionName
+				return mutation, validProjectItem(item)
+			})
+			return updated, tea.Batch(append(cmds, bulkCmd)...)
+		}
 		idx := s.Model.View.FocusedIndex
 		if idx < 0 || idx >= len(s.Model.Items) {
 			s.Model.View.Mode = state.ModeNormal
@@ -104,24 +116,17 @@ func FieldSelectMode(s State, msg tea.Msg) (State, tea.Cmd) {
 			return s, tea.Batch(append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))...)
 		}
 
-		updateCmd := func() tea.Msg {
-			updatedItem, err := s.Github.UpdateField(
-				context.Background(),
-				core.ProjectMutationID(s.Model.Project),
-				s.Model.Project.Owner,
-				item.ID,
-				m.FieldID,
-				m.OptionID,
-				m.FieldName,
-			)
-			if err != nil {
-				return core.NewErrMsg(err)
-			}
-			return core.ItemUpdatedMsg{Index: idx, Item: updatedItem}
-		}
+		mutation := newMutation(s, state.MutationField, item)
+		mutation.FieldID = m.FieldID
+		mutation.FieldName = m.FieldName
+		mutation.OptionID = m.OptionID
+		mutation.OptionName = m.OptionName
 
 		s.Model.View.Mode = state.ModeNormal
-		return s, tea.Batch(append(cmds, updateCmd)...)
+		updated, updateCmd := dispatchMutation(s, mutation, func(updatedItem state.Item) tea.Msg {
+			return core.ItemUpdatedMsg{ItemID: mutation.ItemID(), Item: updatedItem}
+		})
+		return updated, tea.Batch(append(cmds, updateCmd)...)
 	default:
 		return s, tea.Batch(cmds...)
 	}
@@ -139,6 +144,8 @@ func DetailMode(s State, msg tea.Msg) (State, tea.Cmd) {
 			return EnterDetailEditMode(s)
 		case "a":
 			return EnterDetailCommentMode(s)
+		case "e":
+			return EnterFieldEditMode(s)
 		}
 	}
 
@@ -26,6 +26,8 @@ func SettingsSave(s State, msg settings.SaveMsg) (State, tea.Cmd) {
 		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
 	}
 
+	// Settings that are not editable in the form are carried over.
+	existing, _ := config.Load(configPath)
 	cfg := config.Config{
 		DefaultProjectID:        msg.ProjectID,
 		DefaultOwner:            msg.Owner,
@@ -34,6 +36,9 @@ func SettingsSave(s State, msg settings.SaveMsg) (State, tea.Cmd) {
 		DefaultExcludeDone:      msg.ExcludeDone,
 		CreateIssueRepoMode:     msg.CreateIssueRepoMode,
 		DefaultIterationFilters: msg.IterationFilter,
+		RefreshInterval:         existing.RefreshInterval,
+		PlanningCapacity:        existing.PlanningCapacity,
+		SavedViews:              existing.SavedViews,
 	}
 	saveErr := config.Save(configPath, cfg)
 	if saveErr != nil {
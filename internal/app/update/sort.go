@@ -0,0 +1,75 @@
+package update
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+// EnterSortInput asks for sort keys as text, e.g. "Status, -Priority,
+// -UpdatedAt", so the table can be sorted by several keys and by any
+// project field.
+func EnterSortInput(s State) (State, tea.Cmd) {
+	s.TextInput.Width = s.Model.Width - 10
+	if s.TextInput.Width < 30 {
+		s.TextInput.Width = 30
+	}
+	s.TextInput.SetValue(s.Model.View.TableSort.String())
+	s.TextInput.CursorEnd()
+	s.TextInput.Prompt = "SORT BY "
+	s.TextInput.Placeholder = "Status, -Priority, -UpdatedAt"
+	s.Model.View.Mode = state.ModeSortInput
+	return s, s.TextInput.Focus()
+}
+
+// SaveSortInput sorts the table by the typed keys. Clearing the input
+// removes the sort.
+func SaveSortInput(s State, spec string) (State, tea.Cmd) {
+	s.Model.View.TableSort = state.ParseTableSort(spec)
+	s.Model.View.Mode = state.ModeNormal
+	s.TextInput.Prompt = ""
+	return sortApplied(s)
+}
+
+// CancelSortInput leaves the sort unchanged.
+func CancelSortInput(s State) (State, tea.Cmd) {
+	s.Model.View.Mode = state.ModeNormal
+	s.TextInput.Prompt = ""
+	return s, nil
+}
+
+// sortApplied focuses the first item after the table is sorted again and
+// reports the new order.
+func sortApplied(s State) (State, tea.Cmd) {
+	if len(s.Model.Items) > 0 {
+		s.Model.View.FocusedIndex = 0
+		s.Model.View.FocusedItemID = s.Model.Items[0].ID
+	}
+	if s.Model.SuppressHints {
+		return s, nil
+	}
+	notif := state.Notification{Message: "Sort: " + describeSort(s.Model.View.TableSort), Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+}
+
+// describeSort lists the sort keys with arrows for their direction.
+func describeSort(ts state.TableSort) string {
+	keys := ts.Keys()
+	if len(keys) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		arrow := "↓"
+		if k.Asc {
+			arrow = "↑"
+		}
+		parts[i] = k.Field + " " + arrow
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,53 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+func TestSortInputSetsSeveralKeys(t *testing.T) {
+	vp := viewport.New(0, 0)
+	s := State{
+		Model: state.Model{
+			Items: []state.Item{{ID: "1", Status: "Todo"}, {ID: "2", Status: "Done"}},
+			View: state.ViewContext{
+				CurrentView: state.ViewTable,
+				Mode:        state.ModeSort,
+				TableSort:   state.TableSort{Field: "Title", Asc: true},
+			},
+			Width:  80,
+			Height: 24,
+		},
+		TextInput:     textinput.New(),
+		TableViewport: &vp,
+	}
+
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	if s.Model.View.Mode != state.ModeSortInput || s.TextInput.Value() != "Title" {
+		t.Fatalf("expected the sort input prefilled with the current sort, got mode %q value %q", s.Model.View.Mode, s.TextInput.Value())
+	}
+
+	s, _ = SaveSortInput(s, "Status, -Priority")
+	want := state.TableSort{Field: "Status", Asc: true, Then: []state.SortKey{{Field: "Priority"}}}
+	if s.Model.View.Mode != state.ModeNormal || s.Model.View.TableSort.String() != want.String() {
+		t.Fatalf("expected sort %q, got %q", want.String(), s.Model.View.TableSort.String())
+	}
+	if n := len(s.Model.Notifications); n == 0 || s.Model.Notifications[n-1].Message != "Sort: Status ↑, Priority ↓" {
+		t.Fatalf("expected the new sort reported, got %+v", s.Model.Notifications)
+	}
+}
+
+func TestSuggestionsCompleteSortFields(t *testing.T) {
+	m := suggestTestModel(state.ModeSortInput)
+	m.Project.Fields = []state.Field{{Name: "Status"}, {Name: "Estimate", Type: state.FieldTypeNumber}}
+
+	start, matches := Suggestions(m, "Status, -Est")
+	if start != len("Status, -") || len(matches) == 0 || matches[0] != "Estimate" {
+		t.Fatalf("unexpected completion %d %q", start, matches)
+	}
+}
@@ -1,8 +1,10 @@
 package update
 
 import (
+	"context"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -22,6 +24,9 @@ type State struct {
 	TextArea         textarea.Model
 	StatusSelector   components.StatusSelectorModel
 	FieldSelector    components.FieldSelectorModel
+	FieldEditor      components.FieldEditorModel
+	IterationPicker  components.IterationSelectorModel
+	Checklist        components.ChecklistModel
 	SettingsModel    settings.SettingsModel
 	DetailPanel      components.DetailPanelModel
 	DetailItem       state.Item
@@ -32,6 +37,17 @@ type State struct {
 	CreateIssueTitle string
 	CreateIssueBody  string
 	TextAreaVimMode  string
+	Spinner          spinner.Model
+	Fetch            FetchState
+}
+
+// FetchState tracks the refresh currently in flight. Each refresh gets a new
+// generation; results tagged with an older generation are discarded.
+type FetchState struct {
+	Generation int
+	Ctx        context.Context
+	Cancel     context.CancelFunc
+	Previous   []state.Item // Items shown before this refresh, for change highlighting
 }
 
 func NewState(initial state.Model, client github.Client, itemLimit int) State {
@@ -48,6 +64,9 @@ func NewState(initial state.Model, client github.Client, itemLimit int) State {
 	ta.SetWidth(60)
 	ta.SetHeight(12)
 	tableVP := viewport.New(0, 0)
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = components.HeaderLoadingStyle
 	settingsModel := settings.New(initial.Project.ID, initial.Project.Owner, initial.SuppressHints, initial.ItemLimit, initial.ExcludeDone, string(initial.CreateIssueRepoMode), initial.View.Filter.Iterations)
 	return State{
 		Model:         initial,
@@ -58,5 +77,6 @@ func NewState(initial state.Model, client github.Client, itemLimit int) State {
 		TextArea:      ta,
 		SettingsModel: settingsModel,
 		TableViewport: &tableVP,
+		Spinner:       sp,
 	}
 }
@@ -0,0 +1,116 @@
+package update
+
+import (
+	"strings"
+
+	"project-hub/internal/state"
+)
+
+// maxSuggestions bounds how many completions are offered under an input.
+const maxSuggestions = 5
+
+// Suggestions returns completions for the value being typed in the current
+// text input, best match first. A completion replaces value from start on.
+// Labels, milestones and assignees come from the cached repository metadata,
+// so nothing is offered until it has been fetched.
+func Suggestions(m state.Model, value string) (start int, matches []string) {
+	var candidates []string
+	switch m.View.Mode {
+	case "milestoneInput":
+		candidates = state.RepoValues(focusedRepos(m), state.MutationMilestone)
+	case "labelsInput", "assign":
+		kind := state.MutationLabels
+		if m.View.Mode == "assign" {
+			kind = state.MutationAssignees
+		}
+		start = strings.LastIndex(value, ",") + 1
+		for start < len(value) && value[start] == ' ' {
+			start++
+		}
+		candidates = state.RepoValues(m.Repos, kind)
+	case state.ModeCreateIssueRepo:
+		candidates = state.Repositories(m.Items)
+	case state.ModeSortInput:
+		start = strings.LastIndex(value, ",") + 1
+		for start < len(value) && strings.ContainsRune(" -+", rune(value[start])) {
+			start++
+		}
+		candidates = state.SortFields(m.Project.Fields)
+	case state.ModeFiltering:
+		var ok bool
+		start, candidates, ok = filterCandidates(m, value)
+		if !ok {
+			return 0, nil
+		}
+	default:
+		return 0, nil
+	}
+
+	query := strings.TrimPrefix(value[start:], "\"")
+	for _, c := range state.FuzzyRank(query, candidates) {
+		if c == query {
+			continue
+		}
+		if m.View.Mode == state.ModeFiltering && strings.ContainsAny(c, " \t") {
+			c = "\"" + c + "\""
+		}
+		matches = append(matches, c)
+		if len(matches) == maxSuggestions {
+			break
+		}
+	}
+	return start, matches
+}
+
+// filterCandidates finds the value of a label, assignee or milestone token
+// at the end of a filter query and returns where it starts and the values it
+// can take.
+func filterCandidates(m state.Model, query string) (int, []string, bool) {
+	tokenStart, inQuote := 0, false
+	for i, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == ' ' && !inQuote:
+			tokenStart = i + 1
+		}
+	}
+	token := query[tokenStart:]
+	colon := strings.IndexRune(token, ':')
+	if colon <= 0 {
+		return 0, nil, false
+	}
+	var kind state.MutationKind
+	switch strings.ToLower(strings.TrimLeft(token[:colon], "-(")) {
+	case "label", "labels":
+		kind = state.MutationLabels
+	case "assignee", "assignees":
+		kind = state.MutationAssignees
+	case "milestone":
+		kind = state.MutationMilestone
+	default:
+		return 0, nil, false
+	}
+	start := tokenStart + colon + 1
+	if comma := strings.LastIndex(token[colon+1:], ","); comma >= 0 {
+		start += comma + 1
+	}
+	return start, state.RepoValues(m.Repos, kind), true
+}
+
+// focusedRepos narrows the cached metadata to the repository of the focused
+// item, unless several items are selected.
+func focusedRepos(m state.Model) map[string]state.RepoMetadata {
+	if len(m.View.Selection) > 0 {
+		return m.Repos
+	}
+	idx := m.View.FocusedIndex
+	if idx < 0 || idx >= len(m.Items) {
+		return m.Repos
+	}
+	repo := m.Items[idx].Repository
+	if meta, ok := m.Repos[repo]; ok {
+		return map[string]state.RepoMetadata{repo: meta}
+	}
+	return m.Repos
+}
@@ -0,0 +1,50 @@
+package update
+
+import (
+	"strings"
+	"testing"
+
+	"project-hub/internal/state"
+)
+
+func suggestTestModel(mode state.ViewMode) state.Model {
+	return state.Model{
+		Items: []state.Item{{ID: "1", Repository: "acme/app"}},
+		Repos: map[string]state.RepoMetadata{
+			"acme/app": {
+				Labels:     []state.Label{{Name: "bug"}, {Name: "good first issue"}, {Name: "docs"}},
+				Milestones: []string{"v1.0", "v2.0"},
+				Assignees:  []string{"alice", "bob"},
+			},
+			"acme/web": {Milestones: []string{"Web launch"}},
+		},
+		View: state.ViewContext{Mode: mode},
+	}
+}
+
+func TestSuggestionsCompleteFilterTokens(t *testing.T) {
+	m := suggestTestModel(state.ModeFiltering)
+
+	start, matches := Suggestions(m, "status:Todo label:bug,goo")
+	if start != len("status:Todo label:bug,") || len(matches) != 1 || matches[0] != `"good first issue"` {
+		t.Fatalf("unexpected completion %d %q", start, matches)
+	}
+	if _, matches := Suggestions(m, "assignee:"); strings.Join(matches, ",") != "alice,bob" {
+		t.Fatalf("expected every assignee offered, got %q", matches)
+	}
+	if _, matches := Suggestions(m, "(-label:do"); len(matches) != 1 || matches[0] != "docs" {
+		t.Fatalf("expected negated, grouped tokens completed, got %q", matches)
+	}
+	if _, matches := Suggestions(m, "status:To"); len(matches) != 0 {
+		t.Fatalf("expected no completion for status, got %q", matches)
+	}
+}
+
+func TestSuggestionsOfferFocusedRepoMilestones(t *testing.T) {
+	m := suggestTestModel("milestoneInput")
+
+	start, matches := Suggestions(m, "v")
+	if start != 0 || strings.Join(matches, ",") != "v1.0,v2.0" {
+		t.Fatalf("expected acme/app milestones only, got %q", matches)
+	}
+}
@@ -0,0 +1,141 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/app/core"
+	"project-hub/internal/state"
+)
+
+// recordUndo makes m the most recent edit to undo. A new edit forgets what
+// was undone before it. Comments cannot be undone, so they leave both stacks
+// alone and only say so.
+func recordUndo(s State, m state.Mutation) (State, tea.Cmd) {
+	if !m.Reversible() {
+		if s.Model.SuppressHints {
+			return s, nil
+		}
+		notif := state.Notification{Message: "Comments can't be undone", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	s.Model.UndoStack = state.PushUndo(s.Model.UndoStack, state.UndoEntry{Mutations: []state.Mutation{m}})
+	s.Model.RedoStack = nil
+	return s, nil
+}
+
+// Undo reverses the most recent edit and makes it available to Redo.
+func Undo(s State) (State, tea.Cmd) {
+	return reverseEdit(s, true)
+}
+
+// Redo makes the most recently undone edit again.
+func Redo(s State) (State, tea.Cmd) {
+	return reverseEdit(s, false)
+}
+
+// reverseEdit pops the top entry of the undo or redo stack, sends the
+// mutations that reverse it and pushes those onto the other stack. Changes
+// that cannot be reversed, or whose field has changed again since, are
+// skipped and named in the notification.
+func reverseEdit(s State, undo bool) (State, tea.Cmd) {
+	verb, from := "undo", s.Model.UndoStack
+	if !undo {
+		verb, from = "redo", s.Model.RedoStack
+	}
+	if len(from) == 0 {
+		notif := state.Notification{Message: fmt.Sprintf("Nothing to %s", verb), Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+		s.Model.Notifications = append(s.Model.Notifications, notif)
+		return s, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter)
+	}
+	entry := from[len(from)-1]
+	from = from[:len(from)-1]
+	if undo {
+		s.Model.UndoStack = from
+	} else {
+		s.Model.RedoStack = from
+	}
+
+	var reversed []state.Mutation
+	var skipped []string
+	now := time.Now()
+	for i := len(entry.Mutations) - 1; i >= 0; i-- {
+		m := entry.Mutations[i]
+		inv, err := inverseMutation(s, m)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%v)", m.Describe(), err))
+			continue
+		}
+		inv.ID = mutationID(now)
+		inv.QueuedAt = now
+		reversed = append(reversed, inv)
+	}
+
+	var cmds []tea.Cmd
+	if len(reversed) > 0 {
+		if undo {
+			s.Model.RedoStack = state.PushUndo(s.Model.RedoStack, state.UndoEntry{Mutations: reversed})
+		} else {
+			s.Model.UndoStack = state.PushUndo(s.Model.UndoStack, state.UndoEntry{Mutations: reversed})
+		}
+		var sendCmd tea.Cmd
+		s, sendCmd = sendReversed(s, reversed)
+		cmds = append(cmds, sendCmd)
+	}
+
+	// Name the change the way it now stands: what was undone, or what was made again.
+	past, changed := "Undid", entry.Mutations[0]
+	if !undo {
+		past = "Redid"
+		if len(reversed) > 0 {
+			changed = reversed[0]
+		}
+	}
+	var message, level string
+	switch {
+	case len(reversed) == 0:
+		message, level = fmt.Sprintf("Cannot %s %s", verb, strings.Join(skipped, "; ")), "warn"
+	case len(skipped) > 0:
+		message, level = fmt.Sprintf("%s %d of %d changes; skipped %s", past, len(reversed), len(entry.Mutations), strings.Join(skipped, "; ")), "warn"
+	case len(reversed) == 1:
+		message, level = fmt.Sprintf("%s %s", past, changed.Describe()), "info"
+	default:
+		message, level = fmt.Sprintf("%s %d changes", past, len(reversed)), "info"
+	}
+	notif := state.Notification{Message: message, Level: level, At: time.Now(), DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	return s, tea.Batch(cmds...)
+}
+
+// inverseMutation returns the mutation that takes m back on the item as it
+// is now. It fails when the item is gone or its field no longer holds the
+// value m set.
+func inverseMutation(s State, m state.Mutation) (state.Mutation, error) {
+	for _, item := range s.Model.Items {
+		if item.ID != m.ItemID() {
+			continue
+		}
+		if m.Value(item) != m.Value(m.Apply(m.Before)) {
+			return state.Mutation{}, fmt.Errorf("changed again since")
+		}
+		return m.Inverse(item, s.Model.Project.Fields)
+	}
+	return state.Mutation{}, fmt.Errorf("no longer in the project")
+}
+
+// sendReversed sends the mutations of an undo or redo, one as an ordinary
+// edit and several as a bulk edit.
+func sendReversed(s State, mutations []state.Mutation) (State, tea.Cmd) {
+	if len(mutations) > 1 {
+		return sendBulk(s, mutations, nil)
+	}
+	m := mutations[0]
+	return sendMutation(s, m, func(updatedItem state.Item) tea.Msg {
+		return core.ItemUpdatedMsg{ItemID: m.ItemID(), Item: updatedItem}
+	})
+}
@@ -0,0 +1,115 @@
+package update
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+	"project-hub/internal/ui/components"
+)
+
+func undoTestState(t *testing.T, client *offlineClient) State {
+	t.Helper()
+	s := offlineTestState(t, client)
+	s.Model.Project.Fields = []state.Field{
+		{ID: "F_status", Name: "Status", Type: state.FieldTypeSingleSelect, Options: []state.Option{{ID: "opt-todo", Name: "Todo"}, {ID: "opt-done", Name: "Done"}}},
+	}
+	return s
+}
+
+func TestUndoAndRedoStatusChange(t *testing.T) {
+	client := &offlineClient{status: "Done"}
+	s := undoTestState(t, client)
+
+	s, cmd := StatusSelectMode(s, components.StatusSelectedMsg{StatusFieldID: "F_status", OptionID: "opt-done", OptionName: "Done"})
+	s, _ = Update(s, cmd())
+	if s.Model.Items[0].Status != "Done" || len(s.Model.UndoStack) != 1 {
+		t.Fatalf("expected the change recorded for undo, got %q with %d entries", s.Model.Items[0].Status, len(s.Model.UndoStack))
+	}
+
+	client.status = "Todo"
+	s, cmd = HandleKey(s, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if s.Model.Items[0].Status != "Todo" {
+		t.Fatalf("expected undo to restore Todo, got %q", s.Model.Items[0].Status)
+	}
+	if len(s.Model.UndoStack) != 0 || len(s.Model.RedoStack) != 1 {
+		t.Fatalf("expected the entry moved to redo, got %d undo and %d redo", len(s.Model.UndoStack), len(s.Model.RedoStack))
+	}
+	if got := s.Model.Notifications[len(s.Model.Notifications)-1].Message; got != "Undid #3 Login: status → Done" {
+		t.Fatalf("unexpected undo notice %q", got)
+	}
+	if cmd == nil {
+		t.Fatalf("expected undo to send the status change")
+	}
+
+	client.status = "Done"
+	s, _ = HandleKey(s, tea.KeyMsg{Type: tea.KeyCtrlR})
+	if s.Model.Items[0].Status != "Done" || len(s.Model.UndoStack) != 1 || len(s.Model.RedoStack) != 0 {
+		t.Fatalf("expected redo to move the item back to Done, got %q", s.Model.Items[0].Status)
+	}
+	if got := s.Model.Notifications[len(s.Model.Notifications)-1].Message; got != "Redid #3 Login: status → Done" {
+		t.Fatalf("unexpected redo notice %q", got)
+	}
+}
+
+func TestNewEditClearsRedo(t *testing.T) {
+	s := undoTestState(t, &offlineClient{})
+	s.Model.RedoStack = []state.UndoEntry{{Mutations: []state.Mutation{{ID: "old", Kind: state.MutationLabels}}}}
+
+	s, _ = SaveLabelsInput(s, SaveLabelsInputMsg{Labels: "bug"})
+	if len(s.Model.RedoStack) != 0 || len(s.Model.UndoStack) != 1 {
+		t.Fatalf("expected a new edit to clear redo, got %d undo and %d redo", len(s.Model.UndoStack), len(s.Model.RedoStack))
+	}
+}
+
+func TestUndoSkipsComments(t *testing.T) {
+	s := undoTestState(t, &offlineClient{})
+	comment := newMutation(s, state.MutationComment, s.Model.Items[0])
+	comment.Text = "LGTM"
+	s.Model.UndoStack = []state.UndoEntry{{Mutations: []state.Mutation{comment}}}
+
+	s, _ = Undo(s)
+	notif := s.Model.Notifications[len(s.Model.Notifications)-1]
+	if notif.Level != "warn" || !strings.Contains(notif.Message, "comments cannot be removed") {
+		t.Fatalf("expected a notice that comments cannot be undone, got %+v", notif)
+	}
+	if len(s.Model.UndoStack) != 0 || len(s.Model.RedoStack) != 0 {
+		t.Fatalf("expected the comment dropped from history")
+	}
+}
+
+func TestCommentsKeepUndoHistory(t *testing.T) {
+	s := undoTestState(t, &offlineClient{})
+	s.Model.SuppressHints = false
+	label := newMutation(s, state.MutationLabels, s.Model.Items[0])
+	s.Model.UndoStack = []state.UndoEntry{{Mutations: []state.Mutation{label}}}
+	s.Model.RedoStack = []state.UndoEntry{{Mutations: []state.Mutation{{ID: "old", Kind: state.MutationLabels}}}}
+
+	comment := newMutation(s, state.MutationComment, s.Model.Items[0])
+	comment.Text = "LGTM"
+	s, _ = dispatchMutation(s, comment, func(state.Item) tea.Msg { return nil })
+	if len(s.Model.UndoStack) != 1 || s.Model.UndoStack[0].Mutations[0].ID != label.ID || len(s.Model.RedoStack) != 1 {
+		t.Fatalf("expected the comment to leave undo and redo alone, got %d undo and %d redo", len(s.Model.UndoStack), len(s.Model.RedoStack))
+	}
+	if got := s.Model.Notifications[len(s.Model.Notifications)-1].Message; got != "Comments can't be undone" {
+		t.Fatalf("unexpected notice %q", got)
+	}
+}
+
+func TestUndoSkipsChangedItems(t *testing.T) {
+	s := undoTestState(t, &offlineClient{})
+	m := newMutation(s, state.MutationStatus, s.Model.Items[0])
+	m.FieldID, m.OptionID, m.OptionName = "F_status", "opt-done", "Done"
+	s.Model.UndoStack = []state.UndoEntry{{Mutations: []state.Mutation{m}}}
+	s.Model.Items[0].Status = "In Review"
+
+	s, _ = Undo(s)
+	if s.Model.Items[0].Status != "In Review" || len(s.Model.RedoStack) != 0 {
+		t.Fatalf("expected a status changed elsewhere left alone, got %q", s.Model.Items[0].Status)
+	}
+	if got := s.Model.Notifications[len(s.Model.Notifications)-1].Message; !strings.Contains(got, "changed again since") {
+		t.Fatalf("unexpected notice %q", got)
+	}
+}
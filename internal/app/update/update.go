@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"project-hub/internal/app/core"
+	"project-hub/internal/github"
 	"project-hub/internal/state"
 	boardPkg "project-hub/internal/ui/board"
 	"project-hub/internal/ui/components"
@@ -17,18 +19,39 @@ func Update(s State, msg tea.Msg) (State, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
-	if s.Model.View.Mode == state.ModeStatusSelect {
+	if s.Model.View.Mode == state.ModeStatusSelect && !isBackgroundMsg(msg) {
 		updated, statusCmd := StatusSelectMode(s, msg)
 		cmds = append(cmds, statusCmd)
 		return updated, tea.Batch(cmds...)
 	}
 
-	if s.Model.View.Mode == state.ModeLabelSelect || s.Model.View.Mode == state.ModeMilestoneSelect || s.Model.View.Mode == state.ModePrioritySelect {
+	if (s.Model.View.Mode == state.ModeLabelSelect || s.Model.View.Mode == state.ModeMilestoneSelect || s.Model.View.Mode == state.ModePrioritySelect) && !isBackgroundMsg(msg) {
 		updated, fieldCmd := FieldSelectMode(s, msg)
 		cmds = append(cmds, fieldCmd)
 		return updated, tea.Batch(cmds...)
 	}
 
+	if s.Model.View.Mode == state.ModeFieldEdit && !isBackgroundMsg(msg) {
+		updated, editCmd := FieldEditMode(s, msg)
+		cmds = append(cmds, editCmd)
+		return updated, tea.Batch(cmds...)
+	}
+
+	if s.Model.View.Mode == state.ModeIterationSelect && !isBackgroundMsg(msg) {
+		updated, iterationCmd := IterationSelectMode(s, msg)
+		cmds = append(cmds, iterationCmd)
+		return updated, tea.Batch(cmds...)
+	}
+
+	if s.Model.View.Mode == state.ModeChecklist {
+		switch msg.(type) {
+		case tea.KeyMsg, components.ChecklistConfirmedMsg:
+			updated, checklistCmd := ChecklistMode(s, msg)
+			cmds = append(cmds, checklistCmd)
+			return updated, tea.Batch(cmds...)
+		}
+	}
+
 	if s.Model.View.Mode == state.ModeDetail {
 		switch msg.(type) {
 		case tea.KeyMsg, components.DetailCloseMsg:
@@ -79,58 +102,73 @@ func Update(s State, msg tea.Msg) (State, tea.Cmd) {
 			}
 			s.LastKey = ""
 		}
+	case RefreshMsg:
+		updated, fetchCmd := StartFetch(s)
+		s = updated
+		cmds = append(cmds, fetchCmd)
 	case core.FetchProjectMsg:
-		s.Model.Project = m.Project
-		s.Model.Items = m.Items
-		if s.Model.ExcludeDone {
-			var filtered []state.Item
-			for _, item := range s.Model.Items {
-				if item.Status != "Done" {
-					filtered = append(filtered, item)
-				}
-			}
-			s.Model.Items = filtered
+		if m.Generation != s.Fetch.Generation {
+			// Superseded by a newer refresh.
+			break
 		}
-		if len(s.Model.Items) > 0 {
-			s.Model.View.FocusedItemID = s.Model.Items[0].ID
+		updated, fetchCmd := ProjectFetched(s, m)
+		s = updated
+		cmds = append(cmds, fetchCmd)
+	case core.ItemsPageMsg:
+		if m.Generation != s.Fetch.Generation {
+			break
 		}
-		s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
-	case core.ItemUpdatedMsg:
-		if m.Index >= 0 && m.Index < len(s.Model.Items) {
-			existing := s.Model.Items[m.Index]
-			if len(m.Item.Assignees) > 0 {
-				existing.Assignees = m.Item.Assignees
-			}
-			if len(m.Item.Labels) > 0 {
-				existing.Labels = m.Item.Labels
-			}
-			if m.Item.Title != "" {
-				existing.Title = m.Item.Title
-			}
-			if m.Item.Status != "" && m.Item.Status != "Unknown" {
-				existing.Status = m.Item.Status
-			}
-			if m.Item.Priority != "" {
-				existing.Priority = m.Item.Priority
-			}
-			if m.Item.Repository != "" {
-				existing.Repository = m.Item.Repository
-			}
-			if m.Item.Number != 0 {
-				existing.Number = m.Item.Number
-			}
-			s.Model.Items[m.Index] = existing
-		} else {
-			if m.Index >= 0 && m.Index <= len(s.Model.Items) {
-				s.Model.Items[m.Index] = m.Item
-			}
+		updated, pageCmd := ItemsPageFetched(s, m)
+		s = updated
+		cmds = append(cmds, pageCmd)
+	case core.FetchFailedMsg:
+		if m.Generation != s.Fetch.Generation {
+			break
 		}
-		s.BoardModel = boardPkg.NewBoardModel(s.Model.Items, s.Model.Project.Fields, s.Model.View.Filter, s.Model.View.FocusedItemID, s.Model.View.CardFieldVisibility)
-		if !s.Model.SuppressHints {
-			notif := state.Notification{Message: "Item updated successfully", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
-			s.Model.Notifications = append(s.Model.Notifications, notif)
-			cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+		updated, failCmd := FetchFailed(s, m)
+		s = updated
+		cmds = append(cmds, failCmd)
+	case core.AutoRefreshMsg:
+		updated, refreshCmd := AutoRefresh(s)
+		s = updated
+		cmds = append(cmds, refreshCmd)
+	case core.ClearHighlightsMsg:
+		s = ClearHighlights(s, m)
+	case core.DigestCommentsMsg:
+		s = DigestCommentsFetched(s, m)
+	case core.SearchCommentsMsg:
+		updated, searchCmd := SearchCommentsFetched(s, m)
+		s = updated
+		cmds = append(cmds, searchCmd)
+	case spinner.TickMsg:
+		if s.Model.Loading {
+			s.Spinner, cmd = s.Spinner.Update(m)
+			cmds = append(cmds, cmd)
 		}
+	case core.ItemUpdatedMsg:
+		updated, itemCmd := ItemUpdated(s, m)
+		s = updated
+		cmds = append(cmds, itemCmd)
+	case core.MutationQueuedMsg:
+		updated, queueCmd := MutationQueued(s, m)
+		s = updated
+		cmds = append(cmds, queueCmd)
+	case core.MutationReplayedMsg:
+		updated, replayCmd := MutationReplayed(s, m)
+		s = updated
+		cmds = append(cmds, replayCmd)
+	case core.BulkMutationMsg:
+		updated, bulkCmd := BulkMutationDone(s, m)
+		s = updated
+		cmds = append(cmds, bulkCmd)
+	case core.RepoMetadataMsg:
+		updated, metaCmd := RepoMetadataFetched(s, m)
+		s = updated
+		cmds = append(cmds, metaCmd)
+	case core.ReconnectMsg:
+		updated, reconnectCmd := Reconnect(s, m)
+		s = updated
+		cmds = append(cmds, reconnectCmd)
 	case DetailEditSavedMsg:
 		if m.Index >= 0 && m.Index < len(s.Model.Items) {
 			s.Model.Items[m.Index].Description = m.Description
@@ -161,21 +199,29 @@ func Update(s State, msg tea.Msg) (State, tea.Cmd) {
 			s.Model.Notifications = append(s.Model.Notifications, notif)
 			cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
 		}
-		cmds = append(cmds, core.FetchProjectCmd(s.Github, s.Model.Project.ID, s.Model.Project.Owner, s.ItemLimit, s.Model.View.Filter.Iterations))
+		updated, fetchCmd := StartFetch(s)
+		s = updated
+		cmds = append(cmds, fetchCmd)
 	case core.DetailReadyMsg:
 		s.DetailItem = m.Item
 		s.DetailPanel = components.NewDetailPanelModel(m.Item, s.Model.Width, s.Model.Height)
 		if !s.Model.SuppressHints {
-			detailNotif := state.Notification{Message: "Detail mode: j/k to scroll, i=edit body, a=comment, esc/q to close", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
+			detailNotif := state.Notification{Message: "Detail mode: j/k to scroll, i=edit body, a=comment, e=edit field, esc/q to close", Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
 			s.Model.Notifications = append(s.Model.Notifications, detailNotif)
 			cmds = append(cmds, tea.Batch(s.DetailPanel.Init(), core.DismissNotificationCmd(len(s.Model.Notifications)-1, detailNotif.DismissAfter)))
 		} else {
 			cmds = append(cmds, s.DetailPanel.Init())
 		}
 	case core.ErrMsg:
-		notif := state.Notification{Message: fmt.Sprintf("Error: %v", m.Err), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
-		s.Model.Notifications = append(s.Model.Notifications, notif)
-		cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+		if m.Rollback != nil {
+			updated, rollbackCmd := rollbackMutation(s, *m.Rollback, m.Err)
+			s = updated
+			cmds = append(cmds, rollbackCmd)
+			break
+		}
+		updated, errCmd := reportError(s, m.Err)
+		s = updated
+		cmds = append(cmds, errCmd)
 	case core.ActionResultMsg:
 		// Convert action result into a user notification
 		aNotif := state.Notification{Message: m.Message, Level: "info", At: time.Now(), DismissAfter: 3 * time.Second}
@@ -265,3 +311,35 @@ func Update(s State, msg tea.Msg) (State, tea.Cmd) {
 	}
 	return s, tea.Batch(cmds...)
 }
+
+// isBackgroundMsg reports messages that must reach Update regardless of which
+// modal is open, so refreshes, replays and the results of edits in flight are
+// not lost behind a selector.
+func isBackgroundMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case core.ErrMsg, core.MutationQueuedMsg, core.ItemUpdatedMsg:
+		return true
+	case RefreshMsg, core.FetchProjectMsg, core.ItemsPageMsg, core.FetchFailedMsg, core.MutationReplayedMsg, core.BulkMutationMsg, core.ReconnectMsg, core.AutoRefreshMsg, core.ClearHighlightsMsg, core.DigestCommentsMsg, core.SearchCommentsMsg, core.RepoMetadataMsg, core.DismissNotificationMsg, spinner.TickMsg:
+		return true
+	}
+	return false
+}
+
+// reportError shows err as a notification. Connectivity errors also switch to
+// offline mode; once offline, repeated reconnect failures stay quiet.
+func reportError(s State, err error) (State, tea.Cmd) {
+	var cmds []tea.Cmd
+	if github.IsConnectivityError(err) {
+		wasOffline := s.Model.Offline
+		updated, offlineCmd := goOffline(s)
+		s = updated
+		cmds = append(cmds, offlineCmd)
+		if wasOffline {
+			return s, tea.Batch(cmds...)
+		}
+	}
+	notif := state.Notification{Message: fmt.Sprintf("Error: %v", err), Level: "error", At: time.Now(), DismissAfter: 5 * time.Second}
+	s.Model.Notifications = append(s.Model.Notifications, notif)
+	cmds = append(cmds, core.DismissNotificationCmd(len(s.Model.Notifications)-1, notif.DismissAfter))
+	return s, tea.Batch(cmds...)
+}
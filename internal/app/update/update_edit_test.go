@@ -4,10 +4,12 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"project-hub/internal/app/core"
+	"project-hub/internal/github"
 	"project-hub/internal/state"
 	"project-hub/internal/ui/components"
 )
@@ -19,6 +21,8 @@ var mockCreateIssueLastBody string
 var mockUpdateIssueBodyLastBody string
 var mockAddIssueCommentLastBody string
 var mockFetchIssueDetailResult state.Item
+var mockRepoLabels []string
+var mockAssignableUsers []string
 
 func (m *mockClient) FetchProject(ctx context.Context, projectID string, owner string, filter string, limit int) (state.Project, []state.Item, error) {
 	return state.Project{}, nil, nil
@@ -28,6 +32,14 @@ func (m *mockClient) FetchItems(ctx context.Context, projectID string, owner str
 	return nil, nil
 }
 
+func (m *mockClient) FetchProjectMetadata(ctx context.Context, projectID string, owner string) (state.Project, error) {
+	return state.Project{}, nil
+}
+
+func (m *mockClient) FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (github.ItemsPage, error) {
+	return github.ItemsPage{}, nil
+}
+
 func (m *mockClient) CreateIssue(ctx context.Context, projectID string, owner string, repo string, title string, body string) (state.Item, error) {
 	mockCreateIssueLastTitle = title
 	mockCreateIssueLastBody = body
@@ -42,7 +54,15 @@ func (m *mockClient) UpdateField(ctx context.Context, projectID string, owner st
 	return state.Item{}, nil
 }
 
-func (m *mockClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, labels []string) (state.Item, error) {
+func (m *mockClient) UpdateFieldValue(ctx context.Context, projectID string, owner string, itemID string, fieldID string, fieldType state.FieldType, value string) (state.Item, error) {
+	return state.Item{}, nil
+}
+
+func (m *mockClient) UpdateIteration(ctx context.Context, projectID string, owner string, itemID string, fieldID string, iterationID string) (state.Item, error) {
+	return state.Item{}, nil
+}
+
+func (m *mockClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error) {
 	return state.Item{}, nil
 }
 
@@ -50,11 +70,11 @@ func (m *mockClient) UpdateMilestone(ctx context.Context, projectID string, owne
 	return state.Item{}, nil
 }
 
-func (m *mockClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, userLogins []string) (state.Item, error) {
+func (m *mockClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error) {
 	return state.Item{}, nil
 }
 
-func (m *mockClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description string) (state.Item, error) {
+func (m *mockClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description *string) (state.Item, error) {
 	return state.Item{}, nil
 }
 
@@ -72,6 +92,14 @@ func (m *mockClient) FetchIssueDetail(ctx context.Context, repo string, number i
 	return mockFetchIssueDetailResult, nil
 }
 
+func (m *mockClient) FetchRepoMetadata(ctx context.Context, repo string) (state.RepoMetadata, error) {
+	meta := state.RepoMetadata{Assignees: mockAssignableUsers, FetchedAt: time.Now()}
+	for _, name := range mockRepoLabels {
+		meta.Labels = append(meta.Labels, state.Label{Name: name})
+	}
+	return meta, nil
+}
+
 func TestEnterDetailEditMode_PrefillsDescription(t *testing.T) {
 	items := []state.Item{{ID: "item1", Title: "Test", Description: "Old body", Repository: "owner/repo", Number: 12, Type: "Issue"}}
 	project := state.Project{ID: "1", Owner: "owner"}
@@ -128,7 +156,7 @@ func TestSaveDetailCommentReturnsSuccessMessage(t *testing.T) {
 	items := []state.Item{{ID: "item1", Title: "Test", Repository: "owner/repo", Number: 12, Type: "Issue"}}
 	project := state.Project{ID: "1", Owner: "owner"}
 	viewContext := state.ViewContext{Mode: state.ModeDetailComment, FocusedIndex: 0, FocusedItemID: "item1"}
-	initialState := state.Model{Project: project, Items: items, View: viewContext, Width: 100, Height: 40}
+	initialState := state.Model{Project: project, Items: items, View: viewContext, Width: 100, Height: 40, SuppressHints: true}
 
 	stateModel := NewState(initialState, &mockClient{}, 100)
 	stateModel.DetailItem = items[0]
@@ -307,14 +335,17 @@ func TestSaveCreateIssueBodyReturnsCreatedMessage(t *testing.T) {
 	}
 }
 
-func TestEnterAssignMode_PrefillsWithExistingAssignee(t *testing.T) {
+func assignTestState(assignees []string) State {
 	items := []state.Item{
 		{
-			ID:        "item1",
-			Title:     "Test Item",
-			Assignees: []string{"alice"},
-			Status:    "Todo",
-			Position:  1,
+			ID:         "item1",
+			Title:      "Test Item",
+			Assignees:  assignees,
+			Status:     "Todo",
+			Type:       "Issue",
+			Repository: "owner/repo",
+			Number:     7,
+			Position:   1,
 		},
 	}
 
@@ -335,98 +366,78 @@ func TestEnterAssignMode_PrefillsWithExistingAssignee(t *testing.T) {
 		View:    viewContext,
 	}
 
-	stateModel := NewState(initialState, &mockClient{}, 100)
-
-	updated, _ := EnterAssignMode(stateModel, EnterAssignModeMsg{})
-
-	if updated.TextInput.Value() != "alice" {
-		t.Errorf("expected text input to be 'alice', got %q", updated.TextInput.Value())
-	}
-
-	if updated.Model.View.Mode != state.ViewMode("assign") {
-		t.Errorf("expected mode to be 'assign', got %q", updated.Model.View.Mode)
-	}
+	return NewState(initialState, &mockClient{}, 100)
 }
 
-func TestEnterAssignMode_PrefillsEmptyWhenNoAssignee(t *testing.T) {
-	items := []state.Item{
-		{
-			ID:        "item1",
-			Title:     "Test Item",
-			Assignees: []string{},
-			Status:    "Todo",
-			Position:  1,
-		},
-	}
+func TestEnterAssignMode_ChecksExistingAssignee(t *testing.T) {
+	mockAssignableUsers = []string{"alice", "bob"}
+	defer func() { mockAssignableUsers = nil }()
 
-	project := state.Project{
-		ID:    "proj1",
-		Owner: "test-owner",
-	}
+	updated, cmd := EnterAssignMode(assignTestState([]string{"alice"}), EnterAssignModeMsg{})
 
-	viewContext := state.ViewContext{
-		Mode:          state.ModeNormal,
-		FocusedIndex:  0,
-		FocusedItemID: "item1",
+	if updated.Model.View.Mode != state.ModeChecklist {
+		t.Fatalf("expected mode to be %q, got %q", state.ModeChecklist, updated.Model.View.Mode)
+	}
+	if view := updated.Checklist.View(); !strings.Contains(view, "[x] alice") || !strings.Contains(view, "Loading") {
+		t.Errorf("expected alice checked while users load, got %q", view)
 	}
 
-	initialState := state.Model{
-		Project: project,
-		Items:   items,
-		View:    viewContext,
+	updated, _ = Update(updated, cmd())
+	if view := updated.Checklist.View(); !strings.Contains(view, "[ ] bob") || strings.Contains(view, "Loading") {
+		t.Errorf("expected the repository's assignable users listed, got %q", view)
 	}
+}
 
-	stateModel := NewState(initialState, &mockClient{}, 100)
+func TestEnterAssignMode_UsesCachedAssignees(t *testing.T) {
+	s := assignTestState(nil)
+	s.Model.Repos = map[string]state.RepoMetadata{"owner/repo": {Assignees: []string{"carol"}, FetchedAt: time.Now()}}
 
-	updated, _ := EnterAssignMode(stateModel, EnterAssignModeMsg{})
+	updated, cmd := EnterAssignMode(s, EnterAssignModeMsg{})
 
-	if updated.TextInput.Value() != "" {
-		t.Errorf("expected text input to be empty, got %q", updated.TextInput.Value())
+	if cmd != nil {
+		t.Errorf("expected no fetch while the cache is fresh")
 	}
-
-	if updated.Model.View.Mode != state.ViewMode("assign") {
-		t.Errorf("expected mode to be 'assign', got %q", updated.Model.View.Mode)
+	if view := updated.Checklist.View(); !strings.Contains(view, "[ ] carol") || strings.Contains(view, "Loading") {
+		t.Errorf("expected cached assignees listed, got %q", view)
 	}
 }
 
-func TestEnterAssignMode_UsesFirstAssigneeWhenMultiple(t *testing.T) {
-	items := []state.Item{
-		{
-			ID:        "item1",
-			Title:     "Test Item",
-			Assignees: []string{"alice", "bob", "charlie"},
-			Status:    "Todo",
-			Position:  1,
-		},
-	}
+func TestEnterAssignMode_ChecksNothingWhenNoAssignee(t *testing.T) {
+	updated, _ := EnterAssignMode(assignTestState([]string{}), EnterAssignModeMsg{})
 
-	project := state.Project{
-		ID:    "proj1",
-		Owner: "test-owner",
+	if strings.Contains(updated.Checklist.View(), "[x]") {
+		t.Errorf("expected nothing checked, got %q", updated.Checklist.View())
 	}
 
-	viewContext := state.ViewContext{
-		Mode:          state.ModeNormal,
-		FocusedIndex:  0,
-		FocusedItemID: "item1",
+	if updated.Model.View.Mode != state.ModeChecklist {
+		t.Errorf("expected mode to be %q, got %q", state.ModeChecklist, updated.Model.View.Mode)
 	}
+}
 
-	initialState := state.Model{
-		Project: project,
-		Items:   items,
-		View:    viewContext,
+func TestEnterAssignMode_ChecksEveryAssignee(t *testing.T) {
+	updated, _ := EnterAssignMode(assignTestState([]string{"alice", "bob", "charlie"}), EnterAssignModeMsg{})
+
+	view := updated.Checklist.View()
+	for _, login := range []string{"alice", "bob", "charlie"} {
+		if !strings.Contains(view, "[x] "+login) {
+			t.Errorf("expected %s checked, got %q", login, view)
+		}
 	}
+}
 
-	stateModel := NewState(initialState, &mockClient{}, 100)
+func TestAssignChecklistRemovesUnchecked(t *testing.T) {
+	s := assignTestState([]string{"alice", "bob"})
+	s, _ = EnterAssignMode(s, EnterAssignModeMsg{})
 
-	updated, _ := EnterAssignMode(stateModel, EnterAssignModeMsg{})
+	s, _ = Update(s, tea.KeyMsg{Type: tea.KeySpace})
+	s, cmd := Update(s, tea.KeyMsg{Type: tea.KeyEnter})
+	s, _ = Update(s, cmd())
 
-	if updated.TextInput.Value() != "alice" {
-		t.Errorf("expected text input to be 'alice' (first assignee), got %q", updated.TextInput.Value())
+	if s.Model.View.Mode != state.ModeNormal {
+		t.Fatalf("expected the checklist closed, got %q", s.Model.View.Mode)
 	}
-
-	if updated.Model.View.Mode != state.ViewMode("assign") {
-		t.Errorf("expected mode to be 'assign', got %q", updated.Model.View.Mode)
+	if got := strings.Join(s.Model.Items[0].Assignees, ","); got != "bob" {
+		t.Fatalf("expected alice unassigned, got %q", got)
 	}
 }
 
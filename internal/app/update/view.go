@@ -53,6 +53,7 @@ func EnterDetailMode(s State) (State, tea.Cmd) {
 				SubIssueProgress: detailItem.SubIssueProgress,
 				SubIssueTitles:   append([]string(nil), detailItem.SubIssueTitles...),
 				ParentIssue:      detailItem.ParentIssue,
+				FieldValues:      detailItem.FieldValues,
 				Comments:         append([]state.Comment(nil), detailItem.Comments...),
 			}}
 		}
@@ -64,7 +65,7 @@ func EnterDetailMode(s State) (State, tea.Cmd) {
 	s.Model.View.Mode = state.ModeDetail
 	if !s.Model.SuppressHints {
 		detailNotif := state.Notification{
-			Message:      "Detail mode: j/k to scroll, i=edit body, a=comment, esc/q to close",
+			Message:      "Detail mode: j/k to scroll, i=edit body, a=comment, e=edit field, esc/q to close",
 			Level:        "info",
 			At:           time.Now(),
 			DismissAfter: 3 * time.Second,
@@ -83,7 +84,7 @@ func EnterFieldToggleMode(s State) (State, tea.Cmd) {
 			label = "Table"
 		}
 		notif := state.Notification{
-			Message:      fmt.Sprintf("%s field toggle mode: m=milestone r=repository l=labels s=sub-issue p=parent (toggle on/off, esc to cancel)", label),
+			Message:      fmt.Sprintf("%s field toggle mode: m=milestone r=repository l=labels s=sub-issue p=parent i=iteration (toggle on/off, esc to cancel)", label),
 			Level:        "info",
 			At:           time.Now(),
 			DismissAfter: 5 * time.Second,
@@ -107,6 +108,8 @@ func FieldToggle(s State, key string) (State, tea.Cmd) {
 		vis.ShowSubIssueProgress = !vis.ShowSubIssueProgress
 	case "p", "P":
 		vis.ShowParentIssue = !vis.ShowParentIssue
+	case "i", "I":
+		vis.ShowIteration = !vis.ShowIteration
 	case "esc":
 		s.Model.View.Mode = state.ModeNormal
 		return s, nil
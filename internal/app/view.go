@@ -7,20 +7,33 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"project-hub/internal/app/core"
+	"project-hub/internal/app/update"
 	"project-hub/internal/state"
 	boardPkg "project-hub/internal/ui/board"
 	"project-hub/internal/ui/components"
 	"project-hub/internal/ui/table"
 )
 
+// suggestionStyle dims the completions listed under a text input.
+var suggestionStyle = lipgloss.NewStyle().Foreground(components.ColorGray500)
+
 func (a App) View() string {
 	width := a.state.Width
 	if width == 0 {
 		width = 100
 	}
-	header := components.RenderHeader(a.state.Project, a.state.View, width)
+	header := components.RenderHeader(a.state.Project, a.state.View, width, components.HeaderStatus{
+		Loading:   a.state.Loading,
+		Spinner:   a.spinner.View(),
+		Stale:     a.state.Stale,
+		CachedAt:  a.state.CachedAt,
+		Offline:   a.state.Offline,
+		Syncing:   a.state.Syncing,
+		Pending:   len(a.state.PendingMutations),
+		Conflicts: len(a.state.Conflicts),
+	})
 	items := state.ApplyFilter(a.state.Items, a.state.Project.Fields, a.state.View.Filter, time.Now())
-	items = state.ApplyTableSort(items, a.state.View.TableSort)
+	items = state.ApplyTableSort(items, a.state.Project.Fields, a.state.View.TableSort)
 
 	frameWidth := width
 	if frameWidth <= 0 {
@@ -32,12 +45,18 @@ func (a App) View() string {
 	}
 
 	editTitle := ""
-	if a.state.View.Mode == "edit" || a.state.View.Mode == "assign" || a.state.View.Mode == "labelsInput" || a.state.View.Mode == "milestoneInput" || a.state.View.Mode == state.ModeFiltering || a.state.View.Mode == state.ModeCreateIssueRepo || a.state.View.Mode == state.ModeCreateIssueTitle || a.state.View.Mode == state.ModeCreateIssueBody {
+	if a.state.View.Mode == "edit" || a.state.View.Mode == "assign" || a.state.View.Mode == "labelsInput" || a.state.View.Mode == "milestoneInput" || a.state.View.Mode == state.ModeFiltering || a.state.View.Mode == state.ModeCreateIssueRepo || a.state.View.Mode == state.ModeCreateIssueTitle || a.state.View.Mode == state.ModeCreateIssueBody || a.state.View.Mode == state.ModeSaveView || a.state.View.Mode == state.ModeSortInput {
 		editTitle = a.textInput.Value()
 	}
 	// Build visible columns list from CardFieldVisibility so footer can show relevant sort keys
 	visibleCols := tableVisibleColumns(a.state.View.CardFieldVisibility)
 	footerMode := string(a.state.View.Mode)
+	if a.state.View.Mode == state.ModeFiltering {
+		if err := state.ParseFilter(editTitle).Err; err != nil {
+			footerMode = string(a.state.View.Mode) + ":error"
+			editTitle += "  ✗ " + err.Error()
+		}
+	}
 	if (a.state.View.Mode == state.ModeDetailEdit || a.state.View.Mode == state.ModeDetailComment) && a.textAreaVimMode == "insert" {
 		footerMode = string(a.state.View.Mode) + ":insert"
 	}
@@ -51,7 +70,7 @@ func (a App) View() string {
 	case state.ViewTable:
 		groupBy := strings.ToLower(strings.TrimSpace(a.state.View.TableGroupBy))
 		if groupBy != "" {
-			groupedView := renderGroupedTable(groupBy, items, a.state.Project.Fields, a.state.View.FocusedItemID, a.state.View.FocusedColumnIndex, innerWidth, a.state.View.CardFieldVisibility)
+			groupedView := renderGroupedTable(groupBy, items, a.state.Project.Fields, a.state.View.FocusedItemID, a.state.View.FocusedColumnIndex, innerWidth, a.state.View.CardFieldVisibility, a.state.Highlights, a.state.View.Selection)
 			headerHeight := lipgloss.Height(groupedView.Header)
 			rowsHeight := bodyHeight - headerHeight - frameVertical
 			if rowsHeight < 3 {
@@ -69,7 +88,7 @@ func (a App) View() string {
 				body = lipgloss.JoinVertical(lipgloss.Left, append([]string{groupedView.Header}, groupedView.Rows...)...)
 			}
 		} else {
-			tableView := table.Render(items, a.state.View.FocusedItemID, a.state.View.FocusedColumnIndex, innerWidth, a.state.View.CardFieldVisibility)
+			tableView := table.Render(items, a.state.View.FocusedItemID, a.state.View.FocusedColumnIndex, innerWidth, a.state.View.CardFieldVisibility, a.state.Highlights, a.state.View.Selection)
 			headerHeight := lipgloss.Height(tableView.Header)
 			rowsHeight := bodyHeight - headerHeight - frameVertical
 			if rowsHeight < 3 {
@@ -90,9 +109,25 @@ func (a App) View() string {
 	case state.ViewSettings:
 		a.settingsModel.SetSize(innerWidth, bodyHeight)
 		body = a.settingsModel.View()
+	case state.ViewDigest:
+		body = components.RenderDigest(a.state.Digest, a.state.View.DigestIndex, a.state.BaselineAt, innerWidth, bodyHeight-frameVertical)
+	case state.ViewPlanning:
+		plan := state.BuildSprintPlan(a.state.Project, a.state.Items, time.Now())
+		body = components.RenderPlanning(plan, a.state.View.PlanningPane, a.state.View.PlanningIndex, a.state.PlanningCapacity, innerWidth, bodyHeight-frameVertical)
+	case state.ViewRoadmap:
+		rows := state.BuildRoadmap(items, a.state.Project.Fields)
+		body = components.RenderRoadmap(rows, a.state.View.RoadmapZoom, a.state.View.RoadmapOffset, a.state.View.RoadmapIndex, time.Now(), innerWidth, bodyHeight-frameVertical)
+	case state.ViewInsights:
+		now := time.Now()
+		burndown := state.BuildBurndown(a.state.Project, a.state.Items, a.state.History, now)
+		flow := state.BuildCumulativeFlow(a.state.Project, a.state.History)
+		body = components.RenderInsights(burndown, flow, innerWidth, bodyHeight-frameVertical)
 	default:
 		a.boardModel.Width = innerWidth
 		a.boardModel.Height = bodyHeight
+		a.boardModel.Highlights = a.state.Highlights
+		a.boardModel.Selected = a.state.View.Selection
+		a.boardModel.Repos = a.state.Repos
 		a.boardModel.EnsureLayout()
 		body = a.boardModel.View()
 	}
@@ -145,6 +180,70 @@ func (a App) View() string {
 		framed = a.detailPanel.View()
 	}
 
+	if a.state.View.Mode == state.ModeFieldEdit {
+		framed = lipgloss.Place(
+			frameWidth,
+			bodyHeight,
+			lipgloss.Center,
+			lipgloss.Center,
+			a.fieldEditor.View(),
+		)
+	}
+
+	if a.state.View.Mode == state.ModeIterationSelect {
+		framed = lipgloss.Place(
+			frameWidth,
+			bodyHeight,
+			lipgloss.Center,
+			lipgloss.Center,
+			a.iterationPicker.View(),
+		)
+	}
+
+	if a.state.View.Mode == state.ModeChecklist {
+		framed = lipgloss.Place(
+			frameWidth,
+			bodyHeight,
+			lipgloss.Center,
+			lipgloss.Center,
+			a.checklist.View(),
+		)
+	}
+
+	if a.state.View.Mode == state.ModeConflicts {
+		panelView := components.RenderConflictPanel(a.state.Conflicts, a.state.View.ConflictIndex, frameWidth)
+		framed = lipgloss.Place(
+			frameWidth,
+			bodyHeight,
+			lipgloss.Center,
+			lipgloss.Center,
+			panelView,
+		)
+	}
+
+	if a.state.View.Mode == state.ModeSearch {
+		results := update.SearchResults(a.state, a.textInput.Value())
+		panelView := components.RenderSearchPanel(a.textInput.View(), results, a.state.View.SearchIndex, update.SearchCommentsPending(a.state), frameWidth, bodyHeight)
+		framed = lipgloss.Place(
+			frameWidth,
+			bodyHeight,
+			lipgloss.Center,
+			lipgloss.Center,
+			panelView,
+		)
+	}
+
+	if a.state.View.Mode == state.ModeViewPicker {
+		panelView := components.RenderViewPicker(a.state.SavedViews, update.GitHubViews(a.state), a.state.View.ViewPickerIndex, frameWidth)
+		framed = lipgloss.Place(
+			frameWidth,
+			bodyHeight,
+			lipgloss.Center,
+			lipgloss.Center,
+			panelView,
+		)
+	}
+
 	if a.state.View.Mode == state.ModeDetailEdit || a.state.View.Mode == state.ModeDetailComment {
 		inputView := components.FrameStyle.Width(frameWidth).Render(a.textArea.View())
 		framed = lipgloss.Place(
@@ -156,8 +255,12 @@ func (a App) View() string {
 		)
 	}
 
-	if a.state.View.Mode == state.ModeCreateIssueRepo || a.state.View.Mode == state.ModeCreateIssueTitle || a.state.View.Mode == state.ModeCreateIssueBody || a.state.View.Mode == state.ModeEdit || a.state.View.Mode == state.ViewMode("assign") || a.state.View.Mode == state.ViewMode("labelsInput") || a.state.View.Mode == state.ViewMode("milestoneInput") || a.state.View.Mode == state.ModeFiltering {
-		inputView := components.FrameStyle.Width(frameWidth).Render(a.textInput.View())
+	if a.state.View.Mode == state.ModeCreateIssueRepo || a.state.View.Mode == state.ModeCreateIssueTitle || a.state.View.Mode == state.ModeCreateIssueBody || a.state.View.Mode == state.ModeEdit || a.state.View.Mode == state.ViewMode("assign") || a.state.View.Mode == state.ViewMode("labelsInput") || a.state.View.Mode == state.ViewMode("milestoneInput") || a.state.View.Mode == state.ModeFiltering || a.state.View.Mode == state.ModeSaveView || a.state.View.Mode == state.ModeSortInput {
+		input := a.textInput.View()
+		if _, matches := update.Suggestions(a.state, a.textInput.Value()); len(matches) > 0 {
+			input += "\n" + suggestionStyle.Render("tab: "+strings.Join(matches, "  "))
+		}
+		inputView := components.FrameStyle.Width(frameWidth).Render(input)
 		framed = lipgloss.Place(
 			frameWidth,
 			bodyHeight,
@@ -189,6 +292,9 @@ func tableVisibleColumns(vis state.CardFieldVisibility) []int {
 	if vis.ShowParentIssue {
 		cols = append(cols, state.ColumnParentIssue)
 	}
+	if vis.ShowIteration {
+		cols = append(cols, state.ColumnIteration)
+	}
 	cols = append(cols, state.ColumnAssignees)
 	return cols
 }
@@ -202,7 +308,7 @@ type groupedTableView struct {
 	Groups       []boardPkg.GroupBucket
 }
 
-func renderGroupedTable(groupBy string, items []state.Item, fields []state.Field, focusedID string, focusedColIndex int, innerWidth int, fieldVisibility state.CardFieldVisibility) groupedTableView {
+func renderGroupedTable(groupBy string, items []state.Item, fields []state.Field, focusedID string, focusedColIndex int, innerWidth int, fieldVisibility state.CardFieldVisibility, highlights map[string]state.ChangeKind, selected map[string]bool) groupedTableView {
 	if innerWidth <= 0 {
 		innerWidth = 80
 	}
@@ -231,7 +337,7 @@ func renderGroupedTable(groupBy string, items []state.Item, fields []state.Field
 
 	for i, group := range groups {
 		if i == 0 {
-			groupRender := table.Render(group.Items, focusedID, focusedColIndex, innerWidth, fieldVisibility)
+			groupRender := table.Render(group.Items, focusedID, focusedColIndex, innerWidth, fieldVisibility, highlights, selected)
 			header = groupRender.Header
 		}
 
@@ -247,7 +353,7 @@ func renderGroupedTable(groupBy string, items []state.Item, fields []state.Field
 		rowOffsets = append(rowOffsets, cumulativeHeight)
 		cumulativeHeight += lipgloss.Height(groupHeaderView)
 
-		groupRender := table.Render(group.Items, focusedID, focusedColIndex, innerWidth, fieldVisibility)
+		groupRender := table.Render(group.Items, focusedID, focusedColIndex, innerWidth, fieldVisibility, highlights, selected)
 		rows = append(rows, groupRender.Rows...)
 		for _, h := range groupRender.RowHeights {
 			rowHeights = append(rowHeights, h)
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"project-hub/internal/config"
+	"project-hub/internal/state"
+)
+
+// DirName is the directory under the config directory that holds project snapshots.
+const DirName = "cache"
+
+// Snapshot is the last project state fetched from GitHub.
+type Snapshot struct {
+	Project state.Project                 `json:"project"`
+	Items   []state.Item                  `json:"items"`
+	Repos   map[string]state.RepoMetadata `json:"repos,omitempty"`
+	SavedAt time.Time                     `json:"savedAt"`
+}
+
+// Empty reports whether the snapshot holds no cached data.
+func (s Snapshot) Empty() bool {
+	return s.SavedAt.IsZero() && len(s.Items) == 0
+}
+
+// ResolvePath returns the snapshot file for a project/owner pair.
+// Snapshots live next to the config file in ~/.config/project-hub/cache/.
+func ResolvePath(projectID, owner string) (string, error) {
+	configPath, err := config.ResolvePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), DirName, Key(projectID, owner)+".json"), nil
+}
+
+// Key returns a file-name safe identifier for a project/owner pair.
+func Key(projectID, owner string) string {
+	owner = strings.TrimSpace(owner)
+	if owner == "" || owner == "@me" {
+		owner = "me"
+	}
+	return sanitize(owner) + "-" + sanitize(strings.TrimSpace(projectID))
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// Load reads a snapshot from disk.
+// Returns an empty Snapshot{} (not an error) if the file doesn't exist.
+func Load(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Snapshot{}, nil
+		}
+		return Snapshot{}, fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse cache JSON: %w", err)
+	}
+	return snap, nil
+}
+
+// Save writes a snapshot to disk, replacing any previous snapshot atomically.
+func Save(path string, snap Snapshot) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache to JSON: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"project-hub/internal/state"
+)
+
+func TestResolvePathIsPerProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	a, err := ResolvePath("5", "acme")
+	if err != nil {
+		t.Fatalf("ResolvePath() error: %v", err)
+	}
+	b, err := ResolvePath("5", "other")
+	if err != nil {
+		t.Fatalf("ResolvePath() error: %v", err)
+	}
+	if a == b {
+		t.Fatalf("expected distinct paths per owner, got %q", a)
+	}
+	if filepath.Base(filepath.Dir(a)) != DirName {
+		t.Fatalf("expected snapshot under %q, got %q", DirName, a)
+	}
+
+	viewer, err := ResolvePath("5", "")
+	if err != nil {
+		t.Fatalf("ResolvePath() error: %v", err)
+	}
+	if !strings.HasSuffix(viewer, "me-5.json") {
+		t.Fatalf("expected viewer snapshot name, got %q", viewer)
+	}
+}
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "acme-5.json")
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	saved := Snapshot{
+		Project: state.Project{ID: "5", Owner: "acme", Name: "Roadmap", Fields: []state.Field{{ID: "F1", Name: "Status"}}},
+		Items: []state.Item{
+			{ID: "PVTI_1", Title: "First", Status: "Todo", Labels: []string{"bug"}, IterationStart: &start, FieldValues: map[string][]string{"Estimate": {"3"}}},
+		},
+		SavedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	if err := Save(path, saved); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if loaded.Project.Name != "Roadmap" || len(loaded.Project.Fields) != 1 {
+		t.Fatalf("unexpected project: %+v", loaded.Project)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].Labels[0] != "bug" || loaded.Items[0].FieldValues["Estimate"][0] != "3" {
+		t.Fatalf("unexpected items: %+v", loaded.Items)
+	}
+	if loaded.Items[0].IterationStart == nil || !loaded.Items[0].IterationStart.Equal(start) {
+		t.Fatalf("expected iteration start to round-trip, got %v", loaded.Items[0].IterationStart)
+	}
+	if !loaded.SavedAt.Equal(saved.SavedAt) {
+		t.Fatalf("expected SavedAt %v, got %v", saved.SavedAt, loaded.SavedAt)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("read cache dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected no leftover temp files, got %d entries", len(entries))
+	}
+}
+
+func TestLoadMissingReturnsEmpty(t *testing.T) {
+	snap, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !snap.Empty() {
+		t.Fatalf("expected empty snapshot, got %+v", snap)
+	}
+}
+
+func TestLoadMalformedReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`{"items":`), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatalf("expected error for malformed cache")
+	}
+}
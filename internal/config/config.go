@@ -18,6 +18,7 @@ type CardFieldVisibility struct {
 	ShowSubIssueProgress bool `json:"showSubIssueProgress"`
 	ShowParentIssue      bool `json:"showParentIssue"`
 	ShowLabels           bool `json:"showLabels"`
+	ShowIteration        bool `json:"showIteration"`
 }
 
 type Config struct {
@@ -29,6 +30,20 @@ type Config struct {
 	CreateIssueRepoMode     string              `json:"createIssueRepoMode"`
 	DefaultIterationFilters []string            `json:"defaultIterationFilters"`
 	CardFieldVisibility     CardFieldVisibility `json:"cardFieldVisibility"`
+	RefreshInterval         string              `json:"refreshInterval"`  // Go duration such as "5m"; empty disables auto-refresh
+	PlanningCapacity        float64             `json:"planningCapacity"` // Estimate per person per iteration in the planning view
+	SavedViews              []SavedView         `json:"savedViews,omitempty"`
+}
+
+// SavedView is a named filter and layout, selectable from the view picker or
+// with --view.
+type SavedView struct {
+	Name                string              `json:"name"`
+	Query               string              `json:"query,omitempty"`
+	View                string              `json:"view,omitempty"` // board, table, roadmap, ...
+	Sort                string              `json:"sort,omitempty"` // e.g. "Status, -Priority, -UpdatedAt"
+	GroupBy             string              `json:"groupBy,omitempty"`
+	CardFieldVisibility CardFieldVisibility `json:"cardFieldVisibility"`
 }
 
 // ResolvePath returns the canonical config file path using XDG Base Directory spec.
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"project-hub/internal/state"
 )
 
 // TestResolvePath verifies the config file path resolution across platforms.
@@ -497,3 +500,27 @@ func TestConfigStruct(t *testing.T) {
 		})
 	}
 }
+
+// TestSavedViewsRoundTrip verifies saved views survive saving and conversion.
+func TestSavedViewsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	views := []state.SavedView{{
+		Name:                "My bugs",
+		Query:               "label:bug assignee:@me",
+		View:                state.ViewTable,
+		TableSort:           state.TableSort{Field: "Status", Asc: true, Then: []state.SortKey{{Field: "Estimate"}}},
+		TableGroupBy:        "status",
+		CardFieldVisibility: state.CardFieldVisibility{ShowLabels: true},
+	}}
+	if err := Save(path, Config{SavedViews: ConfigViews(views)}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got := StateViews(cfg.SavedViews)
+	if !reflect.DeepEqual(got, views) {
+		t.Errorf("StateViews() = %+v, want %+v", got, views)
+	}
+}
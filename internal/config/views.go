@@ -0,0 +1,49 @@
+package config
+
+import "project-hub/internal/state"
+
+// StateViews converts saved views read from the config file.
+func StateViews(views []SavedView) []state.SavedView {
+	var out []state.SavedView
+	for _, v := range views {
+		out = append(out, state.SavedView{
+			Name:         v.Name,
+			Query:        v.Query,
+			View:         state.ViewType(v.View),
+			TableSort:    state.ParseTableSort(v.Sort),
+			TableGroupBy: v.GroupBy,
+			CardFieldVisibility: state.CardFieldVisibility{
+				ShowMilestone:        v.CardFieldVisibility.ShowMilestone,
+				ShowRepository:       v.CardFieldVisibility.ShowRepository,
+				ShowSubIssueProgress: v.CardFieldVisibility.ShowSubIssueProgress,
+				ShowParentIssue:      v.CardFieldVisibility.ShowParentIssue,
+				ShowLabels:           v.CardFieldVisibility.ShowLabels,
+				ShowIteration:        v.CardFieldVisibility.ShowIteration,
+			},
+		})
+	}
+	return out
+}
+
+// ConfigViews converts saved views for writing to the config file.
+func ConfigViews(views []state.SavedView) []SavedView {
+	var out []SavedView
+	for _, v := range views {
+		out = append(out, SavedView{
+			Name:    v.Name,
+			Query:   v.Query,
+			View:    string(v.View),
+			Sort:    v.TableSort.String(),
+			GroupBy: v.TableGroupBy,
+			CardFieldVisibility: CardFieldVisibility{
+				ShowMilestone:        v.CardFieldVisibility.ShowMilestone,
+				ShowRepository:       v.CardFieldVisibility.ShowRepository,
+				ShowSubIssueProgress: v.CardFieldVisibility.ShowSubIssueProgress,
+				ShowParentIssue:      v.CardFieldVisibility.ShowParentIssue,
+				ShowLabels:           v.CardFieldVisibility.ShowLabels,
+				ShowIteration:        v.CardFieldVisibility.ShowIteration,
+			},
+		})
+	}
+	return out
+}
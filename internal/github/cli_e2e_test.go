@@ -0,0 +1,158 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"project-hub/internal/github/fakegh"
+	"project-hub/internal/state"
+)
+
+func TestCLIClientAgainstFakeGh(t *testing.T) {
+	fake := fakegh.New(t, "testdata/fakegh/roadmap")
+	client := NewCLIClient(fake.Path)
+	ctx := context.Background()
+
+	proj, err := client.FetchProjectMetadata(ctx, "1", "acme")
+	if err != nil {
+		t.Fatalf("FetchProjectMetadata: %v", err)
+	}
+	if proj.Name != "Roadmap" || proj.NodeID != "PVT_kwDOAcme" || len(proj.Fields) != 4 {
+		t.Fatalf("unexpected project: %+v", proj)
+	}
+	if proj.Fields[0].Type != state.FieldTypeSingleSelect || proj.Fields[1].Type != "TITLE" || proj.Fields[2].Type != state.FieldTypeDate || proj.Fields[3].Type != state.FieldTypeIteration {
+		t.Fatalf("expected field types read over GraphQL, got %+v", proj.Fields)
+	}
+	if len(proj.Iterations) != 2 || proj.Iterations[0].ID != "it_1" || !proj.Iterations[0].Completed || proj.Iterations[1].Completed {
+		t.Fatalf("unexpected iterations: %+v", proj.Iterations)
+	}
+	if len(proj.SavedViews) != 2 || !reflect.DeepEqual(proj.SavedViews[1], state.SavedView{Name: "Bugs", Query: "label:bug -status:Done", View: state.ViewTable}) {
+		t.Fatalf("expected saved views with filters, got %+v", proj.SavedViews)
+	}
+	if end := proj.Iterations[1].End; end == nil || end.Format(state.DateLayout) != "2026-10-26" {
+		t.Fatalf("expected iteration end from start and duration, got %v", end)
+	}
+
+	page, err := client.FetchItemsPage(ctx, "1", "acme", "", "", 100)
+	if err != nil {
+		t.Fatalf("FetchItemsPage: %v", err)
+	}
+	if items := page.Items; len(items) != 1 || items[0].Status != "Todo" || items[0].Repository != "acme/app" || page.NextCursor != "c1" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	page, err = client.FetchItemsPage(ctx, "1", "acme", "", page.NextCursor, 100)
+	if err != nil {
+		t.Fatalf("FetchItemsPage: %v", err)
+	}
+	if items := page.Items; len(items) != 1 || items[0].Type != "DraftIssue" || page.NextCursor != "" {
+		t.Fatalf("unexpected second page: %+v", page)
+	}
+	pages := fake.CallsTo("api", "graphql")
+	if len(pages) != 3 {
+		t.Fatalf("expected a field query and two pages over graphql, got %d calls", len(pages))
+	}
+	var body struct {
+		Variables map[string]any `json:"variables"`
+	}
+	if err := json.Unmarshal([]byte(pages[2].Stdin), &body); err != nil {
+		t.Fatalf("decode graphql request: %v", err)
+	}
+	if body.Variables["after"] != "c1" || body.Variables["owner"] != "acme" {
+		t.Fatalf("expected second page requested after c1, got %v", body.Variables)
+	}
+
+	detail, err := client.FetchIssueDetail(ctx, "acme/app", 3)
+	if err != nil {
+		t.Fatalf("FetchIssueDetail: %v", err)
+	}
+	if detail.Description != "Users cannot log in." || len(detail.Comments) != 1 || detail.Comments[0].Author != "bob" {
+		t.Fatalf("unexpected detail: %+v", detail)
+	}
+
+	updated, err := client.UpdateStatus(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "PVTSSF_status", "opt_done")
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if updated.Status != "Done" {
+		t.Fatalf("expected status from item-edit output, got %q", updated.Status)
+	}
+	if _, err := client.UpdateFieldValue(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "PVTF_target", state.FieldTypeDate, "2026-11-02"); err != nil {
+		t.Fatalf("UpdateFieldValue: %v", err)
+	}
+	if _, err := client.UpdateFieldValue(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "PVTF_target", state.FieldTypeDate, "next week"); err == nil {
+		t.Fatalf("expected invalid date to be rejected before calling gh")
+	}
+	if _, err := client.UpdateIteration(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "PVTIF_sprint", "it_2"); err != nil {
+		t.Fatalf("UpdateIteration: %v", err)
+	}
+	if err := client.AddIssueComment(ctx, "acme/app", 3, "Fixed in #4"); err != nil {
+		t.Fatalf("AddIssueComment: %v", err)
+	}
+
+	edits := fake.CallsTo("project", "item-edit")
+	wantEdits := [][]string{
+		{"project", "item-edit", "--id", "PVTI_1", "--project-id", "PVT_kwDOAcme", "--field-id", "PVTSSF_status", "--single-select-option-id", "opt_done", "--format", "json"},
+		{"project", "item-edit", "--id", "PVTI_1", "--project-id", "PVT_kwDOAcme", "--field-id", "PVTF_target", "--date", "2026-11-02", "--format", "json"},
+		{"project", "item-edit", "--id", "PVTI_1", "--project-id", "PVT_kwDOAcme", "--field-id", "PVTIF_sprint", "--iteration-id", "it_2", "--format", "json"},
+	}
+	if len(edits) != len(wantEdits) {
+		t.Fatalf("unexpected item-edit calls: %+v", edits)
+	}
+	for i, want := range wantEdits {
+		if !reflect.DeepEqual(edits[i].Args, want) {
+			t.Fatalf("item-edit call %d: got %v, want %v", i, edits[i].Args, want)
+		}
+	}
+	if _, err := client.UpdateLabels(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "Issue", "acme/app", 3, []string{"bug", "ui"}, []string{"UI", "docs"}); err != nil {
+		t.Fatalf("UpdateLabels: %v", err)
+	}
+	if _, err := client.UpdateLabels(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "Issue", "acme/app", 3, []string{"ui"}, []string{"ui"}); err != nil {
+		t.Fatalf("UpdateLabels without changes: %v", err)
+	}
+	if _, err := client.UpdateAssignees(ctx, "PVT_kwDOAcme", "acme", "PVTI_1", "PullRequest", "acme/app", 3, []string{"alice"}, []string{"bob", "carol"}); err != nil {
+		t.Fatalf("UpdateAssignees: %v", err)
+	}
+	wantLabels := []string{"issue", "edit", "3", "--repo", "acme/app", "--add-label", "docs", "--remove-label", "bug"}
+	if labelEdits := fake.CallsTo("issue", "edit"); len(labelEdits) != 1 || !reflect.DeepEqual(labelEdits[0].Args, wantLabels) {
+		t.Fatalf("expected one label edit with the difference, got %+v", labelEdits)
+	}
+	wantAssignees := []string{"pr", "edit", "3", "--repo", "acme/app", "--add-assignee", "bob,carol", "--remove-assignee", "alice"}
+	if assigneeEdits := fake.CallsTo("pr", "edit"); len(assigneeEdits) != 1 || !reflect.DeepEqual(assigneeEdits[0].Args, wantAssignees) {
+		t.Fatalf("unexpected assignee edits: %+v", assigneeEdits)
+	}
+
+	meta, err := client.FetchRepoMetadata(ctx, "acme/app")
+	if err != nil {
+		t.Fatalf("FetchRepoMetadata: %v", err)
+	}
+	if len(meta.Labels) != 3 || meta.Labels[1] != (state.Label{Name: "docs", Color: "0075ca"}) {
+		t.Fatalf("unexpected labels: %+v", meta.Labels)
+	}
+	if !reflect.DeepEqual(meta.Milestones, []string{"v1.0", "Next release"}) {
+		t.Fatalf("unexpected milestones: %q", meta.Milestones)
+	}
+	if len(meta.Assignees) != 3 || meta.Assignees[2] != "carol" || meta.FetchedAt.IsZero() {
+		t.Fatalf("unexpected assignees: %+v", meta)
+	}
+
+	comments := fake.CallsTo("issue", "comment")
+	wantComment := []string{"issue", "comment", "3", "--repo", "acme/app", "--body-file", "-"}
+	if len(comments) != 1 || !reflect.DeepEqual(comments[0].Args, wantComment) || comments[0].Stdin != "Fixed in #4" {
+		t.Fatalf("unexpected comment calls: %+v", comments)
+	}
+}
+
+func TestCLIClientConnectivityErrorFromFakeGh(t *testing.T) {
+	fake := fakegh.New(t, "testdata/fakegh/offline")
+	client := NewCLIClient(fake.Path)
+
+	_, err := client.FetchProjectMetadata(context.Background(), "1", "acme")
+	if err == nil {
+		t.Fatalf("expected gh failure to surface")
+	}
+	if !IsConnectivityError(err) {
+		t.Fatalf("expected connectivity error, got %v", err)
+	}
+}
@@ -22,21 +22,28 @@ const (
 
 type Client interface {
 	FetchProject(ctx context.Context, projectID string, owner string, filter string, limit int) (state.Project, []state.Item, error)
+	FetchProjectMetadata(ctx context.Context, projectID string, owner string) (state.Project, error)
 	FetchItems(ctx context.Context, projectID string, owner string, filter string, limit int) ([]state.Item, error)
+	FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (ItemsPage, error)
 	CreateIssue(ctx context.Context, projectID string, owner string, repo string, title string, body string) (state.Item, error)
 	UpdateStatus(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string) (state.Item, error)
 	UpdateField(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string, fieldName string) (state.Item, error)
-	UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, labels []string) (state.Item, error)
+	UpdateFieldValue(ctx context.Context, projectID string, owner string, itemID string, fieldID string, fieldType state.FieldType, value string) (state.Item, error)
+	UpdateIteration(ctx context.Context, projectID string, owner string, itemID string, fieldID string, iterationID string) (state.Item, error)
+	UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error)
 	UpdateMilestone(ctx context.Context, projectID string, owner string, itemID string, milestone string) (state.Item, error)
-	UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, userLogins []string) (state.Item, error)
-	UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description string) (state.Item, error)
+	UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error)
+	UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description *string) (state.Item, error)
 	UpdateIssueBody(ctx context.Context, repo string, number int, body string) error
 	AddIssueComment(ctx context.Context, repo string, number int, body string) error
 	FetchIssueDetail(ctx context.Context, repo string, number int) (state.Item, error)
+	FetchRepoMetadata(ctx context.Context, repo string) (state.RepoMetadata, error)
 }
 
 type CLIClient struct {
 	GhPath string
+
+	itemQuery itemQueryFallback
 }
 
 func NewCLIClient(ghPath string) *CLIClient {
@@ -192,7 +199,94 @@ func (c *CLIClient) UpdateField(ctx context.Context, projectID string, owner str
 	return item, nil
 }
 
-func (c *CLIClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, labels []string) (state.Item, error) {
+// UpdateFieldValue sets a date, number or text field. An empty value clears it.
+func (c *CLIClient) UpdateFieldValue(ctx context.Context, projectID string, owner string, itemID string, fieldID string, fieldType state.FieldType, value string) (state.Item, error) {
+	if itemID == "" {
+		return state.Item{}, fmt.Errorf("item ID is required")
+	}
+	if fieldID == "" {
+		return state.Item{}, fmt.Errorf("field ID is required")
+	}
+	value, err := state.NormalizeFieldValue(fieldType, value)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	args := []string{
+		"project", "item-edit",
+		"--id", itemID,
+		"--project-id", projectID,
+		"--field-id", fieldID,
+	}
+	switch {
+	case value == "":
+		args = append(args, "--clear")
+	case fieldType == state.FieldTypeDate:
+		args = append(args, "--date", value)
+	case fieldType == state.FieldTypeNumber:
+		args = append(args, "--number", value)
+	default:
+		args = append(args, "--text", value)
+	}
+	args = append(args, "--format", "json")
+
+	out, err := c.runGh(ctx, args...)
+	if err != nil {
+		return state.Item{}, fmt.Errorf("gh project item-edit for field value failed: %w", err)
+	}
+
+	var rawItem map[string]any
+	if err := json.Unmarshal(out, &rawItem); err != nil {
+		return state.Item{}, fmt.Errorf("parse gh project item-edit json for field value: %w", err)
+	}
+
+	item, ok := parse.ParseItemMap(rawItem)
+	if !ok {
+		return state.Item{}, fmt.Errorf("failed to parse updated item from gh project item-edit output for field value")
+	}
+	return item, nil
+}
+
+// UpdateIteration moves an item to another iteration. An empty iterationID clears it.
+func (c *CLIClient) UpdateIteration(ctx context.Context, projectID string, owner string, itemID string, fieldID string, iterationID string) (state.Item, error) {
+	if itemID == "" {
+		return state.Item{}, fmt.Errorf("item ID is required")
+	}
+	if fieldID == "" {
+		return state.Item{}, fmt.Errorf("field ID is required")
+	}
+
+	args := []string{
+		"project", "item-edit",
+		"--id", itemID,
+		"--project-id", projectID,
+		"--field-id", fieldID,
+	}
+	if iterationID == "" {
+		args = append(args, "--clear")
+	} else {
+		args = append(args, "--iteration-id", iterationID)
+	}
+	args = append(args, "--format", "json")
+
+	out, err := c.runGh(ctx, args...)
+	if err != nil {
+		return state.Item{}, fmt.Errorf("gh project item-edit for iteration failed: %w", err)
+	}
+
+	var rawItem map[string]any
+	if err := json.Unmarshal(out, &rawItem); err != nil {
+		return state.Item{}, fmt.Errorf("parse gh project item-edit json for iteration: %w", err)
+	}
+
+	item, ok := parse.ParseItemMap(rawItem)
+	if !ok {
+		return state.Item{}, fmt.Errorf("failed to parse updated item from gh project item-edit output for iteration")
+	}
+	return item, nil
+}
+
+func (c *CLIClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error) {
 	if itemType != "Issue" && itemType != "PullRequest" {
 		return state.Item{}, fmt.Errorf("cannot edit labels for item of type: %s (only Issues and PullRequests can have labels)", itemType)
 	}
@@ -201,11 +295,17 @@ func (c *CLIClient) UpdateLabels(ctx context.Context, projectID string, owner st
 		return state.Item{}, fmt.Errorf("cannot edit labels: missing repository or issue number")
 	}
 
-	args := []string{"issue", "edit", strconv.Itoa(number), "--repo", repo}
+	add, remove := state.DiffValues(current, labels)
+	if len(add) == 0 && len(remove) == 0 {
+		return state.Item{ID: itemID, Labels: labels}, nil
+	}
 
-	if len(labels) > 0 {
-		args = append(args, "--add-label")
-		args = append(args, strings.Join(labels, ","))
+	args := []string{"issue", "edit", strconv.Itoa(number), "--repo", repo}
+	if len(add) > 0 {
+		args = append(args, "--add-label", strings.Join(add, ","))
+	}
+	if len(remove) > 0 {
+		args = append(args, "--remove-label", strings.Join(remove, ","))
 	}
 
 	_, err := c.runGh(ctx, args...)
@@ -251,7 +351,7 @@ func (c *CLIClient) UpdateMilestone(ctx context.Context, projectID string, owner
 	return item, nil
 }
 
-func (c *CLIClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, userLogins []string) (state.Item, error) {
+func (c *CLIClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error) {
 	if itemType != "Issue" && itemType != "PullRequest" {
 		return state.Item{}, fmt.Errorf("cannot assign to item of type: %s (only Issues and PullRequests can be assigned)", itemType)
 	}
@@ -260,16 +360,22 @@ func (c *CLIClient) UpdateAssignees(ctx context.Context, projectID string, owner
 		return state.Item{}, fmt.Errorf("cannot edit assignees: missing repository or issue number")
 	}
 
+	add, remove := state.DiffValues(current, userLogins)
+	if len(add) == 0 && len(remove) == 0 {
+		return state.Item{ID: itemID, Assignees: userLogins}, nil
+	}
+
 	editCmd := "issue"
 	if itemType == "PullRequest" {
 		editCmd = "pr"
 	}
 
 	editArgs := []string{editCmd, "edit", strconv.Itoa(number), "--repo", repo}
-
-	if len(userLogins) > 0 && userLogins[0] != "" {
-		editArgs = append(editArgs, "--add-assignee")
-		editArgs = append(editArgs, userLogins...)
+	if len(add) > 0 {
+		editArgs = append(editArgs, "--add-assignee", strings.Join(add, ","))
+	}
+	if len(remove) > 0 {
+		editArgs = append(editArgs, "--remove-assignee", strings.Join(remove, ","))
 	}
 
 	_, err := c.runGh(ctx, editArgs...)
@@ -280,7 +386,45 @@ func (c *CLIClient) UpdateAssignees(ctx context.Context, projectID string, owner
 	return state.Item{ID: itemID, Assignees: userLogins}, nil
 }
 
-func (c *CLIClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description string) (state.Item, error) {
+// FetchRepoMetadata lists the labels, open milestones and assignable users
+// of repo.
+func (c *CLIClient) FetchRepoMetadata(ctx context.Context, repo string) (state.RepoMetadata, error) {
+	out, err := c.runGh(ctx, "label", "list", "--repo", repo, "--json", "name,color", "--limit", "1000")
+	if err != nil {
+		return state.RepoMetadata{}, fmt.Errorf("gh label list failed: %w", err)
+	}
+	var meta state.RepoMetadata
+	if err := json.Unmarshal(out, &meta.Labels); err != nil {
+		return state.RepoMetadata{}, fmt.Errorf("parse gh label list json: %w", err)
+	}
+
+	out, err = c.runGh(ctx, "api", "repos/"+repo+"/milestones", "--paginate", "--jq", ".[].title")
+	if err != nil {
+		return state.RepoMetadata{}, fmt.Errorf("gh api milestones failed: %w", err)
+	}
+	meta.Milestones = outputLines(out)
+
+	out, err = c.runGh(ctx, "api", "repos/"+repo+"/assignees", "--paginate", "--jq", ".[].login")
+	if err != nil {
+		return state.RepoMetadata{}, fmt.Errorf("gh api assignees failed: %w", err)
+	}
+	meta.Assignees = outputLines(out)
+	meta.FetchedAt = time.Now()
+	return meta, nil
+}
+
+// outputLines splits command output into its non-blank lines.
+func outputLines(out []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (c *CLIClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description *string) (state.Item, error) {
 	if item.Type == "Issue" {
 		if item.Number == 0 || item.Repository == "" {
 			return state.Item{}, fmt.Errorf("cannot edit issue without number or repository")
@@ -294,8 +438,8 @@ func (c *CLIClient) UpdateItem(ctx context.Context, projectID string, owner stri
 		if title != "" {
 			args = append(args, "--title", title)
 		}
-		if description != "" {
-			args = append(args, "--body", description)
+		if description != nil {
+			args = append(args, "--body", *description)
 		}
 
 		_, err := c.runGh(ctx, args...)
@@ -304,7 +448,9 @@ func (c *CLIClient) UpdateItem(ctx context.Context, projectID string, owner stri
 		}
 
 		item.Title = title
-		item.Description = description
+		if description != nil {
+			item.Description = *description
+		}
 		return item, nil
 	}
 
@@ -317,8 +463,8 @@ func (c *CLIClient) UpdateItem(ctx context.Context, projectID string, owner stri
 	if title != "" {
 		args = append(args, "--title", title)
 	}
-	if description != "" {
-		args = append(args, "--body", description)
+	if description != nil {
+		args = append(args, "--body", *description)
 	}
 
 	out, err := c.runGh(ctx, args...)
@@ -437,11 +583,16 @@ func (c *CLIClient) runGh(ctx context.Context, args ...string) ([]byte, error) {
 }
 
 func (c *CLIClient) runGhWithStdin(ctx context.Context, stdin string, args ...string) error {
+	_, err := c.runGhWithInput(ctx, stdin, args...)
+	return err
+}
+
+func (c *CLIClient) runGhWithInput(ctx context.Context, stdin string, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, c.GhPath, args...)
 	cmd.Stdin = strings.NewReader(stdin)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
 	}
-	return nil
+	return out, nil
 }
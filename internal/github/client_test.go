@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os/exec"
 	"reflect"
 	"strings"
 	"testing"
@@ -173,12 +174,38 @@ func TestParseItemMap(t *testing.T) {
 				Number:      123,
 				URL:         "https://github.com/owner/repo/issues/123",
 				Status:      "Todo",
+				State:       "OPEN",
 				Repository:  "repo",
 				Priority:    "High",
 				UpdatedAt:   parseTime("2023-10-27T16:00:00Z"),
 			},
 			wantOK: true,
 		},
+		{
+			name: "Closed issue commented on after the item changed",
+			inputJSON: `{
+				"id": "PVTI_closed",
+				"updatedAt": "2023-10-27T16:00:00Z",
+				"content": {
+					"type": "Issue",
+					"title": "Shipped feature",
+					"state": "CLOSED",
+					"updatedAt": "2023-10-28T09:00:00Z"
+				},
+				"fieldValues": [
+					{"field": {"name": "Status"}, "singleSelectOption": {"id": "opt-shipped", "name": "Shipped"}}
+				]
+			}`,
+			wantItem: state.Item{
+				ID:        "PVTI_closed",
+				Type:      "Issue",
+				Title:     "Shipped feature",
+				Status:    "Shipped",
+				State:     "CLOSED",
+				UpdatedAt: parseTime("2023-10-28T09:00:00Z"),
+			},
+			wantOK: true,
+		},
 		{
 			name: "Assignees and labels from nested nodes",
 			inputJSON: `{
@@ -292,6 +319,9 @@ func TestParseItemMap(t *testing.T) {
 			if got.Status != tt.wantItem.Status {
 				t.Errorf("parseItemMap() Status = %v, want %v", got.Status, tt.wantItem.Status)
 			}
+			if got.State != tt.wantItem.State {
+				t.Errorf("parseItemMap() State = %v, want %v", got.State, tt.wantItem.State)
+			}
 			if got.Repository != tt.wantItem.Repository {
 				t.Errorf("parseItemMap() Repository = %v, want %v", got.Repository, tt.wantItem.Repository)
 			}
@@ -620,49 +650,46 @@ func TestParseProjectItemAddOutput(t *testing.T) {
 	}
 }
 
-func TestIsUnknownFlagError(t *testing.T) {
+func TestIsUnknownArgumentError(t *testing.T) {
 	tests := []struct {
-		name   string
-		err    error
-		flag   string
-		isTrue bool
+		name     string
+		err      error
+		argument string
+		want     bool
 	}{
-		{
-			name:   "nil error",
-			err:    nil,
-			flag:   "--query",
-			isTrue: false,
-		},
-		{
-			name:   "non-flag error",
-			err:    fmt.Errorf("some other error"),
-			flag:   "--query",
-			isTrue: false,
-		},
-		{
-			name:   "unknown flag match",
-			err:    fmt.Errorf("exit status 1: unknown flag: --query"),
-			flag:   "--query",
-			isTrue: true,
-		},
-		{
-			name:   "unknown flag different",
-			err:    fmt.Errorf("exit status 1: unknown flag: --foo"),
-			flag:   "--query",
-			isTrue: false,
-		},
-		{
-			name:   "missing flag",
-			err:    fmt.Errorf("exit status 1: unknown flag: --query"),
-			flag:   "",
-			isTrue: false,
-		},
+		{name: "nil error", err: nil, argument: "query", want: false},
+		{name: "other error", err: fmt.Errorf("graphql error: something else"), argument: "query", want: false},
+		{name: "query argument rejected", err: fmt.Errorf("graphql error: Field 'items' doesn't accept argument 'query'"), argument: "query", want: true},
+		{name: "different argument rejected", err: fmt.Errorf("graphql error: Field 'items' doesn't accept argument 'orderBy'"), argument: "query", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnknownArgumentError(tt.err, tt.argument); got != tt.want {
+				t.Fatalf("isUnknownArgumentError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsConnectivityError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil error", err: nil, want: false},
+		{name: "gh offline", err: fmt.Errorf("exit status 1: error connecting to api.github.com"), want: true},
+		{name: "dns failure", err: fmt.Errorf("Post \"https://api.github.com/graphql\": dial tcp: lookup api.github.com: no such host"), want: true},
+		{name: "gh missing", err: fmt.Errorf("run gh: %w", exec.ErrNotFound), want: true},
+		{name: "canceled", err: fmt.Errorf("fetch: %w", context.Canceled), want: false},
+		{name: "rejected by github", err: fmt.Errorf("graphql error: Could not resolve to a ProjectV2"), want: false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := isUnknownFlagError(tt.err, tt.flag); got != tt.isTrue {
-				t.Fatalf("isUnknownFlagError() = %v, want %v", got, tt.isTrue)
+			if got := IsConnectivityError(tt.err); got != tt.want {
+				t.Fatalf("IsConnectivityError() = %v, want %v", got, tt.want)
 			}
 		})
 	}
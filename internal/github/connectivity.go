@@ -0,0 +1,45 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os/exec"
+	"strings"
+)
+
+// connectivityMarkers are fragments of gh and net/http error output that mean
+// GitHub could not be reached, as opposed to GitHub rejecting the request.
+var connectivityMarkers = []string{
+	"error connecting to",
+	"no such host",
+	"connection refused",
+	"connection reset",
+	"network is unreachable",
+	"i/o timeout",
+	"tls handshake timeout",
+	"could not resolve host",
+	"temporary failure in name resolution",
+}
+
+// IsConnectivityError reports whether err means GitHub or the gh binary is
+// unavailable, so the request may succeed unchanged once connectivity returns.
+func IsConnectivityError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, exec.ErrNotFound) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range connectivityMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Package fakegh builds a fake gh executable for end-to-end tests of
+// github.CLIClient. Point CLIClient.GhPath at Fake.Path and the fake answers
+// from a fixture directory while recording every argument vector.
+//
+// Fixtures are looked up by the leading non-flag words of the command, most
+// specific first. For `gh issue view 12 --repo acme/app` the fake tries
+// issue-view-12, then issue-view, then issue. Each name may carry a call
+// number to answer repeated invocations differently: api-graphql.2 serves the
+// second `gh api graphql` call. Slashes in a word become underscores, so
+// `gh api repos/acme/app/assignees` reads api-repos_acme_app_assignees. A
+// fixture is a .json or .txt file written to stdout, or a .err file written
+// to stderr with exit status 1. Edits without a fixture succeed with no
+// output; any other command without one fails.
+package fakegh
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Call is one recorded invocation of the fake.
+type Call struct {
+	Args  []string `json:"args"`
+	Stdin string   `json:"stdin"`
+}
+
+// Fake is a gh executable bound to one fixture directory.
+type Fake struct {
+	Path string
+	dir  string
+}
+
+var (
+	buildOnce sync.Once
+	binary    string
+	buildErr  error
+)
+
+// New returns a fake gh serving fixtures from dir. The binary is compiled once
+// per test process; each Fake gets its own call log.
+func New(t testing.TB, fixtures string) *Fake {
+	t.Helper()
+	buildOnce.Do(build)
+	if buildErr != nil {
+		t.Fatalf("build fake gh: %v", buildErr)
+	}
+
+	abs, err := filepath.Abs(fixtures)
+	if err != nil {
+		t.Fatalf("resolve fixtures: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "fixtures"), []byte(abs), 0o644); err != nil {
+		t.Fatalf("write fixture location: %v", err)
+	}
+	// The fake finds its fixtures next to argv[0], so each test gets its own
+	// link to the shared binary.
+	path := filepath.Join(dir, exeName())
+	if err := os.Symlink(binary, path); err != nil {
+		data, readErr := os.ReadFile(binary)
+		if readErr != nil {
+			t.Fatalf("copy fake gh: %v", readErr)
+		}
+		if err := os.WriteFile(path, data, 0o755); err != nil {
+			t.Fatalf("copy fake gh: %v", err)
+		}
+	}
+	return &Fake{Path: path, dir: dir}
+}
+
+// Calls returns the invocations recorded so far, oldest first.
+func (f *Fake) Calls() []Call {
+	file, err := os.Open(filepath.Join(f.dir, "calls.jsonl"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	var calls []Call
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var call Call
+		if json.Unmarshal(scanner.Bytes(), &call) == nil {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallsTo returns the recorded invocations whose arguments start with prefix,
+// e.g. CallsTo("project", "item-edit").
+func (f *Fake) CallsTo(prefix ...string) []Call {
+	var matched []Call
+	for _, call := range f.Calls() {
+		if len(call.Args) >= len(prefix) && slices.Equal(call.Args[:len(prefix)], prefix) {
+			matched = append(matched, call)
+		}
+	}
+	return matched
+}
+
+func build() {
+	dir, err := os.MkdirTemp("", "fakegh")
+	if err != nil {
+		buildErr = err
+		return
+	}
+	binary = filepath.Join(dir, exeName())
+	cmd := exec.Command("go", "build", "-o", binary, "project-hub/internal/github/fakegh/gh")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		buildErr = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+}
+
+func exeName() string {
+	if runtime.GOOS == "windows" {
+		return "gh.exe"
+	}
+	return "gh"
+}
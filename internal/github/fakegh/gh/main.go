@@ -0,0 +1,133 @@
+// Command gh is a stand-in for the GitHub CLI used by end-to-end tests. It
+// answers from canned fixtures and records every invocation; see package
+// fakegh for the fixture layout.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	dir := filepath.Dir(os.Args[0])
+	args := os.Args[1:]
+
+	fixtures, err := os.ReadFile(filepath.Join(dir, "fixtures"))
+	if err != nil {
+		fail("read fixture location: %v", err)
+	}
+
+	var stdin []byte
+	if readsStdin(args) {
+		stdin, _ = io.ReadAll(os.Stdin)
+	}
+	n, err := record(filepath.Join(dir, "calls.jsonl"), args, string(stdin))
+	if err != nil {
+		fail("record call: %v", err)
+	}
+
+	for _, name := range candidates(args, n) {
+		path := filepath.Join(strings.TrimSpace(string(fixtures)), name)
+		if data, err := os.ReadFile(path + ".err"); err == nil {
+			os.Stderr.Write(data)
+			os.Exit(1)
+		}
+		if data, err := os.ReadFile(path + ".json"); err == nil {
+			os.Stdout.Write(data)
+			return
+		}
+		if data, err := os.ReadFile(path + ".txt"); err == nil {
+			os.Stdout.Write(data)
+			return
+		}
+	}
+	if isMutation(args) {
+		// Mutations without a fixture succeed silently.
+		return
+	}
+	fail("no fixture for: gh %s", strings.Join(args, " "))
+}
+
+// candidates lists fixture names from most to least specific. The call number
+// lets a fixture set answer the n-th invocation of a command differently,
+// e.g. successive pages from api graphql.
+func candidates(args []string, n int) []string {
+	var words []string
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") {
+			break
+		}
+		words = append(words, a)
+	}
+	if len(words) > 3 {
+		words = words[:3]
+	}
+	var names []string
+	for i := len(words); i > 0; i-- {
+		base := strings.ReplaceAll(strings.Join(words[:i], "-"), "/", "_")
+		names = append(names, fmt.Sprintf("%s.%d", base, n), base)
+	}
+	return names
+}
+
+// record appends the call to the log and returns how many calls with the
+// same leading words (including this one) have been made.
+func record(path string, args []string, stdin string) (int, error) {
+	data, _ := os.ReadFile(path)
+	n := 1
+	for _, line := range strings.Split(string(data), "\n") {
+		var call struct {
+			Args []string `json:"args"`
+		}
+		if json.Unmarshal([]byte(line), &call) == nil && sameCommand(call.Args, args) {
+			n++
+		}
+	}
+	line, err := json.Marshal(map[string]any{"args": args, "stdin": stdin})
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return n, err
+}
+
+func sameCommand(a, b []string) bool {
+	if len(a) < 2 || len(b) < 2 {
+		return false
+	}
+	return a[0] == b[0] && a[1] == b[1]
+}
+
+func readsStdin(args []string) bool {
+	for i, a := range args {
+		if (a == "--input" || a == "--body-file") && i+1 < len(args) && args[i+1] == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+func isMutation(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	switch args[1] {
+	case "edit", "comment", "item-edit", "item-add", "create":
+		return true
+	}
+	return false
+}
+
+func fail(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, "fake gh: "+format+"\n", a...)
+	os.Exit(2)
+}
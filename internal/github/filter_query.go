@@ -0,0 +1,144 @@
+package github
+
+import (
+	"sort"
+	"strings"
+
+	"project-hub/internal/state"
+)
+
+// FilterQuery is a filter split between GitHub's project search and the
+// client. Every term is still evaluated after fetching; Server only narrows
+// what is fetched, so items past the item limit are not missed.
+type FilterQuery struct {
+	Server     string   // Passed as the items query; "" fetches everything
+	Client     []string // Terms GitHub cannot evaluate, in query syntax
+	ServerOnly []string // Terms only GitHub evaluates, such as assignee:@me; listed last in Server
+}
+
+// CompileFilter translates the top-level terms of fs that GitHub's project
+// search understands: labels, assignees, statuses, milestones, repositories,
+// iterations and project fields, their negations, no: and has:, and
+// comparisons against numbers or dates. Free text, OR groups, created: and
+// ages such as 7d are left to the client. Terms the client cannot evaluate,
+// such as reason: or assignee:@me, are also kept in ServerOnly.
+func CompileFilter(fs state.FilterState) FilterQuery {
+	var q FilterQuery
+	var server []string
+	if iterations := BuildIterationQuery(fs.Iterations); iterations != "" {
+		server = append(server, iterations)
+	}
+
+	var conjuncts []state.FilterExpr
+	switch expr := fs.Expr.(type) {
+	case nil:
+		conjuncts = legacyFilterTerms(fs)
+	case state.FilterAnd:
+		conjuncts = expr
+	default:
+		conjuncts = []state.FilterExpr{expr}
+	}
+	for _, e := range conjuncts {
+		if t, ok := e.(state.FilterTerm); ok && t.Key == "iteration" && t.Op == "" {
+			continue // Copied to fs.Iterations by ParseFilter
+		}
+		term, ok := compileFilterTerm(e)
+		switch {
+		case !ok:
+			q.Client = append(q.Client, e.String())
+		case serverOnly(e):
+			q.ServerOnly = append(q.ServerOnly, term)
+		default:
+			server = append(server, term)
+		}
+	}
+	q.Server = strings.Join(append(server, q.ServerOnly...), " ")
+	return q
+}
+
+func serverOnly(e state.FilterExpr) bool {
+	if n, ok := e.(state.FilterNot); ok {
+		e = n.Expr
+	}
+	t, ok := e.(state.FilterTerm)
+	return ok && t.ServerOnly()
+}
+
+// legacyFilterTerms turns the fields of a FilterState built without a query
+// into terms.
+func legacyFilterTerms(fs state.FilterState) []state.FilterExpr {
+	var terms []state.FilterExpr
+	add := func(key string, values []string) {
+		if len(values) > 0 {
+			terms = append(terms, state.FilterTerm{Key: key, Values: values})
+		}
+	}
+	add("label", fs.Labels)
+	add("assignee", fs.Assignees)
+	add("status", fs.Statuses)
+	names := make([]string, 0, len(fs.FieldFilters))
+	for name := range fs.FieldFilters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, fs.FieldFilters[name])
+	}
+	if fs.Query != "" {
+		add("", []string{fs.Query})
+	}
+	return terms
+}
+
+func compileFilterTerm(e state.FilterExpr) (string, bool) {
+	switch e := e.(type) {
+	case state.FilterNot:
+		t, ok := e.Expr.(state.FilterTerm)
+		if !ok || t.Op != "" {
+			return "", false
+		}
+		term, ok := compileFilterTerm(t)
+		return "-" + term, ok
+	case state.FilterTerm:
+		switch e.Key {
+		case "", "title", "created":
+			return "", false
+		}
+		if strings.ContainsAny(e.Key, " \t") {
+			return "", false
+		}
+		switch e.Op {
+		case "no", "has":
+			return e.Op + ":" + e.Key, e.Key != "updated"
+		case "":
+			values := make([]string, len(e.Values))
+			for i, v := range e.Values {
+				values[i] = state.QuoteFilterValue(v)
+			}
+			return e.Key + ":" + strings.Join(values, ","), true
+		}
+		if ageOnly(e.Values[0]) {
+			return "", false
+		}
+		return e.Key + ":" + e.Op + state.QuoteFilterValue(e.Values[0]), true
+	}
+	return "", false
+}
+
+// ageOnly reports whether a comparison operand is an age such as 7d, which
+// GitHub's search does not accept.
+func ageOnly(operand string) bool {
+	if operand == "" {
+		return false
+	}
+	last := operand[len(operand)-1]
+	if last != 'h' && last != 'd' && last != 'w' {
+		return false
+	}
+	for _, r := range operand[:len(operand)-1] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(operand) > 1
+}
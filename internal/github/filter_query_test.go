@@ -0,0 +1,44 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"project-hub/internal/state"
+)
+
+func TestCompileFilter(t *testing.T) {
+	tests := []struct {
+		query  string
+		server string
+		client string
+	}{
+		{"label:bug,ui status:\"In Progress\"", `label:bug,ui status:"In Progress"`, ""},
+		{"-label:bug no:assignee has:milestone", "-label:bug no:assignee has:milestone", ""},
+		{"iteration:@current Estimate:>=3 updated:>2026-09-01", "iteration:@current Estimate:>=3 updated:>2026-09-01", ""},
+		{"label:bug (status:Todo OR status:Done) fix", "label:bug", "(status:Todo OR status:Done) fix"},
+		{"created:<7d updated:<2w \"Target date\":2026-10-01", "", `created:<7d updated:<2w "Target date":2026-10-01`},
+		{"status:Todo OR label:bug", "", "(status:Todo OR label:bug)"},
+		{"assignee:@me is:open -reason:completed label:bug", "is:open label:bug assignee:@me -reason:completed", ""},
+	}
+	for _, tt := range tests {
+		q := CompileFilter(state.ParseFilter(tt.query))
+		if q.Server != tt.server || strings.Join(q.Client, " ") != tt.client {
+			t.Errorf("CompileFilter(%q) = %q / %q, want %q / %q", tt.query, q.Server, q.Client, tt.server, tt.client)
+		}
+	}
+}
+
+func TestCompileFilterListsServerOnlyTerms(t *testing.T) {
+	q := CompileFilter(state.ParseFilter("assignee:@me label:bug (reason:completed OR status:Done)"))
+	if strings.Join(q.ServerOnly, " ") != "assignee:@me" {
+		t.Fatalf("expected only the top-level assignee:@me listed, got %+v", q)
+	}
+}
+
+func TestCompileFilterWithoutQuery(t *testing.T) {
+	fs := state.FilterState{Iterations: []string{"@next"}, Labels: []string{"bug"}, FieldFilters: map[string][]string{"Sprint": {"Q1"}, "Area": {"api"}}}
+	if q := CompileFilter(fs); q.Server != "iteration:@next label:bug Area:api Sprint:Q1" || len(q.Client) != 0 {
+		t.Fatalf("unexpected compiled filter %+v", q)
+	}
+}
@@ -0,0 +1,274 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"project-hub/internal/github/parse"
+	"project-hub/internal/state"
+)
+
+// graphqlFunc executes a GraphQL document and decodes the data payload into out when non-nil.
+type graphqlFunc func(ctx context.Context, query string, variables map[string]any, out any) error
+
+// itemPageSize is the largest page the GraphQL API serves for project items.
+const itemPageSize = 100
+
+// projectItemSelection mirrors the fields gh project item-list reports for each item.
+const projectItemSelection = `id updatedAt
+fieldValues(first:50){nodes{
+__typename
+... on ProjectV2ItemFieldSingleSelectValue{name optionId field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldTextValue{text field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldNumberValue{number field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldDateValue{date field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldIterationValue{iterationId title startDate duration field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldLabelValue{labels(first:20){nodes{name}} field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldUserValue{users(first:10){nodes{login}} field{... on ProjectV2FieldCommon{name}}}
+... on ProjectV2ItemFieldMilestoneValue{milestone{title} field{... on ProjectV2FieldCommon{name}}}
+}}
+content{
+__typename
+... on DraftIssue{id title body createdAt updatedAt assignees(first:10){nodes{login}}}
+... on Issue{id number title body url state createdAt updatedAt repository{nameWithOwner} assignees(first:10){nodes{login}} labels(first:20){nodes{name}} milestone{title} subIssues(first:20){totalCount nodes{title}} parent{title number}}
+... on PullRequest{id number title body url state createdAt updatedAt repository{nameWithOwner} assignees(first:10){nodes{login}} labels(first:20){nodes{name}} milestone{title}}
+}`
+
+// projectQuery wraps selection in a lookup of the project by number. Projects
+// without an explicit owner are resolved through the authenticated viewer.
+func projectQuery(owner string, varDefs string, selection string) string {
+	if isViewerOwner(owner) {
+		return fmt.Sprintf(`query($number:Int!%s){owner: viewer{project: projectV2(number:$number){%s}}}`, varDefs, selection)
+	}
+	return fmt.Sprintf(`query($owner:String!,$number:Int!%s){owner: repositoryOwner(login:$owner){... on ProjectV2Owner{project: projectV2(number:$number){%s}}}}`, varDefs, selection)
+}
+
+func projectVariables(owner string, number int) map[string]any {
+	vars := map[string]any{"number": number}
+	if !isViewerOwner(owner) {
+		vars["owner"] = owner
+	}
+	return vars
+}
+
+func isViewerOwner(owner string) bool {
+	owner = strings.TrimSpace(owner)
+	return owner == "" || owner == "@me"
+}
+
+type projectEnvelope struct {
+	Owner *struct {
+		Project json.RawMessage `json:"project"`
+	} `json:"owner"`
+}
+
+// project returns the raw project payload, or an error when the owner has no such project.
+func (e projectEnvelope) project(owner string, number int) (json.RawMessage, error) {
+	if e.Owner == nil || len(e.Owner.Project) == 0 || string(e.Owner.Project) == "null" {
+		if isViewerOwner(owner) {
+			owner = "@me"
+		}
+		return nil, fmt.Errorf("project %d not found for %s", number, owner)
+	}
+	return e.Owner.Project, nil
+}
+
+type itemsConnection struct {
+	Nodes    []map[string]any `json:"nodes"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// ItemsPage is one page of a project's items.
+type ItemsPage struct {
+	Items        []state.Item
+	NextCursor   string // "" when there are no more items
+	QueryIgnored bool   // The API does not accept the items query, so Items are not filtered
+}
+
+// itemQueryFallback remembers that the API rejected the items query argument
+// so a client does not send it again with every page.
+type itemQueryFallback struct {
+	rejected atomic.Bool
+}
+
+// fetchItems walks the project's item connection page by page until limit
+// items have been collected or the connection is exhausted.
+func fetchItems(ctx context.Context, run graphqlFunc, fallback *itemQueryFallback, projectID string, owner string, filter string, limit int) ([]state.Item, error) {
+	var items []state.Item
+	var cursor string
+	for limit <= 0 || len(items) < limit {
+		pageSize := itemPageSize
+		if limit > 0 && limit-len(items) < pageSize {
+			pageSize = limit - len(items)
+		}
+		page, err := fetchItemsPage(ctx, run, fallback, projectID, owner, filter, cursor, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	return items, nil
+}
+
+// fetchItemsPage fetches a single page of items starting after cursor. When
+// the API does not accept the items query the page is fetched without it and
+// the fallback remembers so later pages skip the rejected request.
+func fetchItemsPage(ctx context.Context, run graphqlFunc, fallback *itemQueryFallback, projectID string, owner string, filter string, cursor string, pageSize int) (ItemsPage, error) {
+	number, err := projectNumber(projectID)
+	if err != nil {
+		return ItemsPage{}, err
+	}
+	if pageSize <= 0 || pageSize > itemPageSize {
+		pageSize = itemPageSize
+	}
+
+	var page ItemsPage
+	if filter != "" && fallback.rejected.Load() {
+		filter, page.QueryIgnored = "", true
+	}
+	conn, err := queryItemsPage(ctx, run, owner, number, filter, cursor, pageSize)
+	if err != nil && filter != "" && isUnknownArgumentError(err, "query") {
+		fallback.rejected.Store(true)
+		page.QueryIgnored = true
+		conn, err = queryItemsPage(ctx, run, owner, number, "", cursor, pageSize)
+	}
+	if err != nil {
+		return ItemsPage{}, fmt.Errorf("graphql item query failed: %w", err)
+	}
+
+	for _, node := range conn.Nodes {
+		if it, ok := parse.ParseItemMap(normalizeProjectItem(node)); ok {
+			page.Items = append(page.Items, it)
+		}
+	}
+	if conn.PageInfo.HasNextPage {
+		page.NextCursor = conn.PageInfo.EndCursor
+	}
+	return page, nil
+}
+
+func queryItemsPage(ctx context.Context, run graphqlFunc, owner string, number int, filter string, cursor string, pageSize int) (itemsConnection, error) {
+	varDefs := ",$first:Int!,$after:String"
+	args := "first:$first, after:$after"
+	vars := projectVariables(owner, number)
+	vars["first"] = pageSize
+	if cursor != "" {
+		vars["after"] = cursor
+	}
+	if filter != "" {
+		varDefs += ",$query:String!"
+		args += ", query:$query"
+		vars["query"] = filter
+	}
+	selection := fmt.Sprintf(`items(%s){nodes{%s} pageInfo{hasNextPage endCursor}}`, args, projectItemSelection)
+
+	var resp projectEnvelope
+	if err := run(ctx, projectQuery(owner, varDefs, selection), vars, &resp); err != nil {
+		return itemsConnection{}, err
+	}
+	rawProject, err := resp.project(owner, number)
+	if err != nil {
+		return itemsConnection{}, err
+	}
+	var page struct {
+		Items itemsConnection `json:"items"`
+	}
+	if err := json.Unmarshal(rawProject, &page); err != nil {
+		return itemsConnection{}, fmt.Errorf("parse graphql items json: %w", err)
+	}
+	return page.Items, nil
+}
+
+// decodeGraphQLResponse unwraps a GraphQL response body, surfacing the first
+// reported error and decoding the data payload into out when non-nil.
+func decodeGraphQLResponse(body []byte, out any) error {
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("parse graphql response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		return fmt.Errorf("graphql error: %s", envelope.Errors[0].Message)
+	}
+	if out == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// normalizeProjectItem reshapes a GraphQL ProjectV2Item into the layout gh
+// project item-list emits so that parse.ParseItemMap can consume it.
+func normalizeProjectItem(raw map[string]any) map[string]any {
+	if raw == nil {
+		return nil
+	}
+	if content, ok := raw["content"].(map[string]any); ok {
+		if typ, ok := content["__typename"].(string); ok && typ != "" {
+			content["type"] = typ
+		}
+		if repo, ok := content["repository"].(map[string]any); ok {
+			if name, ok := repo["nameWithOwner"].(string); ok {
+				content["repository"] = name
+			}
+		}
+	}
+	if conn, ok := raw["fieldValues"].(map[string]any); ok {
+		nodes, _ := conn["nodes"].([]any)
+		for _, node := range nodes {
+			fm, ok := node.(map[string]any)
+			if !ok {
+				continue
+			}
+			if optionID, ok := fm["optionId"].(string); ok {
+				fm["singleSelectOption"] = map[string]any{"id": optionID, "name": fm["name"]}
+			}
+			if iterationID, ok := fm["iterationId"].(string); ok {
+				fm["iteration"] = map[string]any{
+					"iterationId": iterationID,
+					"title":       fm["title"],
+					"startDate":   fm["startDate"],
+					"duration":    fm["duration"],
+				}
+			}
+		}
+		raw["fieldValues"] = nodes
+	}
+	return raw
+}
+
+func splitRepo(repo string) (string, string, error) {
+	parts := strings.Split(normalizeRepo(repo), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("repository must be in owner/name form: %q", repo)
+	}
+	return parts[0], parts[1], nil
+}
+
+func normalizeRepo(repo string) string {
+	trimmed := strings.TrimSpace(repo)
+	if trimmed == "" {
+		return ""
+	}
+	trimmed = strings.TrimPrefix(trimmed, "https://github.com/")
+	trimmed = strings.TrimPrefix(trimmed, "http://github.com/")
+	trimmed = strings.TrimPrefix(trimmed, "github.com/")
+	return strings.Trim(trimmed, "/")
+}
+
+func viewTypeFromLayout(layout string) string {
+	return strings.TrimSuffix(strings.ToLower(layout), "_layout")
+}
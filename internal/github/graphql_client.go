@@ -0,0 +1,799 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"project-hub/internal/github/parse"
+	"project-hub/internal/state"
+)
+
+// DefaultGraphQLEndpoint is the public GitHub GraphQL API.
+const DefaultGraphQLEndpoint = "https://api.github.com/graphql"
+
+// GraphQLClient talks to the GitHub GraphQL API over HTTP instead of spawning gh.
+type GraphQLClient struct {
+	Endpoint   string
+	Token      string
+	HTTPClient *http.Client
+
+	itemQuery itemQueryFallback
+}
+
+func NewGraphQLClient(endpoint string, token string) *GraphQLClient {
+	if endpoint == "" {
+		endpoint = DefaultGraphQLEndpoint
+	}
+	return &GraphQLClient{Endpoint: endpoint, Token: token, HTTPClient: http.DefaultClient}
+}
+
+// ResolveToken returns GH_TOKEN when set, otherwise the token gh auth token reports.
+func ResolveToken(ctx context.Context, ghPath string) (string, error) {
+	if token := strings.TrimSpace(os.Getenv("GH_TOKEN")); token != "" {
+		return token, nil
+	}
+	if ghPath == "" {
+		ghPath = "gh"
+	}
+	out, err := exec.CommandContext(ctx, ghPath, "auth", "token").Output()
+	if err != nil {
+		return "", fmt.Errorf("gh auth token failed: %w", err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("gh auth token returned an empty token")
+	}
+	return token, nil
+}
+
+func (c *GraphQLClient) FetchProject(ctx context.Context, projectID string, owner string, filter string, limit int) (state.Project, []state.Item, error) {
+	proj, err := c.FetchProjectMetadata(ctx, projectID, owner)
+	if err != nil {
+		return state.Project{}, nil, err
+	}
+	items, err := c.FetchItems(ctx, projectID, owner, filter, limit)
+	if err != nil {
+		return proj, nil, err
+	}
+	return proj, items, nil
+}
+
+func (c *GraphQLClient) FetchProjectMetadata(ctx context.Context, projectID string, owner string) (state.Project, error) {
+	number, err := projectNumber(projectID)
+	if err != nil {
+		return state.Project{}, err
+	}
+
+	selection := `id title owner{... on User{login} ... on Organization{login}}
+views(first:20){nodes{name layout filter}}
+` + projectFieldsSelection
+
+	var resp projectEnvelope
+	if err := c.do(ctx, projectQuery(owner, "", selection), projectVariables(owner, number), &resp); err != nil {
+		return state.Project{}, fmt.Errorf("graphql project query failed: %w", err)
+	}
+	rawProject, err := resp.project(owner, number)
+	if err != nil {
+		return state.Project{}, err
+	}
+
+	var raw struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		Views struct {
+			Nodes []struct {
+				Name   string `json:"name"`
+				Layout string `json:"layout"`
+				Filter string `json:"filter"`
+			} `json:"nodes"`
+		} `json:"views"`
+		Fields struct {
+			Nodes []projectFieldNode `json:"nodes"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(rawProject, &raw); err != nil {
+		return state.Project{}, fmt.Errorf("parse graphql project json: %w", err)
+	}
+
+	proj := state.Project{ID: projectID, NodeID: raw.ID, Owner: raw.Owner.Login, Name: raw.Title}
+	for _, v := range raw.Views.Nodes {
+		if v.Layout != "" {
+			proj.Views = append(proj.Views, state.ViewType(viewTypeFromLayout(v.Layout)))
+		}
+		if v.Name != "" {
+			proj.SavedViews = append(proj.SavedViews, state.SavedView{Name: v.Name, Query: v.Filter, View: state.ViewType(viewTypeFromLayout(v.Layout))})
+		}
+	}
+	setProjectFields(&proj, raw.Fields.Nodes)
+	return proj, nil
+}
+
+func (c *GraphQLClient) FetchItems(ctx context.Context, projectID string, owner string, filter string, limit int) ([]state.Item, error) {
+	return fetchItems(ctx, c.do, &c.itemQuery, projectID, owner, filter, limit)
+}
+
+func (c *GraphQLClient) FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (ItemsPage, error) {
+	return fetchItemsPage(ctx, c.do, &c.itemQuery, projectID, owner, filter, cursor, pageSize)
+}
+
+func (c *GraphQLClient) CreateIssue(ctx context.Context, projectID string, owner string, repo string, title string, body string) (state.Item, error) {
+	number, err := projectNumber(projectID)
+	if err != nil {
+		return state.Item{}, fmt.Errorf("project number required to create issue: %q", projectID)
+	}
+
+	repo = strings.TrimSpace(repo)
+	if repo == "" {
+		return state.Item{}, fmt.Errorf("repository is required")
+	}
+	repoOwner, repoName, err := splitRepo(repo)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return state.Item{}, fmt.Errorf("issue title is required")
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return state.Item{}, fmt.Errorf("issue body is required")
+	}
+
+	var repoResp struct {
+		Repository *struct {
+			ID string `json:"id"`
+		} `json:"repository"`
+	}
+	if err := c.do(ctx, `query($owner:String!,$name:String!){repository(owner:$owner,name:$name){id}}`,
+		map[string]any{"owner": repoOwner, "name": repoName}, &repoResp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql repository lookup failed: %w", err)
+	}
+	if repoResp.Repository == nil {
+		return state.Item{}, fmt.Errorf("repository %s not found", repo)
+	}
+
+	var projResp projectEnvelope
+	if err := c.do(ctx, projectQuery(owner, "", "id"), projectVariables(owner, number), &projResp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql project lookup failed: %w", err)
+	}
+	rawProject, err := projResp.project(owner, number)
+	if err != nil {
+		return state.Item{}, err
+	}
+	var proj struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(rawProject, &proj); err != nil {
+		return state.Item{}, fmt.Errorf("parse graphql project json: %w", err)
+	}
+
+	var created struct {
+		CreateIssue struct {
+			Issue struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			} `json:"issue"`
+		} `json:"createIssue"`
+	}
+	if err := c.do(ctx, `mutation($input:CreateIssueInput!){createIssue(input:$input){issue{id url}}}`,
+		map[string]any{"input": map[string]any{"repositoryId": repoResp.Repository.ID, "title": title, "body": body}}, &created); err != nil {
+		return state.Item{}, fmt.Errorf("graphql createIssue failed: %w", err)
+	}
+
+	var added struct {
+		AddProjectV2ItemByID struct {
+			Item map[string]any `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	mutation := fmt.Sprintf(`mutation($input:AddProjectV2ItemByIdInput!){addProjectV2ItemById(input:$input){item{%s}}}`, projectItemSelection)
+	if err := c.do(ctx, mutation, map[string]any{"input": map[string]any{"projectId": proj.ID, "contentId": created.CreateIssue.Issue.ID}}, &added); err != nil {
+		return state.Item{}, fmt.Errorf("graphql addProjectV2ItemById failed: %w", err)
+	}
+
+	item, ok := parse.ParseItemMap(normalizeProjectItem(added.AddProjectV2ItemByID.Item))
+	if !ok {
+		return state.Item{}, fmt.Errorf("failed to parse created project item from graphql response")
+	}
+	if item.URL == "" {
+		item.URL = created.CreateIssue.Issue.URL
+	}
+	if item.Title == "" {
+		item.Title = title
+	}
+	if item.Repository == "" {
+		item.Repository = repo
+	}
+	if item.Type == "" {
+		item.Type = "Issue"
+	}
+	return item, nil
+}
+
+func (c *GraphQLClient) UpdateStatus(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string) (state.Item, error) {
+	if err := ValidateStatusUpdateIDs(projectID, itemID, fieldID, optionID); err != nil {
+		return state.Item{}, fmt.Errorf("validation failed: %w", err)
+	}
+	item, err := c.setSingleSelect(ctx, projectID, itemID, fieldID, optionID)
+	if err != nil {
+		return state.Item{}, fmt.Errorf("graphql item update for status failed: %w", err)
+	}
+	return item, nil
+}
+
+func (c *GraphQLClient) UpdateField(ctx context.Context, projectID string, owner string, itemID string, fieldID string, optionID string, fieldName string) (state.Item, error) {
+	if itemID == "" {
+		return state.Item{}, fmt.Errorf("item ID is required")
+	}
+	if fieldID == "" {
+		return state.Item{}, fmt.Errorf("field ID is required")
+	}
+	if optionID == "" {
+		return state.Item{}, fmt.Errorf("option ID is required")
+	}
+
+	item, err := c.setSingleSelect(ctx, projectID, itemID, fieldID, optionID)
+	if err != nil {
+		return state.Item{}, fmt.Errorf("graphql item update for %s failed: %w", fieldName, err)
+	}
+
+	switch fieldName {
+	case "Priority":
+		item.Priority = optionID
+	case "Milestone":
+		item.Milestone = optionID
+	case "Labels":
+		item.Labels = []string{optionID}
+	}
+	return item, nil
+}
+
+// UpdateFieldValue sets a date, number or text field. An empty value clears it.
+func (c *GraphQLClient) UpdateFieldValue(ctx context.Context, projectID string, owner string, itemID string, fieldID string, fieldType state.FieldType, value string) (state.Item, error) {
+	if itemID == "" {
+		return state.Item{}, fmt.Errorf("item ID is required")
+	}
+	if fieldID == "" {
+		return state.Item{}, fmt.Errorf("field ID is required")
+	}
+	value, err := state.NormalizeFieldValue(fieldType, value)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	input := map[string]any{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+	}
+	operation := "updateProjectV2ItemFieldValue"
+	inputType := "UpdateProjectV2ItemFieldValueInput"
+	switch {
+	case value == "":
+		operation = "clearProjectV2ItemFieldValue"
+		inputType = "ClearProjectV2ItemFieldValueInput"
+	case fieldType == state.FieldTypeDate:
+		input["value"] = map[string]any{"date": value}
+	case fieldType == state.FieldTypeNumber:
+		n, _ := strconv.ParseFloat(value, 64)
+		input["value"] = map[string]any{"number": n}
+	default:
+		input["value"] = map[string]any{"text": value}
+	}
+
+	var resp map[string]struct {
+		Item map[string]any `json:"projectV2Item"`
+	}
+	mutation := fmt.Sprintf(`mutation($input:%s!){%s(input:$input){projectV2Item{%s}}}`, inputType, operation, projectItemSelection)
+	if err := c.do(ctx, mutation, map[string]any{"input": input}, &resp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql item update for field value failed: %w", err)
+	}
+	item, ok := parse.ParseItemMap(normalizeProjectItem(resp[operation].Item))
+	if !ok {
+		return state.Item{}, fmt.Errorf("failed to parse updated item from graphql response")
+	}
+	return item, nil
+}
+
+// UpdateIteration moves an item to another iteration. An empty iterationID clears it.
+func (c *GraphQLClient) UpdateIteration(ctx context.Context, projectID string, owner string, itemID string, fieldID string, iterationID string) (state.Item, error) {
+	if itemID == "" {
+		return state.Item{}, fmt.Errorf("item ID is required")
+	}
+	if fieldID == "" {
+		return state.Item{}, fmt.Errorf("field ID is required")
+	}
+
+	input := map[string]any{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+	}
+	operation := "updateProjectV2ItemFieldValue"
+	inputType := "UpdateProjectV2ItemFieldValueInput"
+	if iterationID == "" {
+		operation = "clearProjectV2ItemFieldValue"
+		inputType = "ClearProjectV2ItemFieldValueInput"
+	} else {
+		input["value"] = map[string]any{"iterationId": iterationID}
+	}
+
+	var resp map[string]struct {
+		Item map[string]any `json:"projectV2Item"`
+	}
+	mutation := fmt.Sprintf(`mutation($input:%s!){%s(input:$input){projectV2Item{%s}}}`, inputType, operation, projectItemSelection)
+	if err := c.do(ctx, mutation, map[string]any{"input": input}, &resp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql item update for iteration failed: %w", err)
+	}
+	item, ok := parse.ParseItemMap(normalizeProjectItem(resp[operation].Item))
+	if !ok {
+		return state.Item{}, fmt.Errorf("failed to parse updated item from graphql response")
+	}
+	return item, nil
+}
+
+func (c *GraphQLClient) setSingleSelect(ctx context.Context, projectID string, itemID string, fieldID string, optionID string) (state.Item, error) {
+	var resp struct {
+		Update struct {
+			Item map[string]any `json:"projectV2Item"`
+		} `json:"updateProjectV2ItemFieldValue"`
+	}
+	mutation := fmt.Sprintf(`mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input:$input){projectV2Item{%s}}}`, projectItemSelection)
+	input := map[string]any{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+		"value":     map[string]any{"singleSelectOptionId": optionID},
+	}
+	if err := c.do(ctx, mutation, map[string]any{"input": input}, &resp); err != nil {
+		return state.Item{}, err
+	}
+	item, ok := parse.ParseItemMap(normalizeProjectItem(resp.Update.Item))
+	if !ok {
+		return state.Item{}, fmt.Errorf("failed to parse updated item from graphql response")
+	}
+	return item, nil
+}
+
+func (c *GraphQLClient) UpdateLabels(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, labels []string) (state.Item, error) {
+	if itemType != "Issue" && itemType != "PullRequest" {
+		return state.Item{}, fmt.Errorf("cannot edit labels for item of type: %s (only Issues and PullRequests can have labels)", itemType)
+	}
+	if repo == "" || number == 0 {
+		return state.Item{}, fmt.Errorf("cannot edit labels: missing repository or issue number")
+	}
+	add, remove := state.DiffValues(current, labels)
+	if len(add) == 0 && len(remove) == 0 {
+		return state.Item{ID: itemID, Labels: labels}, nil
+	}
+
+	repoOwner, repoName, err := splitRepo(repo)
+	if err != nil {
+		return state.Item{}, err
+	}
+	var resp struct {
+		Repository *struct {
+			Subject *struct {
+				ID string `json:"id"`
+			} `json:"issueOrPullRequest"`
+			Labels struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"labels"`
+		} `json:"repository"`
+	}
+	query := `query($owner:String!,$name:String!,$number:Int!){repository(owner:$owner,name:$name){issueOrPullRequest(number:$number){... on Issue{id} ... on PullRequest{id}} labels(first:100){nodes{id name}}}}`
+	if err := c.do(ctx, query, map[string]any{"owner": repoOwner, "name": repoName, "number": number}, &resp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql label lookup failed: %w", err)
+	}
+	if resp.Repository == nil || resp.Repository.Subject == nil {
+		return state.Item{}, fmt.Errorf("%s#%d not found", repo, number)
+	}
+
+	labelIDs := func(names []string) ([]string, error) {
+		var ids []string
+		for _, name := range names {
+			id := ""
+			for _, l := range resp.Repository.Labels.Nodes {
+				if strings.EqualFold(l.Name, name) {
+					id = l.ID
+					break
+				}
+			}
+			if id == "" {
+				return nil, fmt.Errorf("label %q not found in %s", name, repo)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	addIDs, err := labelIDs(add)
+	if err != nil {
+		return state.Item{}, err
+	}
+	removeIDs, err := labelIDs(remove)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	subjectID := resp.Repository.Subject.ID
+	if len(addIDs) > 0 {
+		input := map[string]any{"labelableId": subjectID, "labelIds": addIDs}
+		if err := c.do(ctx, `mutation($input:AddLabelsToLabelableInput!){addLabelsToLabelable(input:$input){clientMutationId}}`, map[string]any{"input": input}, nil); err != nil {
+			return state.Item{}, fmt.Errorf("graphql addLabelsToLabelable failed: %w", err)
+		}
+	}
+	if len(removeIDs) > 0 {
+		input := map[string]any{"labelableId": subjectID, "labelIds": removeIDs}
+		if err := c.do(ctx, `mutation($input:RemoveLabelsFromLabelableInput!){removeLabelsFromLabelable(input:$input){clientMutationId}}`, map[string]any{"input": input}, nil); err != nil {
+			return state.Item{}, fmt.Errorf("graphql removeLabelsFromLabelable failed: %w", err)
+		}
+	}
+	return state.Item{ID: itemID, Labels: labels}, nil
+}
+
+func (c *GraphQLClient) UpdateMilestone(ctx context.Context, projectID string, owner string, itemID string, milestone string) (state.Item, error) {
+	if itemID == "" {
+		return state.Item{}, fmt.Errorf("item ID is required")
+	}
+
+	var resp struct {
+		Node *struct {
+			Content *struct {
+				Type       string `json:"__typename"`
+				ID         string `json:"id"`
+				Repository struct {
+					Milestones struct {
+						Nodes []struct {
+							ID    string `json:"id"`
+							Title string `json:"title"`
+						} `json:"nodes"`
+					} `json:"milestones"`
+				} `json:"repository"`
+			} `json:"content"`
+		} `json:"node"`
+	}
+	query := `query($id:ID!){node(id:$id){... on ProjectV2Item{content{__typename ... on Issue{id repository{milestones(first:100,states:[OPEN]){nodes{id title}}}} ... on PullRequest{id repository{milestones(first:100,states:[OPEN]){nodes{id title}}}}}}}}`
+	if err := c.do(ctx, query, map[string]any{"id": itemID}, &resp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql milestone lookup failed: %w", err)
+	}
+	if resp.Node == nil || resp.Node.Content == nil || resp.Node.Content.ID == "" {
+		return state.Item{}, fmt.Errorf("cannot set milestone: item %s is not an issue or pull request", itemID)
+	}
+	content := resp.Node.Content
+
+	var milestoneID any
+	if milestone != "" {
+		for _, m := range content.Repository.Milestones.Nodes {
+			if strings.EqualFold(m.Title, milestone) {
+				milestoneID = m.ID
+				break
+			}
+		}
+		if milestoneID == nil {
+			return state.Item{}, fmt.Errorf("milestone %q not found", milestone)
+		}
+	}
+
+	mutation := `mutation($input:UpdateIssueInput!){updateIssue(input:$input){clientMutationId}}`
+	input := map[string]any{"id": content.ID, "milestoneId": milestoneID}
+	if content.Type == "PullRequest" {
+		mutation = `mutation($input:UpdatePullRequestInput!){updatePullRequest(input:$input){clientMutationId}}`
+		input = map[string]any{"pullRequestId": content.ID, "milestoneId": milestoneID}
+	}
+	if err := c.do(ctx, mutation, map[string]any{"input": input}, nil); err != nil {
+		return state.Item{}, fmt.Errorf("graphql milestone update failed: %w", err)
+	}
+	return state.Item{ID: itemID, Milestone: milestone}, nil
+}
+
+func (c *GraphQLClient) UpdateAssignees(ctx context.Context, projectID string, owner string, itemID string, itemType string, repo string, number int, current []string, userLogins []string) (state.Item, error) {
+	if itemType != "Issue" && itemType != "PullRequest" {
+		return state.Item{}, fmt.Errorf("cannot assign to item of type: %s (only Issues and PullRequests can be assigned)", itemType)
+	}
+	if repo == "" || number == 0 {
+		return state.Item{}, fmt.Errorf("cannot edit assignees: missing repository or issue number")
+	}
+	add, remove := state.DiffValues(current, userLogins)
+	if len(add) == 0 && len(remove) == 0 {
+		return state.Item{ID: itemID, Assignees: userLogins}, nil
+	}
+
+	subjectID, _, err := c.lookupSubject(ctx, repo, number)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	userIDs := func(logins []string) ([]string, error) {
+		var ids []string
+		for _, login := range logins {
+			var resp struct {
+				User *struct {
+					ID string `json:"id"`
+				} `json:"user"`
+			}
+			if err := c.do(ctx, `query($login:String!){user(login:$login){id}}`, map[string]any{"login": login}, &resp); err != nil {
+				return nil, fmt.Errorf("graphql user lookup for %s failed: %w", login, err)
+			}
+			if resp.User == nil {
+				return nil, fmt.Errorf("user %q not found", login)
+			}
+			ids = append(ids, resp.User.ID)
+		}
+		return ids, nil
+	}
+	addIDs, err := userIDs(add)
+	if err != nil {
+		return state.Item{}, err
+	}
+	removeIDs, err := userIDs(remove)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	if len(addIDs) > 0 {
+		input := map[string]any{"assignableId": subjectID, "assigneeIds": addIDs}
+		if err := c.do(ctx, `mutation($input:AddAssigneesToAssignableInput!){addAssigneesToAssignable(input:$input){clientMutationId}}`, map[string]any{"input": input}, nil); err != nil {
+			return state.Item{}, fmt.Errorf("graphql addAssigneesToAssignable failed: %w", err)
+		}
+	}
+	if len(removeIDs) > 0 {
+		input := map[string]any{"assignableId": subjectID, "assigneeIds": removeIDs}
+		if err := c.do(ctx, `mutation($input:RemoveAssigneesFromAssignableInput!){removeAssigneesFromAssignable(input:$input){clientMutationId}}`, map[string]any{"input": input}, nil); err != nil {
+			return state.Item{}, fmt.Errorf("graphql removeAssigneesFromAssignable failed: %w", err)
+		}
+	}
+	return state.Item{ID: itemID, Assignees: userLogins}, nil
+}
+
+func (c *GraphQLClient) UpdateItem(ctx context.Context, projectID string, owner string, item state.Item, title string, description *string) (state.Item, error) {
+	input := map[string]any{}
+	if title != "" {
+		input["title"] = title
+	}
+	if description != nil {
+		input["body"] = *description
+	}
+
+	var mutation string
+	switch item.Type {
+	case "Issue", "PullRequest":
+		if item.Number == 0 || item.Repository == "" {
+			return state.Item{}, fmt.Errorf("cannot edit issue without number or repository")
+		}
+		subjectID := item.ContentID
+		if subjectID == "" {
+			id, _, err := c.lookupSubject(ctx, item.Repository, item.Number)
+			if err != nil {
+				return state.Item{}, err
+			}
+			subjectID = id
+		}
+		if item.Type == "PullRequest" {
+			mutation = `mutation($input:UpdatePullRequestInput!){updatePullRequest(input:$input){clientMutationId}}`
+			input["pullRequestId"] = subjectID
+		} else {
+			mutation = `mutation($input:UpdateIssueInput!){updateIssue(input:$input){clientMutationId}}`
+			input["id"] = subjectID
+		}
+	default:
+		if !strings.HasPrefix(item.ContentID, "DI_") {
+			return state.Item{}, fmt.Errorf("cannot edit draft issue without content ID")
+		}
+		mutation = `mutation($input:UpdateProjectV2DraftIssueInput!){updateProjectV2DraftIssue(input:$input){clientMutationId}}`
+		input["draftIssueId"] = item.ContentID
+	}
+
+	if err := c.do(ctx, mutation, map[string]any{"input": input}, nil); err != nil {
+		return state.Item{}, fmt.Errorf("graphql item edit failed: %w", err)
+	}
+
+	item.Title = title
+	if description != nil {
+		item.Description = *description
+	}
+	return item, nil
+}
+
+func (c *GraphQLClient) UpdateIssueBody(ctx context.Context, repo string, number int, body string) error {
+	repo = strings.TrimSpace(repo)
+	if repo == "" || number <= 0 {
+		return fmt.Errorf("cannot edit issue body: missing repository or issue number")
+	}
+
+	subjectID, typ, err := c.lookupSubject(ctx, repo, number)
+	if err != nil {
+		return err
+	}
+	mutation := `mutation($input:UpdateIssueInput!){updateIssue(input:$input){clientMutationId}}`
+	input := map[string]any{"id": subjectID, "body": body}
+	if typ == "PullRequest" {
+		mutation = `mutation($input:UpdatePullRequestInput!){updatePullRequest(input:$input){clientMutationId}}`
+		input = map[string]any{"pullRequestId": subjectID, "body": body}
+	}
+	if err := c.do(ctx, mutation, map[string]any{"input": input}, nil); err != nil {
+		return fmt.Errorf("graphql issue body update failed: %w", err)
+	}
+	return nil
+}
+
+func (c *GraphQLClient) AddIssueComment(ctx context.Context, repo string, number int, body string) error {
+	repo = strings.TrimSpace(repo)
+	if repo == "" || number <= 0 {
+		return fmt.Errorf("cannot add issue comment: missing repository or issue number")
+	}
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("comment body is required")
+	}
+
+	subjectID, _, err := c.lookupSubject(ctx, repo, number)
+	if err != nil {
+		return err
+	}
+	input := map[string]any{"subjectId": subjectID, "body": body}
+	if err := c.do(ctx, `mutation($input:AddCommentInput!){addComment(input:$input){clientMutationId}}`, map[string]any{"input": input}, nil); err != nil {
+		return fmt.Errorf("graphql addComment failed: %w", err)
+	}
+	return nil
+}
+
+func (c *GraphQLClient) FetchIssueDetail(ctx context.Context, repo string, number int) (state.Item, error) {
+	repoOwner, repoName, err := splitRepo(repo)
+	if err != nil {
+		return state.Item{}, err
+	}
+
+	var resp struct {
+		Repository *struct {
+			Subject *struct {
+				Body     string          `json:"body"`
+				Comments json.RawMessage `json:"comments"`
+			} `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+	query := `query($owner:String!,$name:String!,$number:Int!){repository(owner:$owner,name:$name){issueOrPullRequest(number:$number){... on Issue{body comments(first:100){nodes{author{login} body createdAt}}} ... on PullRequest{body comments(first:100){nodes{author{login} body createdAt}}}}}}`
+	if err := c.do(ctx, query, map[string]any{"owner": repoOwner, "name": repoName, "number": number}, &resp); err != nil {
+		return state.Item{}, fmt.Errorf("graphql issue query failed: %w", err)
+	}
+	if resp.Repository == nil || resp.Repository.Subject == nil {
+		return state.Item{}, fmt.Errorf("%s#%d not found", repo, number)
+	}
+
+	comments, err := parseIssueComments(resp.Repository.Subject.Comments)
+	if err != nil {
+		return state.Item{}, fmt.Errorf("parse graphql issue comments json: %w", err)
+	}
+	return state.Item{Description: resp.Repository.Subject.Body, Comments: comments}, nil
+}
+
+// FetchRepoMetadata lists the labels, open milestones and assignable users
+// of repo.
+func (c *GraphQLClient) FetchRepoMetadata(ctx context.Context, repo string) (state.RepoMetadata, error) {
+	repoOwner, repoName, err := splitRepo(repo)
+	if err != nil {
+		return state.RepoMetadata{}, err
+	}
+	var resp struct {
+		Repository *struct {
+			Labels struct {
+				Nodes []state.Label `json:"nodes"`
+			} `json:"labels"`
+			Milestones struct {
+				Nodes []struct {
+					Title string `json:"title"`
+				} `json:"nodes"`
+			} `json:"milestones"`
+			Users struct {
+				Nodes []struct {
+					Login string `json:"login"`
+				} `json:"nodes"`
+			} `json:"assignableUsers"`
+		} `json:"repository"`
+	}
+	query := `query($owner:String!,$name:String!){repository(owner:$owner,name:$name){labels(first:100,orderBy:{field:NAME,direction:ASC}){nodes{name color}} milestones(first:100,states:[OPEN]){nodes{title}} assignableUsers(first:100){nodes{login}}}}`
+	if err := c.do(ctx, query, map[string]any{"owner": repoOwner, "name": repoName}, &resp); err != nil {
+		return state.RepoMetadata{}, fmt.Errorf("graphql repository metadata query failed: %w", err)
+	}
+	if resp.Repository == nil {
+		return state.RepoMetadata{}, fmt.Errorf("repository %s not found", repo)
+	}
+	meta := state.RepoMetadata{Labels: resp.Repository.Labels.Nodes, FetchedAt: time.Now()}
+	for _, m := range resp.Repository.Milestones.Nodes {
+		meta.Milestones = append(meta.Milestones, m.Title)
+	}
+	for _, u := range resp.Repository.Users.Nodes {
+		meta.Assignees = append(meta.Assignees, u.Login)
+	}
+	return meta, nil
+}
+
+// lookupSubject resolves the node ID and type of an issue or pull request.
+func (c *GraphQLClient) lookupSubject(ctx context.Context, repo string, number int) (string, string, error) {
+	repoOwner, repoName, err := splitRepo(repo)
+	if err != nil {
+		return "", "", err
+	}
+	var resp struct {
+		Repository *struct {
+			Subject *struct {
+				Type string `json:"__typename"`
+				ID   string `json:"id"`
+			} `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+	query := `query($owner:String!,$name:String!,$number:Int!){repository(owner:$owner,name:$name){issueOrPullRequest(number:$number){__typename ... on Issue{id} ... on PullRequest{id}}}}`
+	if err := c.do(ctx, query, map[string]any{"owner": repoOwner, "name": repoName, "number": number}, &resp); err != nil {
+		return "", "", fmt.Errorf("graphql issue lookup failed: %w", err)
+	}
+	if resp.Repository == nil || resp.Repository.Subject == nil || resp.Repository.Subject.ID == "" {
+		return "", "", fmt.Errorf("%s#%d not found", repo, number)
+	}
+	return resp.Repository.Subject.ID, resp.Repository.Subject.Type, nil
+}
+
+// do posts a GraphQL document and decodes the data payload into out when non-nil.
+func (c *GraphQLClient) do(ctx context.Context, query string, variables map[string]any, out any) error {
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return decodeGraphQLResponse(body, out)
+}
+
+func projectNumber(projectID string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimSpace(projectID))
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("project number required: %q", projectID)
+	}
+	return number, nil
+}
+
+func isUnknownArgumentError(err error, argument string) bool {
+	if err == nil || argument == "" {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "doesn't accept argument") && strings.Contains(message, "'"+argument+"'")
+}
@@ -0,0 +1,334 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"project-hub/internal/state"
+)
+
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newGraphQLServer answers each request with the response returned by handle.
+func newGraphQLServer(t *testing.T, handle func(req graphqlRequest) string) (*httptest.Server, *[]graphqlRequest) {
+	t.Helper()
+	var requests []graphqlRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "bearer test-token" {
+			t.Errorf("expected bearer token header, got %q", got)
+		}
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(handle(req)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestGraphQLClientFetchProject(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		if strings.Contains(req.Query, "items(") {
+			if req.Variables["after"] == nil {
+				return `{"data":{"owner":{"project":{"items":{
+					"nodes":[{"id":"PVTI_1","updatedAt":"2026-01-02T03:04:05Z",
+						"fieldValues":{"nodes":[
+							{"__typename":"ProjectV2ItemFieldSingleSelectValue","name":"Todo","optionId":"opt-todo","field":{"name":"Status"}},
+							{"__typename":"ProjectV2ItemFieldIterationValue","iterationId":"it-1","title":"Sprint 1","startDate":"2026-01-01","duration":14,"field":{"name":"Iteration"}}
+						]},
+						"content":{"__typename":"Issue","id":"I_1","number":7,"title":"First","body":"Body","url":"https://github.com/acme/app/issues/7",
+							"repository":{"nameWithOwner":"acme/app"},"assignees":{"nodes":[{"login":"alice"}]},"labels":{"nodes":[{"name":"bug"}]}}}],
+					"pageInfo":{"hasNextPage":true,"endCursor":"cursor-1"}}}}}}`
+			}
+			return `{"data":{"owner":{"project":{"items":{
+				"nodes":[{"id":"PVTI_2","fieldValues":{"nodes":[]},"content":{"__typename":"DraftIssue","id":"DI_2","title":"Second"}}],
+				"pageInfo":{"hasNextPage":false,"endCursor":""}}}}}}`
+		}
+		return `{"data":{"owner":{"project":{"id":"PVT_node","title":"Roadmap","owner":{"login":"acme"},
+			"views":{"nodes":[{"name":"Board","layout":"BOARD_LAYOUT","filter":""},{"name":"Bugs","layout":"TABLE_LAYOUT","filter":"label:bug"}]},
+			"fields":{"nodes":[{"__typename":"ProjectV2SingleSelectField","id":"F_status","name":"Status","dataType":"SINGLE_SELECT","options":[{"id":"opt-todo","name":"Todo"}]},{"__typename":"ProjectV2Field","id":"F_title","name":"Title","dataType":"TITLE"},
+				{"__typename":"ProjectV2IterationField","id":"F_sprint","name":"Sprint","dataType":"ITERATION","configuration":{
+					"iterations":[{"id":"it-2","title":"Sprint 2","startDate":"2026-01-15","duration":14}],
+					"completedIterations":[{"id":"it-1","title":"Sprint 1","startDate":"2026-01-01","duration":14}]}}]}}}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	proj, items, err := client.FetchProject(context.Background(), "5", "acme", "", 100)
+	if err != nil {
+		t.Fatalf("FetchProject returned error: %v", err)
+	}
+
+	if proj.NodeID != "PVT_node" || proj.Name != "Roadmap" || proj.Owner != "acme" {
+		t.Fatalf("unexpected project metadata: %+v", proj)
+	}
+	if len(proj.Views) != 2 || proj.Views[0] != "board" || proj.Views[1] != "table" {
+		t.Fatalf("expected board and table views, got %v", proj.Views)
+	}
+	if len(proj.SavedViews) != 2 || !reflect.DeepEqual(proj.SavedViews[1], state.SavedView{Name: "Bugs", Query: "label:bug", View: state.ViewTable}) {
+		t.Fatalf("expected saved views with filters, got %+v", proj.SavedViews)
+	}
+	if len(proj.Fields) != 3 || len(proj.Fields[0].Options) != 1 {
+		t.Fatalf("expected fields with options, got %+v", proj.Fields)
+	}
+	if proj.Fields[0].Type != state.FieldTypeSingleSelect || proj.Fields[1].Type != "TITLE" || proj.Fields[2].Type != state.FieldTypeIteration {
+		t.Fatalf("expected field types from dataType, got %+v", proj.Fields)
+	}
+	if len(proj.Iterations) != 2 || !proj.Iterations[0].Completed || proj.Iterations[1].Name != "Sprint 2" || proj.Iterations[1].Start == nil {
+		t.Fatalf("expected completed and upcoming iterations, got %+v", proj.Iterations)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items across pages, got %d", len(items))
+	}
+	first := items[0]
+	if first.Status != "Todo" || first.Repository != "acme/app" || first.Number != 7 || first.Type != "Issue" {
+		t.Fatalf("unexpected first item: %+v", first)
+	}
+	if first.IterationID != "it-1" || first.IterationDurationDays != 14 {
+		t.Fatalf("expected iteration metadata, got %+v", first)
+	}
+	if len(first.Assignees) != 1 || first.Assignees[0] != "alice" || len(first.Labels) != 1 || first.Labels[0] != "bug" {
+		t.Fatalf("expected assignees and labels, got %+v", first)
+	}
+	if items[1].ContentID != "DI_2" || items[1].Type != "DraftIssue" {
+		t.Fatalf("unexpected draft item: %+v", items[1])
+	}
+
+	reqs := *requests
+	if len(reqs) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(reqs))
+	}
+	if reqs[0].Variables["owner"] != "acme" || reqs[0].Variables["number"] != float64(5) {
+		t.Fatalf("unexpected project variables: %v", reqs[0].Variables)
+	}
+	if reqs[2].Variables["after"] != "cursor-1" {
+		t.Fatalf("expected second page to use cursor, got %v", reqs[2].Variables)
+	}
+}
+
+func TestGraphQLClientUsesViewerWithoutOwner(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		return `{"data":{"owner":{"project":{"items":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	if _, err := client.FetchItems(context.Background(), "3", "", "", 10); err != nil {
+		t.Fatalf("FetchItems returned error: %v", err)
+	}
+	req := (*requests)[0]
+	if !strings.Contains(req.Query, "viewer") {
+		t.Fatalf("expected viewer lookup, got %s", req.Query)
+	}
+	if _, ok := req.Variables["owner"]; ok {
+		t.Fatalf("expected no owner variable, got %v", req.Variables)
+	}
+}
+
+func TestGraphQLClientUpdateStatus(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		return `{"data":{"updateProjectV2ItemFieldValue":{"projectV2Item":{"id":"PVTI_1",
+			"fieldValues":{"nodes":[{"name":"Done","optionId":"opt-done","field":{"name":"Status"}}]},
+			"content":{"__typename":"Issue","id":"I_1","title":"First"}}}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	item, err := client.UpdateStatus(context.Background(), "PVT_node", "acme", "PVTI_1", "PVTF_status", "opt-done")
+	if err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	if item.Status != "Done" {
+		t.Fatalf("expected Done status, got %q", item.Status)
+	}
+
+	input, _ := (*requests)[0].Variables["input"].(map[string]any)
+	value, _ := input["value"].(map[string]any)
+	if input["projectId"] != "PVT_node" || input["itemId"] != "PVTI_1" || value["singleSelectOptionId"] != "opt-done" {
+		t.Fatalf("unexpected mutation input: %v", input)
+	}
+}
+
+func TestGraphQLClientUpdateFieldValue(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		if strings.Contains(req.Query, "clearProjectV2ItemFieldValue") {
+			return `{"data":{"clearProjectV2ItemFieldValue":{"projectV2Item":{"id":"PVTI_1","fieldValues":{"nodes":[]},
+				"content":{"__typename":"Issue","id":"I_1","title":"First"}}}}}`
+		}
+		return `{"data":{"updateProjectV2ItemFieldValue":{"projectV2Item":{"id":"PVTI_1",
+			"fieldValues":{"nodes":[{"__typename":"ProjectV2ItemFieldNumberValue","number":2.5,"field":{"name":"Estimate"}}]},
+			"content":{"__typename":"Issue","id":"I_1","title":"First"}}}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	item, err := client.UpdateFieldValue(context.Background(), "PVT_node", "acme", "PVTI_1", "PVTF_estimate", state.FieldTypeNumber, "2.50")
+	if err != nil {
+		t.Fatalf("UpdateFieldValue returned error: %v", err)
+	}
+	if got := item.FieldValues["Estimate"]; len(got) != 1 || got[0] != "2.5" {
+		t.Fatalf("expected estimate 2.5, got %v", got)
+	}
+	input, _ := (*requests)[0].Variables["input"].(map[string]any)
+	value, _ := input["value"].(map[string]any)
+	if input["fieldId"] != "PVTF_estimate" || value["number"] != 2.5 {
+		t.Fatalf("unexpected mutation input: %v", input)
+	}
+
+	if _, err := client.UpdateFieldValue(context.Background(), "PVT_node", "acme", "PVTI_1", "PVTF_estimate", state.FieldTypeNumber, ""); err != nil {
+		t.Fatalf("clearing field returned error: %v", err)
+	}
+	clear := (*requests)[1]
+	if !strings.Contains(clear.Query, "clearProjectV2ItemFieldValue") {
+		t.Fatalf("expected clear mutation, got %s", clear.Query)
+	}
+	if input, _ := clear.Variables["input"].(map[string]any); input["value"] != nil {
+		t.Fatalf("expected no value when clearing, got %v", input)
+	}
+}
+
+func TestGraphQLClientReportsErrors(t *testing.T) {
+	srv, _ := newGraphQLServer(t, func(req graphqlRequest) string {
+		return `{"data":null,"errors":[{"message":"Could not resolve to a ProjectV2"}]}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	_, _, err := client.FetchProject(context.Background(), "1", "acme", "", 10)
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve to a ProjectV2") {
+		t.Fatalf("expected graphql error to surface, got %v", err)
+	}
+}
+
+func TestGraphQLClientRemembersRejectedItemQuery(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		if req.Variables["query"] != nil {
+			return `{"data":null,"errors":[{"message":"Field 'items' doesn't accept argument 'query'"}]}`
+		}
+		return `{"data":{"owner":{"project":{"items":{
+			"nodes":[{"id":"PVTI_1","fieldValues":{"nodes":[]},"content":{"__typename":"DraftIssue","id":"DI_1","title":"First"}}],
+			"pageInfo":{"hasNextPage":true,"endCursor":"cursor-1"}}}}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	page, err := client.FetchItemsPage(context.Background(), "1", "acme", "label:bug", "", 10)
+	if err != nil || !page.QueryIgnored || len(page.Items) != 1 || page.NextCursor != "cursor-1" {
+		t.Fatalf("expected an unfiltered page flagged as such, got %+v, %v", page, err)
+	}
+	page, err = client.FetchItemsPage(context.Background(), "1", "acme", "label:bug", page.NextCursor, 10)
+	if err != nil || !page.QueryIgnored {
+		t.Fatalf("expected the next page flagged as unfiltered, got %+v, %v", page, err)
+	}
+	if len(*requests) != 3 {
+		t.Fatalf("expected the query tried once, then two pages without it; got %d requests", len(*requests))
+	}
+}
+
+func TestGraphQLClientAddIssueComment(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		if strings.HasPrefix(req.Query, "query") {
+			return `{"data":{"repository":{"issueOrPullRequest":{"__typename":"Issue","id":"I_42"}}}}`
+		}
+		return `{"data":{"addComment":{"clientMutationId":null}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	if err := client.AddIssueComment(context.Background(), "acme/app", 42, "Looks good"); err != nil {
+		t.Fatalf("AddIssueComment returned error: %v", err)
+	}
+	reqs := *requests
+	if len(reqs) != 2 {
+		t.Fatalf("expected lookup and mutation, got %d requests", len(reqs))
+	}
+	input, _ := reqs[1].Variables["input"].(map[string]any)
+	if input["subjectId"] != "I_42" || input["body"] != "Looks good" {
+		t.Fatalf("unexpected comment input: %v", input)
+	}
+}
+
+func TestGraphQLClientUpdateItemClearsBody(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		return `{"data":{"updateProjectV2DraftIssue":{"clientMutationId":null}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	draft := state.Item{ID: "PVTI_1", ContentID: "DI_1", Title: "Draft", Description: "Body"}
+	empty := ""
+	updated, err := client.UpdateItem(context.Background(), "PVT_1", "acme", draft, "Draft", &empty)
+	if err != nil {
+		t.Fatalf("UpdateItem returned error: %v", err)
+	}
+	input, _ := (*requests)[0].Variables["input"].(map[string]any)
+	if body, ok := input["body"]; !ok || body != "" || updated.Description != "" {
+		t.Fatalf("expected the body cleared, got input %v and item %+v", input, updated)
+	}
+
+	if _, err := client.UpdateItem(context.Background(), "PVT_1", "acme", draft, "Renamed", nil); err != nil {
+		t.Fatalf("UpdateItem returned error: %v", err)
+	}
+	if input, _ := (*requests)[1].Variables["input"].(map[string]any); input["body"] != nil {
+		t.Fatalf("expected a title-only edit to leave the body out, got %v", input)
+	}
+}
+
+func TestGraphQLClientUpdateLabelsAddsAndRemoves(t *testing.T) {
+	srv, requests := newGraphQLServer(t, func(req graphqlRequest) string {
+		if strings.HasPrefix(req.Query, "query") {
+			return `{"data":{"repository":{"issueOrPullRequest":{"id":"I_42"},"labels":{"nodes":[{"id":"L_bug","name":"bug"},{"id":"L_ui","name":"ui"},{"id":"L_docs","name":"docs"}]}}}}`
+		}
+		return `{"data":{}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	if _, err := client.UpdateLabels(context.Background(), "PVT_1", "acme", "PVTI_1", "Issue", "acme/app", 42, []string{"bug", "ui"}, []string{"ui", "docs"}); err != nil {
+		t.Fatalf("UpdateLabels returned error: %v", err)
+	}
+	reqs := *requests
+	if len(reqs) != 3 || !strings.Contains(reqs[1].Query, "addLabelsToLabelable") || !strings.Contains(reqs[2].Query, "removeLabelsFromLabelable") {
+		t.Fatalf("expected lookup, add and remove, got %+v", reqs)
+	}
+	added, _ := reqs[1].Variables["input"].(map[string]any)
+	removed, _ := reqs[2].Variables["input"].(map[string]any)
+	if ids, _ := added["labelIds"].([]any); len(ids) != 1 || ids[0] != "L_docs" {
+		t.Fatalf("unexpected labels added: %v", added)
+	}
+	if ids, _ := removed["labelIds"].([]any); len(ids) != 1 || ids[0] != "L_bug" {
+		t.Fatalf("unexpected labels removed: %v", removed)
+	}
+}
+
+func TestGraphQLClientFetchRepoMetadata(t *testing.T) {
+	srv, _ := newGraphQLServer(t, func(req graphqlRequest) string {
+		return `{"data":{"repository":{"labels":{"nodes":[{"name":"bug","color":"d73a4a"}]},"milestones":{"nodes":[{"title":"v1.0"}]},"assignableUsers":{"nodes":[{"login":"alice"},{"login":"bob"}]}}}}`
+	})
+
+	client := NewGraphQLClient(srv.URL, "test-token")
+	meta, err := client.FetchRepoMetadata(context.Background(), "acme/app")
+	if err != nil {
+		t.Fatalf("FetchRepoMetadata returned error: %v", err)
+	}
+	if len(meta.Labels) != 1 || meta.Labels[0].Color != "d73a4a" {
+		t.Fatalf("unexpected labels: %+v", meta.Labels)
+	}
+	if len(meta.Milestones) != 1 || meta.Milestones[0] != "v1.0" || len(meta.Assignees) != 2 {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+}
+
+func TestResolveTokenPrefersEnvironment(t *testing.T) {
+	t.Setenv("GH_TOKEN", "env-token")
+	token, err := ResolveToken(context.Background(), "/nonexistent/gh")
+	if err != nil {
+		t.Fatalf("ResolveToken returned error: %v", err)
+	}
+	if token != "env-token" {
+		t.Fatalf("expected env-token, got %q", token)
+	}
+}
@@ -2,6 +2,7 @@ package parse
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -171,11 +172,14 @@ func addFieldValue(values map[string][]string, fieldName string, fieldMap map[st
 	if num, ok := fieldMap["number"]; ok {
 		switch n := num.(type) {
 		case float64:
-			collected = append(collected, fmt.Sprintf("%.0f", n))
+			collected = append(collected, strconv.FormatFloat(n, 'f', -1, 64))
 		case int:
 			collected = append(collected, fmt.Sprintf("%d", n))
 		}
 	}
+	if date, ok := fieldMap["date"].(string); ok && date != "" {
+		collected = append(collected, date)
+	}
 	if labelsVal, ok := fieldMap["labels"]; ok {
 		collected = append(collected, extractLabelNames(labelsVal)...)
 	}
@@ -40,8 +40,8 @@ func ParseItemMap(r any) (state.Item, bool) {
 		if url, ok := content["url"].(string); ok {
 			item.URL = url
 		}
-		if st, ok := content["state"].(string); ok && item.Status == "" {
-			item.Status = st
+		if st, ok := content["state"].(string); ok {
+			item.State = strings.ToUpper(st)
 		}
 		if repo, ok := content["repository"].(string); ok && item.Repository == "" {
 			item.Repository = repo
@@ -258,5 +258,13 @@ func ParseItemMap(r any) (state.Item, bool) {
 			item.UpdatedAt = &t
 		}
 	}
+	// Comments and closing touch the issue or pull request, not the project item.
+	if content, ok := m["content"].(map[string]any); ok {
+		if updated, ok := content["updatedAt"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, updated); err == nil && (item.UpdatedAt == nil || t.After(*item.UpdatedAt)) {
+				item.UpdatedAt = &t
+			}
+		}
+	}
 	return item, true
 }
@@ -4,11 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
+	"time"
 
-	"project-hub/internal/github/parse"
 	"project-hub/internal/state"
 )
 
@@ -39,17 +37,29 @@ func BuildIterationQuery(iterationFilters []string) string {
 }
 
 func (c *CLIClient) FetchProject(ctx context.Context, projectID string, owner string, filter string, limit int) (state.Project, []state.Item, error) {
+	proj, err := c.FetchProjectMetadata(ctx, projectID, owner)
+	if err != nil {
+		return state.Project{}, nil, err
+	}
+	items, err := c.FetchItems(ctx, projectID, owner, filter, limit)
+	if err != nil {
+		return proj, nil, err
+	}
+	return proj, items, nil
+}
+
+func (c *CLIClient) FetchProjectMetadata(ctx context.Context, projectID string, owner string) (state.Project, error) {
 	viewArgs := []string{"project", "view", projectID, "--format", "json"}
 	if owner != "" {
 		viewArgs = append(viewArgs, "--owner", owner)
 	}
 	out, err := c.runGh(ctx, viewArgs...)
 	if err != nil {
-		return state.Project{}, nil, fmt.Errorf("gh project view failed: %w", err)
+		return state.Project{}, fmt.Errorf("gh project view failed: %w", err)
 	}
 	var raw map[string]any
 	if err := json.Unmarshal(out, &raw); err != nil {
-		return state.Project{}, nil, fmt.Errorf("parse gh project view json: %w", err)
+		return state.Project{}, fmt.Errorf("parse gh project view json: %w", err)
 	}
 	proj := state.Project{ID: projectID}
 	if id, ok := raw["id"].(string); ok && id != "" {
@@ -66,9 +76,14 @@ func (c *CLIClient) FetchProject(ctx context.Context, projectID string, owner st
 	if views, ok := raw["views"].([]any); ok {
 		for _, v := range views {
 			if m, ok := v.(map[string]any); ok {
-				if typ, ok := m["type"].(string); ok {
+				typ, _ := m["type"].(string)
+				if typ != "" {
 					proj.Views = append(proj.Views, state.ViewType(strings.ToLower(typ)))
 				}
+				if name, _ := m["name"].(string); name != "" {
+					filter, _ := m["filter"].(string)
+					proj.SavedViews = append(proj.SavedViews, state.SavedView{Name: name, Query: filter, View: state.ViewType(viewTypeFromLayout(typ))})
+				}
 			}
 		}
 	}
@@ -81,269 +96,157 @@ func (c *CLIClient) FetchProject(ctx context.Context, projectID string, owner st
 	if err != nil {
 	} else {
 		var rawFields struct {
-			Fields []struct {
-				ID      string `json:"id"`
-				Name    string `json:"name"`
-				Options []struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-				} `json:"options"`
-			} `json:"fields"`
+			Fields []projectFieldNode `json:"fields"`
 		}
 		if err := json.Unmarshal(fieldsOut, &rawFields); err == nil {
-			for _, rf := range rawFields.Fields {
-				field := state.Field{
-					ID:   rf.ID,
-					Name: rf.Name,
-				}
-				for _, ro := range rf.Options {
-					field.Options = append(field.Options, state.Option{
-						ID:   ro.ID,
-						Name: ro.Name,
-					})
-				}
-				proj.Fields = append(proj.Fields, field)
-			}
+			setProjectFields(&proj, rawFields.Fields)
 		}
 	}
-
-	items, err := c.FetchItems(ctx, projectID, owner, filter, limit)
-	if err != nil {
-		return proj, nil, err
+	if proj.NodeID != "" && lacksFieldDetails(proj) {
+		// field-list reports plain fields without their data type and leaves
+		// out iteration configuration, so read the fields again over GraphQL.
+		var resp struct {
+			Node *struct {
+				Fields struct {
+					Nodes []projectFieldNode `json:"nodes"`
+				} `json:"fields"`
+			} `json:"node"`
+		}
+		query := `query($id:ID!){node(id:$id){... on ProjectV2{` + projectFieldsSelection + `}}}`
+		if err := c.graphql(ctx, query, map[string]any{"id": proj.NodeID}, &resp); err == nil && resp.Node != nil {
+			setProjectFields(&proj, resp.Node.Fields.Nodes)
+		}
 	}
-	return proj, items, nil
+	return proj, nil
 }
 
-func (c *CLIClient) FetchItems(ctx context.Context, projectID string, owner string, filter string, limit int) ([]state.Item, error) {
-	limitStr := strconv.Itoa(limit)
-	baseArgs := []string{"project", "item-list", projectID, "--format", "json", "--limit", limitStr}
-	if owner != "" {
-		baseArgs = append(baseArgs, "--owner", owner)
-	}
+// projectFieldsSelection reads every project field with its data type,
+// single-select options and iteration configuration.
+const projectFieldsSelection = `fields(first:50){nodes{__typename
+... on ProjectV2FieldCommon{id name dataType}
+... on ProjectV2SingleSelectField{options{id name}}
+... on ProjectV2IterationField{configuration{iterations{id title startDate duration} completedIterations{id title startDate duration}}}
+}}`
+
+// projectFieldNode is a project field as gh project field-list or the GraphQL
+// API reports it.
+type projectFieldNode struct {
+	TypeName string `json:"__typename"`
+	Type     string `json:"type"` // field-list's name for __typename
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	DataType string `json:"dataType"`
+	Options  []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"options"`
+	Configuration *struct {
+		Iterations          []iterationNode `json:"iterations"`
+		CompletedIterations []iterationNode `json:"completedIterations"`
+	} `json:"configuration"`
+}
 
-	args := append([]string{}, baseArgs...)
-	if filter != "" {
-		args = append(args, "--query", filter)
-	}
-	out, err := c.runGh(ctx, args...)
-	if err != nil {
-		if filter != "" && isUnknownFlagError(err, "--query") {
-			fmt.Fprintln(os.Stderr, "warning: gh project item-list does not support --query; falling back to client-side filtering")
-			out, err = c.runGh(ctx, baseArgs...)
-			if err != nil {
-				return nil, fmt.Errorf("gh project item-list failed: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("gh project item-list failed: %w", err)
+type iterationNode struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+func (n iterationNode) timeline(completed bool) state.Timeline {
+	t := state.Timeline{ID: n.ID, Name: n.Title, Completed: completed}
+	if start, err := time.Parse(state.DateLayout, n.StartDate); err == nil {
+		t.Start = &start
+		if n.Duration > 0 {
+			end := start.AddDate(0, 0, n.Duration)
+			t.End = &end
 		}
 	}
-	items, parseErr := parse.ParseItemList(out)
-	if parseErr != nil {
-		return nil, parseErr
-	}
+	return t
+}
 
-	if hierarchy, err := c.fetchProjectHierarchy(ctx, owner, projectID); err == nil {
-		for i := range items {
-			key := issueKey(items[i].Repository, items[i].Number)
-			if key == "" {
-				continue
-			}
-			info, ok := hierarchy[key]
-			if !ok {
-				continue
-			}
-			if items[i].SubIssueProgress == "" && info.SubIssueTotal > 0 {
-				items[i].SubIssueProgress = fmt.Sprintf("%d", info.SubIssueTotal)
-			}
-			if len(items[i].SubIssueTitles) == 0 && len(info.SubIssueTitles) > 0 {
-				items[i].SubIssueTitles = append([]string(nil), info.SubIssueTitles...)
+// setProjectFields replaces the project's fields with nodes and collects the
+// iterations of its iteration field, completed ones first.
+func setProjectFields(proj *state.Project, nodes []projectFieldNode) {
+	proj.Fields = nil
+	proj.Iterations = nil
+	for _, rf := range nodes {
+		if rf.ID == "" {
+			continue
+		}
+		typeName := rf.TypeName
+		if typeName == "" {
+			typeName = rf.Type
+		}
+		field := state.Field{ID: rf.ID, Name: rf.Name, Type: fieldType(typeName, rf.DataType)}
+		for _, ro := range rf.Options {
+			field.Options = append(field.Options, state.Option{ID: ro.ID, Name: ro.Name})
+		}
+		proj.Fields = append(proj.Fields, field)
+
+		if field.Type == state.FieldTypeIteration && rf.Configuration != nil && len(proj.Iterations) == 0 {
+			for _, it := range rf.Configuration.CompletedIterations {
+				proj.Iterations = append(proj.Iterations, it.timeline(true))
 			}
-			if items[i].ParentIssue == "" {
-				if info.ParentTitle != "" {
-					items[i].ParentIssue = info.ParentTitle
-				} else if info.ParentNumber > 0 {
-					items[i].ParentIssue = fmt.Sprintf("#%d", info.ParentNumber)
-				}
+			for _, it := range rf.Configuration.Iterations {
+				proj.Iterations = append(proj.Iterations, it.timeline(false))
 			}
 		}
 	}
-	return items, nil
 }
 
-func isUnknownFlagError(err error, flag string) bool {
-	if err == nil || flag == "" {
-		return false
+// lacksFieldDetails reports whether some field's type is unknown, or the
+// project has an iteration field but no iterations were read.
+func lacksFieldDetails(proj state.Project) bool {
+	if len(proj.Fields) == 0 {
+		return true
 	}
-	message := err.Error()
-	if !strings.Contains(message, "unknown flag") {
-		return false
+	for _, field := range proj.Fields {
+		if field.Type == "" {
+			return true
+		}
+		if field.Type == state.FieldTypeIteration && len(proj.Iterations) == 0 {
+			return true
+		}
 	}
-	return strings.Contains(message, flag)
+	return false
 }
 
-type projectHierarchy struct {
-	SubIssueTotal  int
-	SubIssueTitles []string
-	ParentTitle    string
-	ParentNumber   int
-}
-
-func issueKey(repo string, number int) string {
-	if number <= 0 {
-		return ""
+// fieldType maps a field's reported data type, or failing that its GraphQL
+// type name, to a state.FieldType. Plain ProjectV2Field entries without a
+// data type are left untyped.
+func fieldType(typeName string, dataType string) state.FieldType {
+	if dataType = strings.ToUpper(strings.TrimSpace(dataType)); dataType != "" {
+		return state.FieldType(dataType)
 	}
-	cleanRepo := normalizeRepo(repo)
-	if cleanRepo == "" {
-		return ""
-	}
-	return fmt.Sprintf("%s#%d", strings.ToLower(cleanRepo), number)
-}
-
-func normalizeRepo(repo string) string {
-	trimmed := strings.TrimSpace(repo)
-	if trimmed == "" {
-		return ""
+	switch typeName {
+	case "ProjectV2SingleSelectField":
+		return state.FieldTypeSingleSelect
+	case "ProjectV2IterationField":
+		return state.FieldTypeIteration
 	}
-	trimmed = strings.TrimPrefix(trimmed, "https://github.com/")
-	trimmed = strings.TrimPrefix(trimmed, "http://github.com/")
-	trimmed = strings.TrimPrefix(trimmed, "github.com/")
-	return strings.Trim(trimmed, "/")
+	return ""
 }
 
-type projectHierarchyResponse struct {
-	Data struct {
-		User         *projectHierarchyNode `json:"user"`
-		Organization *projectHierarchyNode `json:"organization"`
-	} `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-		Type    string `json:"type"`
-	} `json:"errors"`
-}
-
-type projectHierarchyNode struct {
-	ProjectV2 *struct {
-		Items struct {
-			Nodes []struct {
-				Content *struct {
-					Number     int `json:"number"`
-					Repository struct {
-						NameWithOwner string `json:"nameWithOwner"`
-					} `json:"repository"`
-					SubIssues struct {
-						TotalCount int `json:"totalCount"`
-						Nodes      []struct {
-							Title string `json:"title"`
-						} `json:"nodes"`
-					} `json:"subIssues"`
-					Parent *struct {
-						Title  string `json:"title"`
-						Number int    `json:"number"`
-					} `json:"parent"`
-				} `json:"content"`
-			} `json:"nodes"`
-			PageInfo struct {
-				HasNextPage bool   `json:"hasNextPage"`
-				EndCursor   string `json:"endCursor"`
-			} `json:"pageInfo"`
-		} `json:"items"`
-	} `json:"projectV2"`
+// FetchItems pages through the project's items with gh api graphql, following
+// pageInfo.endCursor until limit items are collected.
+func (c *CLIClient) FetchItems(ctx context.Context, projectID string, owner string, filter string, limit int) ([]state.Item, error) {
+	return fetchItems(ctx, c.graphql, &c.itemQuery, projectID, owner, filter, limit)
 }
 
-func (c *CLIClient) fetchProjectHierarchy(ctx context.Context, owner, projectID string) (map[string]projectHierarchy, error) {
-	projectNumber, err := strconv.Atoi(strings.TrimSpace(projectID))
-	if err != nil || projectNumber <= 0 {
-		return nil, fmt.Errorf("project number required for hierarchy fetch")
-	}
-
-	query := `query($owner:String!,$number:Int!,$after:String){user(login:$owner){projectV2(number:$number){items(first:100, after:$after){nodes{content{... on Issue{number repository{nameWithOwner} subIssues(first:20){totalCount nodes{title}} parent{title number}}}} pageInfo{hasNextPage endCursor}}}}}`
-	res, err := c.fetchProjectHierarchyForOwner(ctx, "user", owner, projectNumber, query)
-	if err == nil {
-		return res, nil
-	}
-
-	query = `query($owner:String!,$number:Int!,$after:String){organization(login:$owner){projectV2(number:$number){items(first:100, after:$after){nodes{content{... on Issue{number repository{nameWithOwner} subIssues(first:20){totalCount nodes{title}} parent{title number}}}} pageInfo{hasNextPage endCursor}}}}}`
-	return c.fetchProjectHierarchyForOwner(ctx, "organization", owner, projectNumber, query)
+func (c *CLIClient) FetchItemsPage(ctx context.Context, projectID string, owner string, filter string, cursor string, pageSize int) (ItemsPage, error) {
+	return fetchItemsPage(ctx, c.graphql, &c.itemQuery, projectID, owner, filter, cursor, pageSize)
 }
 
-func (c *CLIClient) fetchProjectHierarchyForOwner(ctx context.Context, ownerType, owner string, projectNumber int, query string) (map[string]projectHierarchy, error) {
-	var out map[string]projectHierarchy
-	var after string
-	for {
-		args := []string{"api", "graphql", "--field", fmt.Sprintf("query=%s", query), "-F", fmt.Sprintf("owner=%s", owner), "-F", fmt.Sprintf("number=%d", projectNumber)}
-		if after != "" {
-			args = append(args, "-F", fmt.Sprintf("after=%s", after))
-		}
-		respBytes, err := c.runGh(ctx, args...)
-		if err != nil {
-			return nil, err
-		}
-		var resp projectHierarchyResponse
-		if err := json.Unmarshal(respBytes, &resp); err != nil {
-			return nil, err
-		}
-		if len(resp.Errors) > 0 {
-			return nil, fmt.Errorf("hierarchy query error: %s", resp.Errors[0].Message)
-		}
-
-		var node *projectHierarchyNode
-		switch ownerType {
-		case "user":
-			node = resp.Data.User
-		case "organization":
-			node = resp.Data.Organization
-		}
-		if node == nil || node.ProjectV2 == nil {
-			return nil, fmt.Errorf("hierarchy data not found for %s", ownerType)
-		}
-		if out == nil {
-			out = make(map[string]projectHierarchy)
-		}
-		for _, item := range node.ProjectV2.Items.Nodes {
-			if item.Content == nil {
-				continue
-			}
-			key := issueKey(item.Content.Repository.NameWithOwner, item.Content.Number)
-			if key == "" {
-				continue
-			}
-			parentTitle := ""
-			parentNumber := 0
-			if item.Content.Parent != nil {
-				parentTitle = item.Content.Parent.Title
-				parentNumber = item.Content.Parent.Number
-			}
-			out[key] = projectHierarchy{
-				SubIssueTotal:  item.Content.SubIssues.TotalCount,
-				SubIssueTitles: collectSubIssueTitles(item.Content.SubIssues.Nodes),
-				ParentTitle:    parentTitle,
-				ParentNumber:   parentNumber,
-			}
-		}
-		if !node.ProjectV2.Items.PageInfo.HasNextPage {
-			break
-		}
-		after = node.ProjectV2.Items.PageInfo.EndCursor
-	}
-	return out, nil
-}
-
-func collectSubIssueTitles(nodes []struct {
-	Title string `json:"title"`
-}) []string {
-	if len(nodes) == 0 {
-		return nil
-	}
-	titles := make([]string, 0, len(nodes))
-	for _, node := range nodes {
-		title := strings.TrimSpace(node.Title)
-		if title == "" {
-			continue
-		}
-		titles = append(titles, title)
+// graphql runs a GraphQL document through gh api graphql, passing the request body on stdin.
+func (c *CLIClient) graphql(ctx context.Context, query string, variables map[string]any, out any) error {
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
 	}
-	if len(titles) == 0 {
-		return nil
+	respBytes, err := c.runGhWithInput(ctx, string(payload), "api", "graphql", "--input", "-")
+	if err != nil {
+		return fmt.Errorf("gh api graphql failed: %w", err)
 	}
-	return titles
+	return decodeGraphQLResponse(respBytes, out)
 }
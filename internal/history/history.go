@@ -0,0 +1,75 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"project-hub/internal/cache"
+	"project-hub/internal/config"
+	"project-hub/internal/state"
+)
+
+// DirName is the directory under the config directory that holds refresh history.
+const DirName = "history"
+
+// ResolvePath returns the history file for a project/owner pair.
+// History lives next to the config file in ~/.config/project-hub/history/.
+func ResolvePath(projectID, owner string) (string, error) {
+	configPath, err := config.ResolvePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), DirName, cache.Key(projectID, owner)+".json"), nil
+}
+
+// Load reads the recorded samples, oldest first.
+// Returns nil (not an error) if the file doesn't exist.
+func Load(path string) ([]state.StatusSample, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var samples []state.StatusSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, fmt.Errorf("failed to parse history JSON: %w", err)
+	}
+	return samples, nil
+}
+
+// Save writes the samples to disk, replacing the previous history atomically.
+func Save(path string, samples []state.StatusSample) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history to JSON: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace history file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"project-hub/internal/state"
+)
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "acme-5.json")
+	saved := []state.StatusSample{
+		{At: time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC), Items: []state.SampleItem{{ID: "PVTI_1", Status: "Todo", IterationID: "it-1", Estimate: 3}}},
+		{At: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), Items: []state.SampleItem{{ID: "PVTI_1", Status: "Done", IterationID: "it-1", Estimate: 3}}},
+	}
+
+	if err := Save(path, saved); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].Items[0] != saved[1].Items[0] || !loaded[1].At.Equal(saved[1].At) {
+		t.Fatalf("unexpected samples: %+v", loaded)
+	}
+}
+
+func TestLoadMissingFileIsEmpty(t *testing.T) {
+	samples, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || samples != nil {
+		t.Fatalf("expected no samples and no error, got %v, %v", samples, err)
+	}
+}
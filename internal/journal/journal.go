@@ -0,0 +1,135 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"project-hub/internal/cache"
+	"project-hub/internal/config"
+	"project-hub/internal/state"
+)
+
+// DirName is the directory under the config directory that holds mutation journals.
+const DirName = "journal"
+
+// mu serializes journal I/O so a read never sees a rewrite half done and
+// versions stays consistent.
+var mu sync.Mutex
+
+// versions holds the latest version SaveVersion wrote, by path.
+var versions = make(map[string]uint64)
+
+// ResolvePath returns the journal file for a project/owner pair.
+// Journals live next to the config file in ~/.config/project-hub/journal/.
+func ResolvePath(projectID, owner string) (string, error) {
+	configPath, err := config.ResolvePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), DirName, cache.Key(projectID, owner)+".jsonl"), nil
+}
+
+// Load reads queued mutations in the order they were recorded.
+// Returns nil (not an error) if the file doesn't exist. A truncated final
+// line, as left by a crash mid-write, is ignored, and a mutation recorded
+// more than once is only returned the first time.
+func Load(path string) ([]state.Mutation, error) {
+	mu.Lock()
+	data, err := os.ReadFile(path)
+	mu.Unlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read journal file: %w", err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	var mutations []state.Mutation
+	seen := make(map[string]bool)
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var m state.Mutation
+		if err := json.Unmarshal(line, &m); err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse journal entry: %w", err)
+		}
+		if m.ID != "" && seen[m.ID] {
+			continue
+		}
+		seen[m.ID] = true
+		mutations = append(mutations, m)
+	}
+	return mutations, nil
+}
+
+// SaveVersion replaces the journal with mutations, removing the file when
+// none remain. Snapshots are written from concurrent goroutines: version
+// must grow with each snapshot taken of path, and a snapshot older than the
+// one already written is dropped instead of replacing it.
+func SaveVersion(path string, version uint64, mutations []state.Mutation) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if version <= versions[path] {
+		return nil
+	}
+	versions[path] = version
+	return save(path, mutations)
+}
+
+func save(path string, mutations []state.Mutation) error {
+	if len(mutations) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove journal file: %w", err)
+		}
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, m := range mutations {
+		line, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("failed to marshal journal entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create journal file: %w", err)
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write journal file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to sync journal file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write journal file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace journal file: %w", err)
+	}
+	return nil
+}
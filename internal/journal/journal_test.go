@@ -0,0 +1,101 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"project-hub/internal/state"
+)
+
+func TestResolvePathIsPerProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := ResolvePath("5", "acme")
+	if err != nil {
+		t.Fatalf("ResolvePath() error: %v", err)
+	}
+	if filepath.Base(filepath.Dir(path)) != DirName || !strings.HasSuffix(path, "acme-5.jsonl") {
+		t.Fatalf("unexpected journal path %q", path)
+	}
+}
+
+func TestSaveVersionAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal", "acme-5.jsonl")
+
+	first := state.Mutation{ID: "1", Kind: state.MutationStatus, OptionName: "Done", Before: state.Item{ID: "PVTI_1", Status: "Todo"}}
+	second := state.Mutation{ID: "2", Kind: state.MutationLabels, Values: []string{"bug"}, Before: state.Item{ID: "PVTI_2"}}
+	if err := SaveVersion(path, 1, []state.Mutation{first, second}); err != nil {
+		t.Fatalf("SaveVersion() error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].ID != "1" || loaded[1].Values[0] != "bug" || loaded[0].Before.Status != "Todo" {
+		t.Fatalf("unexpected journal contents: %+v", loaded)
+	}
+
+	if err := SaveVersion(path, 2, loaded[1:]); err != nil {
+		t.Fatalf("SaveVersion() error: %v", err)
+	}
+	loaded, err = Load(path)
+	if err != nil || len(loaded) != 1 || loaded[0].ID != "2" {
+		t.Fatalf("expected only second mutation after save, got %+v (err %v)", loaded, err)
+	}
+
+	if err := SaveVersion(path, 3, nil); err != nil {
+		t.Fatalf("SaveVersion() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected empty journal to be removed, got %v", err)
+	}
+}
+
+func TestLoadMissingAndTruncated(t *testing.T) {
+	dir := t.TempDir()
+	loaded, err := Load(filepath.Join(dir, "missing.jsonl"))
+	if err != nil || loaded != nil {
+		t.Fatalf("expected nothing for a missing journal, got %+v (err %v)", loaded, err)
+	}
+
+	path := filepath.Join(dir, "acme-5.jsonl")
+	if err := os.WriteFile(path, []byte(`{"id":"1","kind":"status"}`+"\n"+`{"id":"2","ki`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = Load(path)
+	if err != nil || len(loaded) != 1 || loaded[0].ID != "1" {
+		t.Fatalf("expected truncated tail to be ignored, got %+v (err %v)", loaded, err)
+	}
+}
+
+func TestSaveVersionDropsOlderSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme-5.jsonl")
+	first := state.Mutation{ID: "1", Kind: state.MutationStatus}
+	second := state.Mutation{ID: "2", Kind: state.MutationComment}
+
+	if err := SaveVersion(path, 2, []state.Mutation{first, second}); err != nil {
+		t.Fatalf("SaveVersion() error: %v", err)
+	}
+	if err := SaveVersion(path, 1, []state.Mutation{first}); err != nil {
+		t.Fatalf("SaveVersion() error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("expected the newer snapshot kept, got %+v (err %v)", loaded, err)
+	}
+}
+
+func TestLoadSkipsDuplicateMutations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme-5.jsonl")
+	comment := state.Mutation{ID: "1", Kind: state.MutationComment, Values: []string{"LGTM"}}
+	if err := SaveVersion(path, 1, []state.Mutation{comment, comment}); err != nil {
+		t.Fatalf("SaveVersion() error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil || len(loaded) != 1 {
+		t.Fatalf("expected the comment loaded once, got %+v (err %v)", loaded, err)
+	}
+}
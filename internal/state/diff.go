@@ -0,0 +1,65 @@
+package state
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// ChangeKind describes how an item changed between two refreshes.
+type ChangeKind string
+
+const (
+	ChangeAdded  ChangeKind = "added"
+	ChangeMoved  ChangeKind = "moved" // Status changed
+	ChangeEdited ChangeKind = "edited"
+)
+
+// DiffItems compares a refresh against the items shown before it and returns
+// the kind of change for every item that was added, moved or edited. Removed
+// items are not reported since there is nothing left to highlight.
+func DiffItems(prev, next []Item) map[string]ChangeKind {
+	before := make(map[string]Item, len(prev))
+	for _, item := range prev {
+		before[item.ID] = item
+	}
+	changes := make(map[string]ChangeKind)
+	for _, item := range next {
+		old, ok := before[item.ID]
+		switch {
+		case !ok:
+			changes[item.ID] = ChangeAdded
+		case old.Status != item.Status:
+			changes[item.ID] = ChangeMoved
+		case itemEdited(old, item):
+			changes[item.ID] = ChangeEdited
+		}
+	}
+	return changes
+}
+
+// itemEdited compares the fields shown on cards and in the table. Nil and
+// empty collections are treated as equal so cached snapshots do not show up
+// as edits.
+func itemEdited(a, b Item) bool {
+	if a.Title != b.Title || a.Description != b.Description || a.Milestone != b.Milestone || a.Priority != b.Priority {
+		return true
+	}
+	if a.IterationID != b.IterationID || a.SubIssueProgress != b.SubIssueProgress || a.ParentIssue != b.ParentIssue {
+		return true
+	}
+	if !slices.Equal(a.Assignees, b.Assignees) || !slices.Equal(a.Labels, b.Labels) {
+		return true
+	}
+	if !sameTime(a.Due, b.Due) {
+		return true
+	}
+	return !maps.EqualFunc(a.FieldValues, b.FieldValues, slices.Equal[[]string])
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
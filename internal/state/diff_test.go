@@ -0,0 +1,31 @@
+package state
+
+import "testing"
+
+func TestDiffItems(t *testing.T) {
+	prev := []Item{
+		{ID: "1", Title: "Same", Status: "Todo", Labels: []string{}},
+		{ID: "2", Title: "Moves", Status: "Todo"},
+		{ID: "3", Title: "Renamed", Status: "Todo"},
+		{ID: "4", Title: "Gone", Status: "Todo"},
+		{ID: "5", Title: "Field", Status: "Todo", FieldValues: map[string][]string{"Size": {"S"}}},
+	}
+	next := []Item{
+		{ID: "1", Title: "Same", Status: "Todo"},
+		{ID: "2", Title: "Moves", Status: "Done"},
+		{ID: "3", Title: "Renamed again", Status: "Todo"},
+		{ID: "5", Title: "Field", Status: "Todo", FieldValues: map[string][]string{"Size": {"L"}}},
+		{ID: "6", Title: "New", Status: "Todo"},
+	}
+
+	got := DiffItems(prev, next)
+	want := map[string]ChangeKind{"2": ChangeMoved, "3": ChangeEdited, "5": ChangeEdited, "6": ChangeAdded}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for id, kind := range want {
+		if got[id] != kind {
+			t.Fatalf("item %s: expected %s, got %s", id, kind, got[id])
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DigestChangeKind classifies one line of the "changed since last session" digest.
+type DigestChangeKind string
+
+const (
+	DigestNew       DigestChangeKind = "new"
+	DigestStatus    DigestChangeKind = "status"
+	DigestAssignees DigestChangeKind = "assignees"
+	DigestComment   DigestChangeKind = "comment"
+	DigestClosed    DigestChangeKind = "closed"
+)
+
+// DigestChange is a single change to an item.
+type DigestChange struct {
+	Kind    DigestChangeKind
+	Summary string
+}
+
+// DigestEntry groups the changes to one item.
+type DigestEntry struct {
+	Item    Item
+	Changes []DigestChange
+}
+
+// BuildDigest lists what changed between the items of the previous session
+// (baseline) and the current items. comments holds comments fetched for
+// items; only those created after since are reported. Entries follow the
+// order of current.
+func BuildDigest(baseline, current []Item, since time.Time, comments map[string][]Comment) []DigestEntry {
+	before := make(map[string]Item, len(baseline))
+	for _, item := range baseline {
+		before[item.ID] = item
+	}
+
+	var entries []DigestEntry
+	for _, item := range current {
+		var changes []DigestChange
+		old, existed := before[item.ID]
+		if !existed {
+			changes = append(changes, DigestChange{Kind: DigestNew, Summary: "added to the project"})
+		} else {
+			// A baseline without a state predates it being fetched and cannot tell.
+			closed := old.State != "" && !old.Closed() && item.Closed()
+			switch {
+			case closed && old.Status == item.Status:
+				changes = append(changes, DigestChange{Kind: DigestClosed, Summary: strings.ToLower(item.State)})
+			case old.Status != item.Status:
+				kind := DigestStatus
+				if closed {
+					kind = DigestClosed
+				}
+				changes = append(changes, DigestChange{Kind: kind, Summary: fmt.Sprintf("%s → %s", displayStatus(old.Status), displayStatus(item.Status))})
+			}
+			if summary := assigneeChanges(old.Assignees, item.Assignees); summary != "" {
+				changes = append(changes, DigestChange{Kind: DigestAssignees, Summary: summary})
+			}
+		}
+		for _, c := range comments[item.ID] {
+			if c.CreatedAt == nil || !c.CreatedAt.After(since) {
+				continue
+			}
+			changes = append(changes, DigestChange{Kind: DigestComment, Summary: commentSummary(c)})
+		}
+		if len(changes) > 0 {
+			entries = append(entries, DigestEntry{Item: item, Changes: changes})
+		}
+	}
+	return entries
+}
+
+// UpdatedSince reports whether GitHub touched item or its issue after t,
+// which is when new comments may exist that the project listing does not
+// include.
+func UpdatedSince(item Item, t time.Time) bool {
+	return item.UpdatedAt != nil && item.UpdatedAt.After(t)
+}
+
+func displayStatus(status string) string {
+	if strings.TrimSpace(status) == "" {
+		return "(none)"
+	}
+	return status
+}
+
+func assigneeChanges(before, after []string) string {
+	var parts []string
+	for _, a := range after {
+		if !slices.Contains(before, a) {
+			parts = append(parts, "+@"+a)
+		}
+	}
+	for _, b := range before {
+		if !slices.Contains(after, b) {
+			parts = append(parts, "-@"+b)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func commentSummary(c Comment) string {
+	body := strings.Join(strings.Fields(c.Body), " ")
+	const maxLen = 60
+	if runes := []rune(body); len(runes) > maxLen {
+		body = string(runes[:maxLen]) + "…"
+	}
+	author := c.Author
+	if author == "" {
+		author = "someone"
+	}
+	return fmt.Sprintf("@%s: %s", author, body)
+}
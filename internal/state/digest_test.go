@@ -0,0 +1,55 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildDigest(t *testing.T) {
+	since := time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC)
+	before := since.Add(-time.Hour)
+	after := since.Add(time.Hour)
+	baseline := []Item{
+		{ID: "1", Title: "Quiet", Status: "Todo"},
+		{ID: "2", Title: "Moved", Status: "Todo"},
+		{ID: "3", Title: "Closed", Status: "In Progress", State: "OPEN"},
+		{ID: "4", Title: "Reassigned", Status: "Todo", Assignees: []string{"bob"}},
+		{ID: "6", Title: "Merged", Status: "Review", State: "OPEN"},
+		{ID: "7", Title: "Cached before states", Status: "Review"},
+	}
+	current := []Item{
+		{ID: "1", Title: "Quiet", Status: "Todo"},
+		{ID: "2", Title: "Moved", Status: "In Progress"},
+		{ID: "3", Title: "Closed", Status: "Shipped", State: "CLOSED"},
+		{ID: "4", Title: "Reassigned", Status: "Todo", Assignees: []string{"alice"}},
+		{ID: "5", Title: "New", Status: "Todo"},
+		{ID: "6", Title: "Merged", Status: "Review", State: "MERGED"},
+		{ID: "7", Title: "Cached before states", Status: "Review", State: "CLOSED"},
+	}
+	comments := map[string][]Comment{
+		"1": {{Author: "carol", Body: "old", CreatedAt: &before}, {Author: "dave", Body: "new\nreply", CreatedAt: &after}},
+	}
+
+	entries := BuildDigest(baseline, current, since, comments)
+	if len(entries) != 6 {
+		t.Fatalf("expected 6 entries, got %+v", entries)
+	}
+	want := []struct {
+		id      string
+		kind    DigestChangeKind
+		summary string
+	}{
+		{"1", DigestComment, "@dave: new reply"},
+		{"2", DigestStatus, "Todo → In Progress"},
+		{"3", DigestClosed, "In Progress → Shipped"},
+		{"4", DigestAssignees, "+@alice -@bob"},
+		{"5", DigestNew, "added to the project"},
+		{"6", DigestClosed, "merged"},
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Item.ID != w.id || len(e.Changes) != 1 || e.Changes[0].Kind != w.kind || e.Changes[0].Summary != w.summary {
+			t.Fatalf("entry %d: expected %s %s %q, got %+v", i, w.id, w.kind, w.summary, e)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format GitHub uses for date field values.
+const DateLayout = "2006-01-02"
+
+// HoldsValue reports whether the field takes a typed value (date, number or
+// text) rather than one of a fixed set of options.
+func (f Field) HoldsValue() bool {
+	switch f.Type {
+	case FieldTypeDate, FieldTypeNumber, FieldTypeText:
+		return true
+	}
+	return false
+}
+
+// FindField returns the first field of type t. Fields whose type was not
+// reported are matched by name instead.
+func FindField(fields []Field, t FieldType, name string) (Field, bool) {
+	for _, field := range fields {
+		if field.Type == t {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if field.Type == "" && strings.EqualFold(strings.TrimSpace(field.Name), name) {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// NormalizeFieldValue checks raw against the field type and returns it in the
+// form GitHub expects. An empty value means the field is cleared.
+func NormalizeFieldValue(t FieldType, raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", nil
+	}
+	switch t {
+	case FieldTypeDate:
+		d, err := time.Parse(DateLayout, value)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q: use YYYY-MM-DD", value)
+		}
+		return d.Format(DateLayout), nil
+	case FieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", fmt.Errorf("invalid number %q", value)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case FieldTypeText:
+		return value, nil
+	default:
+		return "", fmt.Errorf("field type %q does not take a typed value", t)
+	}
+}
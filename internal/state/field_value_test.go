@@ -0,0 +1,28 @@
+package state
+
+import "testing"
+
+func TestNormalizeFieldValue(t *testing.T) {
+	tests := []struct {
+		typ     FieldType
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{typ: FieldTypeDate, raw: " 2026-11-02 ", want: "2026-11-02"},
+		{typ: FieldTypeDate, raw: "11/02/2026", wantErr: true},
+		{typ: FieldTypeNumber, raw: "2.50", want: "2.5"},
+		{typ: FieldTypeNumber, raw: "-3", want: "-3"},
+		{typ: FieldTypeNumber, raw: "three", wantErr: true},
+		{typ: FieldTypeNumber, raw: "NaN", wantErr: true},
+		{typ: FieldTypeText, raw: "Needs design review", want: "Needs design review"},
+		{typ: FieldTypeDate, raw: "", want: ""},
+		{typ: FieldTypeSingleSelect, raw: "Done", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeFieldValue(tt.typ, tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeFieldValue(%s, %q) = %q, %v; want %q, error %v", tt.typ, tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -5,71 +5,65 @@ import (
 	"time"
 )
 
+// ParseFilter parses a filter query. Besides the prefix tokens it accepts
+// -term negation, OR between terms, parenthesised groups, no:field and
+// has:field, and comparisons such as updated:>2026-09-01, created:<7d or
+// Estimate:>=3. Err reports a query that cannot be parsed.
+//
+// The top-level terms that must all match are also copied to Labels,
+// Assignees, Statuses, Iterations, FieldFilters and Query.
 func ParseFilter(query string) FilterState {
 	trimmed := strings.TrimSpace(query)
-	fs := FilterState{Raw: trimmed, Query: trimmed}
+	fs := FilterState{Raw: trimmed}
 	if trimmed == "" {
 		return fs
 	}
-	var queryTokens []string
-	tokens := tokenizeFilterQuery(trimmed)
-	for _, t := range tokens {
-		if strings.HasPrefix(t, "label:") {
-			fs.Labels = append(fs.Labels, splitFilterValues(strings.TrimPrefix(t, "label:"))...)
-			continue
-		}
-		if strings.HasPrefix(t, "labels:") {
-			fs.Labels = append(fs.Labels, splitFilterValues(strings.TrimPrefix(t, "labels:"))...)
-			continue
-		}
-		if strings.HasPrefix(t, "assignee:") {
-			fs.Assignees = append(fs.Assignees, splitFilterValues(strings.TrimPrefix(t, "assignee:"))...)
-			continue
-		}
-		if strings.HasPrefix(t, "assignees:") {
-			fs.Assignees = append(fs.Assignees, splitFilterValues(strings.TrimPrefix(t, "assignees:"))...)
-			continue
-		}
-		if strings.HasPrefix(t, "status:") {
-			fs.Statuses = append(fs.Statuses, splitFilterValues(strings.TrimPrefix(t, "status:"))...)
-			continue
-		}
-		if strings.HasPrefix(t, "iteration:") {
-			fs.Iterations = append(fs.Iterations, splitFilterValues(strings.TrimPrefix(t, "iteration:"))...)
-			continue
-		}
-		if strings.HasPrefix(t, "group:") {
-			fs.GroupBy = strings.TrimSpace(strings.TrimPrefix(t, "group:"))
-			continue
-		}
-		if strings.HasPrefix(t, "group-by:") {
-			fs.GroupBy = strings.TrimSpace(strings.TrimPrefix(t, "group-by:"))
-			continue
-		}
-		if strings.HasPrefix(t, "groupby:") {
-			fs.GroupBy = strings.TrimSpace(strings.TrimPrefix(t, "groupby:"))
-			continue
-		}
-		if fieldName, fieldValue, ok := splitFieldToken(t); ok {
-			fs.FieldFilters = addFieldFilter(fs.FieldFilters, fieldName, splitFilterValues(fieldValue))
-			continue
-		}
-		if strings.HasPrefix(t, "@") {
-			fs.Iterations = append(fs.Iterations, t)
-			continue
-		}
-		lower := strings.ToLower(t)
-		if lower == "current" || lower == "next" || lower == "previous" {
-			fs.Iterations = append(fs.Iterations, t)
-			continue
+	expr, err := parseFilterExpr(trimmed, &fs)
+	if err != nil {
+		return FilterState{Raw: trimmed, Err: err}
+	}
+	fs.Expr = expr
+
+	conjuncts := []FilterExpr{expr}
+	if and, ok := expr.(FilterAnd); ok {
+		conjuncts = and
+	}
+	for _, e := range conjuncts {
+		t, ok := e.(FilterTerm)
+		if !ok || t.Op != "" {
+			continue
+		}
+		switch t.Key {
+		case "":
+			fs.Query = t.Values[0]
+		case "label":
+			fs.Labels = append(fs.Labels, t.Values...)
+		case "assignee":
+			fs.Assignees = append(fs.Assignees, t.Values...)
+		case "status":
+			fs.Statuses = append(fs.Statuses, t.Values...)
+		case "iteration":
+			fs.Iterations = append(fs.Iterations, t.Values...)
+		default:
+			fs.FieldFilters = addFieldFilter(fs.FieldFilters, t.Key, t.Values)
 		}
-		queryTokens = append(queryTokens, trimQuotes(t))
 	}
-	fs.Query = strings.Join(queryTokens, " ")
 	return fs
 }
 
+// ApplyFilter returns the items fs matches. A parsed query is evaluated as
+// an expression, together with Iterations, which settings and flags may set
+// on their own; a FilterState built by hand matches on its fields.
 func ApplyFilter(items []Item, fields []Field, fs FilterState, now time.Time) []Item {
+	if fs.Expr != nil {
+		var out []Item
+		for _, it := range items {
+			if fs.Expr.Match(it, fields, now) && MatchesIterationFilters(it, fs.Iterations, now) {
+				out = append(out, it)
+			}
+		}
+		return out
+	}
 	if fs.Query == "" && len(fs.Labels) == 0 && len(fs.Assignees) == 0 && len(fs.Statuses) == 0 && len(fs.Iterations) == 0 && len(fs.FieldFilters) == 0 {
 		return items
 	}
@@ -186,65 +180,6 @@ func containsAny(haystack []string, needles []string) bool {
 	return false
 }
 
-func splitFieldToken(token string) (string, string, bool) {
-	idx := strings.IndexRune(token, ':')
-	if idx <= 0 || idx >= len(token)-1 {
-		return "", "", false
-	}
-	fieldName := strings.TrimSpace(token[:idx])
-	if fieldName == "" {
-		return "", "", false
-	}
-	fieldName = trimQuotes(fieldName)
-	if isReservedFilterKey(fieldName) {
-		return "", "", false
-	}
-	value := strings.TrimSpace(token[idx+1:])
-	value = trimQuotes(value)
-	if value == "" {
-		return "", "", false
-	}
-	return fieldName, value, true
-}
-
-func isReservedFilterKey(fieldName string) bool {
-	key := strings.ToLower(strings.TrimSpace(fieldName))
-	switch key {
-	case "label", "labels", "assignee", "assignees", "status", "iteration", "group", "group-by", "groupby":
-		return true
-	default:
-		return false
-	}
-}
-
-func tokenizeFilterQuery(query string) []string {
-	var tokens []string
-	var current strings.Builder
-	inQuote := false
-	for _, r := range query {
-		switch r {
-		case '"':
-			inQuote = !inQuote
-			current.WriteRune(r)
-		case ' ', '\t', '\n', '\r':
-			if inQuote {
-				current.WriteRune(r)
-				continue
-			}
-			if current.Len() > 0 {
-				tokens = append(tokens, current.String())
-				current.Reset()
-			}
-		default:
-			current.WriteRune(r)
-		}
-	}
-	if current.Len() > 0 {
-		tokens = append(tokens, current.String())
-	}
-	return tokens
-}
-
 func splitFilterValues(value string) []string {
 	parts := strings.FieldsFunc(value, func(r rune) bool {
 		switch r {
@@ -0,0 +1,643 @@
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterExpr is a node of a parsed filter query: a FilterTerm, or a
+// FilterAnd, FilterOr or FilterNot combining other nodes.
+type FilterExpr interface {
+	Match(item Item, fields []Field, now time.Time) bool
+	String() string // The node written back as query syntax
+}
+
+// FilterAnd matches items that every operand matches.
+type FilterAnd []FilterExpr
+
+// FilterOr matches items that any operand matches.
+type FilterOr []FilterExpr
+
+// FilterNot matches items that Expr does not match.
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+// FilterTerm is a single condition such as label:bug, no:assignee or
+// Estimate:>=3.
+type FilterTerm struct {
+	Key    string   // Qualifier such as "label" or a field name; "" for free text
+	Op     string   // "" for a value match, "no", "has", or a comparison: ">", ">=", "<", "<="
+	Values []string // Any of them matches; free text is a single phrase
+}
+
+func (a FilterAnd) Match(item Item, fields []Field, now time.Time) bool {
+	for _, e := range a {
+		if !e.Match(item, fields, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o FilterOr) Match(item Item, fields []Field, now time.Time) bool {
+	for _, e := range o {
+		if e.Match(item, fields, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n FilterNot) Match(item Item, fields []Field, now time.Time) bool {
+	if t, ok := n.Expr.(FilterTerm); ok && t.ServerOnly() {
+		return true
+	}
+	return !n.Expr.Match(item, fields, now)
+}
+
+func (t FilterTerm) Match(item Item, fields []Field, now time.Time) bool {
+	if t.ServerOnly() {
+		return true
+	}
+	switch t.Op {
+	case "no", "has":
+		return isEmptyField(item, fields, t.Key) == (t.Op == "no")
+	case ">", ">=", "<", "<=":
+		return matchesComparison(item, fields, t.Key, t.Op, t.Values[0], now)
+	}
+	switch t.Key {
+	case "":
+		return strings.Contains(strings.ToLower(item.Title), strings.ToLower(strings.Join(t.Values, " ")))
+	case "created", "updated":
+		ts := itemTime(item, t.Key)
+		for _, v := range t.Values {
+			if day, err := parseFilterDate(v, now.Location()); err == nil && ts != nil && sameDay(*ts, day) {
+				return true
+			}
+		}
+		return false
+	case "repo":
+		return matchSliceValues([]string{item.Repository}, t.Values)
+	case "is":
+		for _, v := range t.Values {
+			if isItem(item, strings.ToLower(v)) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchesSingleFieldFilter(item, fields, t.Key, t.Values, now)
+}
+
+// githubOnlyKeys are qualifiers of GitHub's search that fetched items do not
+// carry enough data to evaluate.
+var githubOnlyKeys = map[string]bool{"author": true, "reason": true, "parent-issue": true, "sub-issues-progress": true}
+
+// ServerOnly reports whether only GitHub can evaluate t, as with reason:,
+// assignee:@me or an is: value other than open, closed, merged, draft, issue
+// and pr. Such terms match every item here; GitHub narrows the fetch with
+// them.
+func (t FilterTerm) ServerOnly() bool {
+	if githubOnlyKeys[strings.ToLower(t.Key)] {
+		return true
+	}
+	for _, v := range t.Values {
+		if strings.EqualFold(v, "@me") {
+			return true
+		}
+		if t.Key == "is" && t.Op == "" && !isValue(strings.ToLower(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isValue reports whether an is: value can be evaluated here.
+func isValue(v string) bool {
+	switch v {
+	case "open", "closed", "merged", "draft", "issue", "pr":
+		return true
+	}
+	return false
+}
+
+// isItem matches an is: value against the item's state and content type.
+func isItem(item Item, v string) bool {
+	switch v {
+	case "open":
+		return !item.Closed()
+	case "closed":
+		return item.Closed()
+	case "merged":
+		return item.State == "MERGED"
+	case "draft":
+		return item.Type == "DraftIssue"
+	case "issue":
+		return item.Type == "Issue"
+	case "pr":
+		return item.Type == "PullRequest"
+	}
+	return false
+}
+
+func (a FilterAnd) String() string {
+	parts := make([]string, len(a))
+	for i, e := range a {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func (o FilterOr) String() string {
+	parts := make([]string, len(o))
+	for i, e := range o {
+		parts[i] = e.String()
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+func (n FilterNot) String() string {
+	if _, ok := n.Expr.(FilterAnd); ok {
+		return "-(" + n.Expr.String() + ")"
+	}
+	return "-" + n.Expr.String()
+}
+
+func (t FilterTerm) String() string {
+	switch t.Op {
+	case "no", "has":
+		return t.Op + ":" + QuoteFilterValue(t.Key)
+	case "":
+		values := make([]string, len(t.Values))
+		for i, v := range t.Values {
+			values[i] = QuoteFilterValue(v)
+		}
+		if t.Key == "" {
+			return strings.Join(values, " ")
+		}
+		return QuoteFilterValue(t.Key) + ":" + strings.Join(values, ",")
+	}
+	return QuoteFilterValue(t.Key) + ":" + t.Op + QuoteFilterValue(t.Values[0])
+}
+
+// QuoteFilterValue quotes a qualifier or value that contains spaces.
+func QuoteFilterValue(v string) string {
+	if strings.ContainsAny(v, " \t") {
+		return `"` + v + `"`
+	}
+	return v
+}
+
+// parseFilterExpr parses a filter query into an expression. Terms written
+// next to each other must all match; OR between them, which binds less
+// tightly, lets either match. Repeating a qualifier in the same group
+// matches any of its values, as a comma-separated list does. group: tokens
+// set fs.GroupBy and match everything.
+func parseFilterExpr(query string, fs *FilterState) (FilterExpr, error) {
+	tokens, err := lexFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens, fs: fs}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		if tok == ")" {
+			return nil, fmt.Errorf("unexpected )")
+		}
+		return nil, fmt.Errorf("unexpected %s", tok)
+	}
+	return expr, nil
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+	fs     *FilterState
+}
+
+func (p *filterParser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	var operands []FilterExpr
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if operand != nil {
+			operands = append(operands, operand)
+		}
+		if tok, ok := p.peek(); !ok || tok != "OR" {
+			break
+		}
+		if operand == nil {
+			return nil, fmt.Errorf("OR needs a term on each side")
+		}
+		p.pos++
+		if tok, ok := p.peek(); !ok || tok == ")" || tok == "OR" {
+			return nil, fmt.Errorf("OR needs a term on each side")
+		}
+	}
+	switch len(operands) {
+	case 0:
+		return nil, nil
+	case 1:
+		return operands[0], nil
+	}
+	return FilterOr(operands), nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	var operands []FilterExpr
+	for {
+		tok, ok := p.peek()
+		if !ok || tok == ")" || tok == "OR" {
+			break
+		}
+		if tok == "AND" {
+			p.pos++
+			if next, ok := p.peek(); len(operands) == 0 || !ok || next == ")" || next == "OR" || next == "AND" {
+				return nil, fmt.Errorf("AND needs a term on each side")
+			}
+			continue
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if operand != nil {
+			operands = append(operands, operand)
+		}
+	}
+	operands = mergeTerms(operands)
+	switch len(operands) {
+	case 0:
+		return nil, nil
+	case 1:
+		return operands[0], nil
+	}
+	return FilterAnd(operands), nil
+}
+
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	tok, _ := p.peek()
+	p.pos++
+	switch tok {
+	case "-", "NOT":
+		if next, ok := p.peek(); !ok || next == ")" || next == "OR" || next == "AND" {
+			return nil, fmt.Errorf("nothing to negate after %s", tok)
+		}
+		operand, err := p.parseUnary()
+		if err != nil || operand == nil {
+			return nil, err
+		}
+		return FilterNot{Expr: operand}, nil
+	case "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		if expr == nil {
+			return nil, fmt.Errorf("empty ()")
+		}
+		return expr, nil
+	}
+	if strings.HasPrefix(tok, "-") {
+		operand, err := parseFilterTerm(tok[1:], p.fs)
+		if err != nil || operand == nil {
+			return nil, err
+		}
+		return FilterNot{Expr: operand}, nil
+	}
+	return parseFilterTerm(tok, p.fs)
+}
+
+// filterComparison matches the operator at the start of a comparison value.
+var filterComparison = regexp.MustCompile(`^(>=|<=|>|<)(.*)$`)
+
+// parseFilterTerm parses one word of a query. It returns nil for words that
+// do not filter, such as group: or a qualifier with no value yet.
+func parseFilterTerm(word string, fs *FilterState) (FilterExpr, error) {
+	lower := strings.ToLower(word)
+	if strings.HasPrefix(word, "@") || lower == "current" || lower == "next" || lower == "previous" {
+		return FilterTerm{Key: "iteration", Values: []string{word}}, nil
+	}
+	idx := colonOutsideQuotes(word)
+	if idx <= 0 {
+		return FilterTerm{Values: []string{trimQuotes(word)}}, nil
+	}
+	key := trimQuotes(word[:idx])
+	value := strings.TrimSpace(word[idx+1:])
+	switch strings.ToLower(key) {
+	case "group", "group-by", "groupby":
+		fs.GroupBy = strings.TrimSpace(value)
+		return nil, nil
+	case "no", "has":
+		field := trimQuotes(value)
+		if field == "" {
+			return nil, nil
+		}
+		return FilterTerm{Key: canonicalFilterKey(field), Op: strings.ToLower(key)}, nil
+	}
+	key = canonicalFilterKey(key)
+	if m := filterComparison.FindStringSubmatch(value); m != nil {
+		operand := trimQuotes(m[2])
+		if operand == "" {
+			return nil, fmt.Errorf("%s:%s needs a value", key, m[1])
+		}
+		if err := validComparison(key, operand); err != nil {
+			return nil, err
+		}
+		return FilterTerm{Key: key, Op: m[1], Values: []string{operand}}, nil
+	}
+	values := splitFilterValues(value)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if key == "created" || key == "updated" {
+		for _, v := range values {
+			if _, err := parseFilterDate(v, time.UTC); err != nil {
+				return nil, fmt.Errorf("%s: expects a date like 2006-01-02, got %q", key, v)
+			}
+		}
+	}
+	return FilterTerm{Key: key, Values: values}, nil
+}
+
+// canonicalFilterKey folds the built-in qualifiers and their aliases to one
+// lower-case spelling. Field names are kept as typed.
+func canonicalFilterKey(key string) string {
+	switch lower := strings.ToLower(key); lower {
+	case "label", "labels":
+		return "label"
+	case "assignee", "assignees":
+		return "assignee"
+	case "repo", "repository":
+		return "repo"
+	case "status", "iteration", "milestone", "priority", "title", "created", "updated", "is":
+		return lower
+	}
+	return key
+}
+
+func validComparison(key, operand string) error {
+	if _, err := parseFilterDuration(operand); err == nil {
+		return nil
+	}
+	if _, err := parseFilterDate(operand, time.UTC); err == nil {
+		return nil
+	}
+	if key == "created" || key == "updated" {
+		return fmt.Errorf("%s: expects a date like 2006-01-02 or an age like 7d, got %q", key, operand)
+	}
+	if _, err := strconv.ParseFloat(operand, 64); err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: can only compare numbers, dates and ages, got %q", key, operand)
+}
+
+// mergeTerms folds value matches on the same qualifier into one term that
+// matches any of their values, and joins free-text words into one phrase.
+func mergeTerms(operands []FilterExpr) []FilterExpr {
+	var out []FilterExpr
+	seen := map[string]int{}
+	for _, e := range operands {
+		t, ok := e.(FilterTerm)
+		if !ok || t.Op != "" {
+			out = append(out, e)
+			continue
+		}
+		key := strings.ToLower(t.Key)
+		i, ok := seen[key]
+		if !ok {
+			seen[key] = len(out)
+			out = append(out, t)
+			continue
+		}
+		merged := out[i].(FilterTerm)
+		if t.Key == "" {
+			merged.Values = []string{merged.Values[0] + " " + t.Values[0]}
+		} else {
+			merged.Values = append(append([]string(nil), merged.Values...), t.Values...)
+		}
+		out[i] = merged
+	}
+	return out
+}
+
+// lexFilterQuery splits a query into words, parentheses and a leading "-"
+// that negates a parenthesised group. Quoted text stays in its word.
+func lexFilterQuery(query string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	inQuote := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			current.WriteRune(r)
+		case inQuote:
+			current.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+func colonOutsideQuotes(word string) int {
+	inQuote := false
+	for i, r := range word {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == ':' && !inQuote:
+			return i
+		}
+	}
+	return -1
+}
+
+// isEmptyField reports whether item has no value for the named field.
+func isEmptyField(item Item, fields []Field, key string) bool {
+	switch key {
+	case "":
+		return false
+	case "label":
+		return len(item.Labels) == 0
+	case "assignee":
+		return len(item.Assignees) == 0
+	case "status":
+		return item.Status == ""
+	case "milestone":
+		return item.Milestone == ""
+	case "priority":
+		return item.Priority == ""
+	case "repo":
+		return item.Repository == ""
+	case "iteration":
+		return item.IterationName == "" && item.IterationID == ""
+	case "created", "updated":
+		return itemTime(item, key) == nil
+	}
+	for _, v := range fieldValues(item, key) {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesComparison compares a date, age or number field of item with
+// operand. Ages compare how long ago the date was, so created:<7d matches
+// items created within the last week.
+func matchesComparison(item Item, fields []Field, key, op, operand string, now time.Time) bool {
+	var values []string
+	if key == "created" || key == "updated" {
+		ts := itemTime(item, key)
+		if ts == nil {
+			return false
+		}
+		if age, err := parseFilterDuration(operand); err == nil {
+			return compareOrdered(now.Sub(*ts), age, op)
+		}
+		values = []string{ts.In(now.Location()).Format("2006-01-02")}
+	} else {
+		values = fieldValues(item, key)
+	}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if age, err := parseFilterDuration(operand); err == nil {
+			if day, err := parseFilterDate(v, now.Location()); err == nil && compareOrdered(now.Sub(day), age, op) {
+				return true
+			}
+			continue
+		}
+		if want, err := parseFilterDate(operand, now.Location()); err == nil {
+			if day, err := parseFilterDate(v, now.Location()); err == nil && compareOrdered(day.Unix(), want.Unix(), op) {
+				return true
+			}
+			continue
+		}
+		want, errWant := strconv.ParseFloat(operand, 64)
+		got, errGot := strconv.ParseFloat(v, 64)
+		if errWant == nil && errGot == nil && compareOrdered(got, want, op) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareOrdered[T int64 | float64 | time.Duration](got, want T, op string) bool {
+	switch op {
+	case ">":
+		return got > want
+	case ">=":
+		return got >= want
+	case "<":
+		return got < want
+	case "<=":
+		return got <= want
+	}
+	return false
+}
+
+// fieldValues returns the values of a built-in or custom field of item.
+func fieldValues(item Item, key string) []string {
+	switch key {
+	case "label":
+		return item.Labels
+	case "assignee":
+		return item.Assignees
+	case "status":
+		return []string{item.Status}
+	case "milestone":
+		return []string{item.Milestone}
+	case "priority":
+		return []string{item.Priority}
+	case "title":
+		return []string{item.Title}
+	case "repo":
+		return []string{item.Repository}
+	case "iteration":
+		return []string{item.IterationName}
+	}
+	for name, values := range item.FieldValues {
+		if strings.EqualFold(name, key) {
+			return values
+		}
+	}
+	return nil
+}
+
+func itemTime(item Item, key string) *time.Time {
+	if key == "created" {
+		return item.CreatedAt
+	}
+	return item.UpdatedAt
+}
+
+// parseFilterDate parses a calendar date, with or without a time of day.
+func parseFilterDate(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+var filterDuration = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+// parseFilterDuration parses an age such as 12h, 7d or 2w.
+func parseFilterDuration(value string) (time.Duration, error) {
+	m := filterDuration.FindStringSubmatch(strings.ToLower(value))
+	if m == nil {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+	n, _ := strconv.Atoi(m[1])
+	unit := time.Hour
+	switch m[2] {
+	case "d":
+		unit = 24 * time.Hour
+	case "w":
+		unit = 7 * 24 * time.Hour
+	}
+	return time.Duration(n) * unit, nil
+}
+
+func sameDay(t, day time.Time) bool {
+	t = t.In(day.Location())
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := day.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
@@ -1,6 +1,10 @@
 package state
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestParseFilterIterationShorthand(t *testing.T) {
 	fs := ParseFilter("@current next previous")
@@ -71,3 +75,82 @@ func TestParseFilterFieldTokenWithSpaces(t *testing.T) {
 		t.Fatalf("expected Iteration Name=Q1 Sprint, got %v", values)
 	}
 }
+
+func TestParseFilterExpressions(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+	items := []Item{
+		{ID: "1", Type: "Issue", State: "OPEN", Title: "Fix login", Status: "Todo", Labels: []string{"bug"}, Assignees: []string{"alice"}, CreatedAt: at(2), UpdatedAt: at(1), FieldValues: map[string][]string{"Estimate": {"5"}}},
+		{ID: "2", Type: "DraftIssue", Title: "Write docs", Status: "In Progress", Labels: []string{"docs"}, Milestone: "v1.0", CreatedAt: at(30), UpdatedAt: at(20), FieldValues: map[string][]string{"Estimate": {"2"}}},
+		{ID: "3", Type: "PullRequest", State: "MERGED", Title: "Polish UI", Status: "Done", Labels: []string{"ui", "bug"}, CreatedAt: at(10), UpdatedAt: at(3)},
+	}
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"-label:bug", "2"},
+		{`(status:Todo OR status:"In Progress")`, "1,2"},
+		{"status:Todo OR status:Done label:ui", "1,3"},
+		{"-(status:Todo OR status:Done)", "2"},
+		{"NOT label:docs AND no:milestone", "1,3"},
+		{"no:assignee", "2,3"},
+		{"has:milestone", "2"},
+		{"updated:>2026-10-10", "1,3"},
+		{"updated:2026-10-16", "1"},
+		{"created:<7d", "1"},
+		{"created:>=10d", "2,3"},
+		{"Estimate:>=3", "1"},
+		{"label:docs label:ui", "2,3"},
+		{"fix login", "1"},
+		{"is:open", "1,2"},
+		{"is:closed", "3"},
+		{"is:draft,issue", "1,2"},
+		{"assignee:@me label:bug", "1,3"},
+		{"-reason:completed is:unlocked", "1,2,3"},
+	}
+	for _, tt := range tests {
+		fs := ParseFilter(tt.query)
+		if fs.Err != nil {
+			t.Fatalf("ParseFilter(%q) failed: %v", tt.query, fs.Err)
+		}
+		var got []string
+		for _, it := range ApplyFilter(items, nil, fs, now) {
+			got = append(got, it.ID)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("%q matched %v, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterReportsErrors(t *testing.T) {
+	for _, query := range []string{
+		"(status:Todo OR label:bug",
+		"label:bug)",
+		"OR label:bug",
+		"label:bug OR",
+		`status:"In Progress`,
+		"Estimate:>=many",
+		"updated:>soon",
+		"()",
+		"label:bug -",
+	} {
+		fs := ParseFilter(query)
+		if fs.Err == nil {
+			t.Errorf("expected %q to fail to parse", query)
+		}
+		if fs.Expr != nil || len(ApplyFilter([]Item{{ID: "1"}}, nil, fs, time.Now())) != 1 {
+			t.Errorf("expected %q to filter nothing out", query)
+		}
+	}
+}
+
+func TestParseFilterKeepsTopLevelFields(t *testing.T) {
+	fs := ParseFilter("iteration:@current -label:bug (status:Todo OR status:Done)")
+	if len(fs.Iterations) != 1 || len(fs.Labels) != 0 || len(fs.Statuses) != 0 {
+		t.Fatalf("expected only the top-level iteration copied, got %+v", fs)
+	}
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FuzzyScore reports whether the letters of query appear in candidate in
+// order, ignoring case, and how well they match. Matches at the start of the
+// candidate or of a word, and runs of consecutive letters, score higher;
+// letters skipped before the first match score lower.
+func FuzzyScore(query, candidate string) (int, bool) {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	if len(q) == 0 {
+		return 0, true
+	}
+	c := []rune(strings.ToLower(candidate))
+	score, qi, last := 0, 0, -1
+	for ci := 0; ci < len(c) && qi < len(q); ci++ {
+		if c[ci] != q[qi] {
+			continue
+		}
+		score++
+		switch {
+		case ci == 0:
+			score += 8
+		case !unicode.IsLetter(c[ci-1]) && !unicode.IsDigit(c[ci-1]):
+			score += 4
+		}
+		if last >= 0 && ci == last+1 {
+			score += 5
+		}
+		if last < 0 {
+			score -= ci
+		}
+		last = ci
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	if len(q) == len(c) {
+		score += 10
+	}
+	return score, true
+}
+
+// FuzzyRank returns the candidates that match query, best match first.
+// Candidates that score the same keep their order.
+func FuzzyRank(query string, candidates []string) []string {
+	type match struct {
+		value string
+		score int
+	}
+	var matches []match
+	for _, c := range candidates {
+		if score, ok := FuzzyScore(query, c); ok {
+			matches = append(matches, match{c, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, m.value)
+	}
+	return out
+}
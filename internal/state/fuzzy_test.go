@@ -0,0 +1,25 @@
+package state
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuzzyRank(t *testing.T) {
+	candidates := []string{"documentation", "bug", "good first issue", "backend-bug", "enhancement"}
+	if got := strings.Join(FuzzyRank("bug", candidates), ","); got != "bug,backend-bug" {
+		t.Fatalf("expected the exact match ahead of the word match, got %s", got)
+	}
+	if got := FuzzyRank("gfi", candidates); len(got) != 1 || got[0] != "good first issue" {
+		t.Fatalf("expected word initials to match, got %v", got)
+	}
+	if got := FuzzyRank("DOC", candidates); len(got) != 1 || got[0] != "documentation" {
+		t.Fatalf("expected a case-insensitive match, got %v", got)
+	}
+	if got := FuzzyRank("", candidates); len(got) != len(candidates) {
+		t.Fatalf("expected an empty query to keep every candidate, got %v", got)
+	}
+	if _, ok := FuzzyScore("xyz", "bug"); ok {
+		t.Fatalf("expected no match")
+	}
+}
@@ -0,0 +1,252 @@
+package state
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// MaxHistoryDays bounds how far back refresh samples are kept.
+const MaxHistoryDays = 120
+
+// StatusSample records where each item stood at one refresh. GitHub keeps no
+// history of project items, so the insights charts are built from these.
+type StatusSample struct {
+	At    time.Time    `json:"at"`
+	Items []SampleItem `json:"items"`
+}
+
+// SampleItem is the part of an item the insights charts need.
+type SampleItem struct {
+	ID          string  `json:"id"`
+	Status      string  `json:"status,omitempty"`
+	IterationID string  `json:"iterationId,omitempty"`
+	Estimate    float64 `json:"estimate,omitempty"`
+	Closed      bool    `json:"closed,omitempty"` // The issue or pull request is closed or merged
+}
+
+// NewStatusSample captures items as they are at the given time.
+func NewStatusSample(items []Item, at time.Time) StatusSample {
+	sample := StatusSample{At: at, Items: make([]SampleItem, 0, len(items))}
+	for _, item := range items {
+		sample.Items = append(sample.Items, SampleItem{
+			ID:          item.ID,
+			Status:      item.Status,
+			IterationID: item.IterationID,
+			Estimate:    ItemEstimate(item),
+			Closed:      item.Closed(),
+		})
+	}
+	return sample
+}
+
+// RecordSample adds sample to history. One sample is kept per day, the latest
+// refresh of a day replacing earlier ones, and samples older than
+// MaxHistoryDays are dropped.
+func RecordSample(history []StatusSample, sample StatusSample) []StatusSample {
+	cutoff := sample.At.AddDate(0, 0, -MaxHistoryDays)
+	kept := make([]StatusSample, 0, len(history)+1)
+	for _, s := range history {
+		if s.At.Before(cutoff) || dayKey(s.At) == dayKey(sample.At) {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return append(kept, sample)
+}
+
+// dayKey names the calendar day t falls on in its own location.
+func dayKey(t time.Time) string {
+	return t.Format(DateLayout)
+}
+
+// dailySamples returns the last sample of each day, keyed by dayKey.
+func dailySamples(history []StatusSample) map[string]StatusSample {
+	days := make(map[string]StatusSample, len(history))
+	for _, s := range history {
+		key := dayKey(s.At)
+		if prev, ok := days[key]; !ok || !s.At.Before(prev.At) {
+			days[key] = s
+		}
+	}
+	return days
+}
+
+// Burndown is the work left in the current iteration on each of its days.
+type Burndown struct {
+	Iteration *Timeline   // Nil when no iteration is running
+	Points    bool        // Work is summed from estimates instead of counted
+	Days      []time.Time // Every day of the iteration
+	Remaining []float64   // NaN for days without a sample
+	Ideal     []float64   // Straight line from the first known value to zero
+}
+
+// BuildBurndown charts the unfinished work in the iteration running at now.
+// A day without a refresh repeats the day before it, as nothing was seen to
+// change in between.
+func BuildBurndown(project Project, items []Item, history []StatusSample, now time.Time) Burndown {
+	var b Burndown
+	for _, t := range ProjectIterations(project, items) {
+		if t.Start != nil && t.End != nil && t.Phase(now) == IterationCurrent {
+			b.Iteration = &t
+			break
+		}
+	}
+	if b.Iteration == nil {
+		return b
+	}
+
+	for _, s := range history {
+		for _, item := range s.Items {
+			if item.IterationID == b.Iteration.ID && item.Estimate > 0 {
+				b.Points = true
+			}
+		}
+	}
+
+	days := dailySamples(history)
+	today := dayKey(now)
+	last := math.NaN()
+	for d := *b.Iteration.Start; d.Before(*b.Iteration.End); d = d.AddDate(0, 0, 1) {
+		b.Days = append(b.Days, d)
+		key := dayKey(d)
+		if s, ok := days[key]; ok {
+			last = remainingWork(s, b.Iteration.ID, b.Points)
+		}
+		if key > today {
+			last = math.NaN()
+		}
+		b.Remaining = append(b.Remaining, last)
+	}
+
+	start := math.NaN()
+	for _, v := range b.Remaining {
+		if !math.IsNaN(v) {
+			start = v
+			break
+		}
+	}
+	if math.IsNaN(start) {
+		return b
+	}
+	for i := range b.Days {
+		progress := 1.0
+		if len(b.Days) > 1 {
+			progress = float64(i) / float64(len(b.Days)-1)
+		}
+		b.Ideal = append(b.Ideal, start*(1-progress))
+	}
+	return b
+}
+
+func remainingWork(s StatusSample, iterationID string, points bool) float64 {
+	total := 0.0
+	for _, item := range s.Items {
+		if item.IterationID != iterationID || item.Closed {
+			continue
+		}
+		if points {
+			total += item.Estimate
+		} else {
+			total++
+		}
+	}
+	return total
+}
+
+// CSV returns the burndown as CSV records with a header row.
+func (b Burndown) CSV() [][]string {
+	records := [][]string{{"date", "remaining", "ideal"}}
+	for i, d := range b.Days {
+		ideal := math.NaN()
+		if i < len(b.Ideal) {
+			ideal = b.Ideal[i]
+		}
+		records = append(records, []string{d.Format(DateLayout), csvNumber(b.Remaining[i]), csvNumber(ideal)})
+	}
+	return records
+}
+
+// CumulativeFlow counts items per status on each day the project was sampled.
+type CumulativeFlow struct {
+	Days     []time.Time
+	Statuses []string // Workflow order, as the Status field lists its options
+	Counts   [][]int  // Counts[day][status]
+	Closed   []bool   // Whether every item in a status was closed on the last day
+}
+
+// BuildCumulativeFlow counts the statuses of the last sample of each day.
+// Statuses the Status field does not list follow the listed ones.
+func BuildCumulativeFlow(project Project, history []StatusSample) CumulativeFlow {
+	var c CumulativeFlow
+	index := map[string]int{}
+	addStatus := func(status string) int {
+		if i, ok := index[status]; ok {
+			return i
+		}
+		index[status] = len(c.Statuses)
+		c.Statuses = append(c.Statuses, status)
+		return index[status]
+	}
+	for _, field := range project.Fields {
+		if field.Name == "Status" {
+			for _, opt := range field.Options {
+				addStatus(opt.Name)
+			}
+			break
+		}
+	}
+
+	days := dailySamples(history)
+	for _, s := range history {
+		if !days[dayKey(s.At)].At.Equal(s.At) {
+			continue
+		}
+		c.Days = append(c.Days, s.At)
+		counts := make([]int, len(c.Statuses))
+		for _, item := range s.Items {
+			i := addStatus(displayStatus(item.Status))
+			for len(counts) <= i {
+				counts = append(counts, 0)
+			}
+			counts[i]++
+		}
+		c.Counts = append(c.Counts, counts)
+	}
+	for i := range c.Counts {
+		for len(c.Counts[i]) < len(c.Statuses) {
+			c.Counts[i] = append(c.Counts[i], 0)
+		}
+	}
+
+	c.Closed = make([]bool, len(c.Statuses))
+	if len(c.Days) > 0 {
+		open := make([]bool, len(c.Statuses))
+		for _, item := range days[dayKey(c.Days[len(c.Days)-1])].Items {
+			i := index[displayStatus(item.Status)]
+			c.Closed[i] = !open[i] && item.Closed
+			open[i] = open[i] || !item.Closed
+		}
+	}
+	return c
+}
+
+// CSV returns the cumulative flow as CSV records with a header row.
+func (c CumulativeFlow) CSV() [][]string {
+	records := [][]string{append([]string{"date"}, c.Statuses...)}
+	for i, d := range c.Days {
+		row := []string{d.Format(DateLayout)}
+		for _, n := range c.Counts[i] {
+			row = append(row, strconv.Itoa(n))
+		}
+		records = append(records, row)
+	}
+	return records
+}
+
+func csvNumber(v float64) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
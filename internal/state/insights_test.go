@@ -0,0 +1,91 @@
+package state
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRecordSampleKeepsOnePerDay(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	var history []StatusSample
+	history = RecordSample(history, StatusSample{At: at(15, 9)})
+	history = RecordSample(history, StatusSample{At: at(16, 9)})
+	history = RecordSample(history, StatusSample{At: at(16, 17)})
+	if len(history) != 2 || !history[1].At.Equal(at(16, 17)) {
+		t.Fatalf("expected the later refresh to replace the same day, got %+v", history)
+	}
+
+	history = RecordSample(history, StatusSample{At: at(16, 17).AddDate(0, 0, MaxHistoryDays+1)})
+	if len(history) != 1 {
+		t.Fatalf("expected old samples to be dropped, got %d", len(history))
+	}
+}
+
+func TestBuildBurndown(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	start, end := day(12), day(17)
+	project := Project{Iterations: []Timeline{{ID: "it-1", Name: "Sprint 1", Start: &start, End: &end}}}
+	sample := func(d int, statuses ...string) StatusSample {
+		s := StatusSample{At: day(d).Add(10 * time.Hour)}
+		for _, status := range statuses {
+			s.Items = append(s.Items, SampleItem{ID: status, Status: status, IterationID: "it-1", Estimate: 2, Closed: status == "Shipped"})
+		}
+		s.Items = append(s.Items, SampleItem{ID: "other", Status: "Todo", Estimate: 5})
+		return s
+	}
+	history := []StatusSample{
+		sample(12, "Todo", "Todo", "In Progress", "Todo"),
+		sample(14, "Todo", "Shipped", "In Progress", "Shipped"),
+	}
+
+	b := BuildBurndown(project, nil, history, day(15).Add(9*time.Hour))
+	if b.Iteration == nil || !b.Points {
+		t.Fatalf("expected a points burndown of Sprint 1, got %+v", b)
+	}
+	want := []float64{8, 8, 4, 4, math.NaN()}
+	for i, w := range want {
+		got := b.Remaining[i]
+		if (math.IsNaN(w) && !math.IsNaN(got)) || (!math.IsNaN(w) && got != w) {
+			t.Fatalf("day %d: got %v, want %v", i, got, w)
+		}
+	}
+	if b.Ideal[0] != 8 || b.Ideal[4] != 0 {
+		t.Fatalf("expected the ideal line from 8 to 0, got %v", b.Ideal)
+	}
+	if rec := b.CSV(); len(rec) != 6 || rec[5][1] != "" || rec[3][2] != "4" {
+		t.Fatalf("unexpected CSV: %v", rec)
+	}
+}
+
+func TestBuildCumulativeFlow(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	project := Project{Fields: []Field{{Name: "Status", Options: []Option{{Name: "Todo"}, {Name: "In Progress"}, {Name: "Done"}}}}}
+	history := []StatusSample{
+		{At: at(15, 9), Items: []SampleItem{{ID: "1", Status: "Todo"}, {ID: "2", Status: "Todo"}}},
+		{At: at(16, 9), Items: []SampleItem{{ID: "1", Status: "Done", Closed: true}, {ID: "2", Status: "Blocked"}, {ID: "3"}}},
+	}
+
+	c := BuildCumulativeFlow(project, history)
+	wantStatuses := []string{"Todo", "In Progress", "Done", "Blocked", "(none)"}
+	if len(c.Statuses) != len(wantStatuses) {
+		t.Fatalf("unexpected statuses: %v", c.Statuses)
+	}
+	for i, s := range wantStatuses {
+		if c.Statuses[i] != s {
+			t.Fatalf("status %d: got %q, want %q", i, c.Statuses[i], s)
+		}
+	}
+	if got := c.Counts[0]; got[0] != 2 || len(got) != len(wantStatuses) {
+		t.Fatalf("unexpected first day: %v", got)
+	}
+	if got := c.Counts[1]; got[0] != 0 || got[2] != 1 || got[3] != 1 || got[4] != 1 {
+		t.Fatalf("unexpected second day: %v", got)
+	}
+	if !c.Closed[2] || c.Closed[0] || c.Closed[3] {
+		t.Fatalf("expected only Done marked closed, got %v", c.Closed)
+	}
+	if rec := c.CSV(); len(rec) != 3 || rec[0][1] != "Todo" || rec[2][0] != "2026-10-16" {
+		t.Fatalf("unexpected CSV: %v", rec)
+	}
+}
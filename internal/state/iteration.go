@@ -0,0 +1,107 @@
+package state
+
+import (
+	"sort"
+	"time"
+)
+
+// IterationPhase places an iteration relative to the current day.
+type IterationPhase string
+
+const (
+	IterationPast     IterationPhase = "past"
+	IterationCurrent  IterationPhase = "current"
+	IterationUpcoming IterationPhase = "upcoming"
+)
+
+// Phase reports whether the iteration has ended, is running or has yet to
+// start at now. Completed iterations are past and those without dates count
+// as upcoming.
+func (t Timeline) Phase(now time.Time) IterationPhase {
+	switch {
+	case t.Completed:
+		return IterationPast
+	case t.Start == nil || now.Before(*t.Start):
+		return IterationUpcoming
+	case t.End != nil && !now.Before(*t.End):
+		return IterationPast
+	default:
+		return IterationCurrent
+	}
+}
+
+// DateRange renders the days the iteration covers, e.g. "Oct 6 – Oct 19".
+func (t Timeline) DateRange() string {
+	if t.Start == nil {
+		return ""
+	}
+	if t.End == nil {
+		return t.Start.Format("Jan 2")
+	}
+	last := t.End.AddDate(0, 0, -1)
+	return t.Start.Format("Jan 2") + " – " + last.Format("Jan 2")
+}
+
+// DurationDays returns the iteration's length in days, or 0 without dates.
+func (t Timeline) DurationDays() int {
+	if t.Start == nil || t.End == nil {
+		return 0
+	}
+	return int(t.End.Sub(*t.Start).Hours() / 24)
+}
+
+// ItemIteration returns the iteration item is assigned to, or nil.
+func ItemIteration(item Item) *Timeline {
+	if item.IterationID == "" && item.IterationName == "" {
+		return nil
+	}
+	t := Timeline{ID: item.IterationID, Name: item.IterationName, Start: item.IterationStart}
+	if item.IterationStart != nil && item.IterationDurationDays > 0 {
+		end := item.IterationStart.AddDate(0, 0, item.IterationDurationDays)
+		t.End = &end
+	}
+	return &t
+}
+
+// SetItemIteration assigns item to iteration t; nil clears the assignment.
+func SetItemIteration(item Item, t *Timeline) Item {
+	if t == nil {
+		item.IterationID = ""
+		item.IterationName = ""
+		item.IterationStart = nil
+		item.IterationDurationDays = 0
+		return item
+	}
+	item.IterationID = t.ID
+	item.IterationName = t.Name
+	item.IterationStart = t.Start
+	item.IterationDurationDays = t.DurationDays()
+	return item
+}
+
+// ProjectIterations returns the project's iterations ordered by start date.
+// When the project lists none, they are gathered from the items' assignments.
+func ProjectIterations(project Project, items []Item) []Timeline {
+	iterations := append([]Timeline(nil), project.Iterations...)
+	if len(iterations) == 0 {
+		seen := map[string]bool{}
+		for _, item := range items {
+			t := ItemIteration(item)
+			if t == nil || seen[t.ID+"\x00"+t.Name] {
+				continue
+			}
+			seen[t.ID+"\x00"+t.Name] = true
+			iterations = append(iterations, *t)
+		}
+	}
+	sort.SliceStable(iterations, func(i, j int) bool {
+		a, b := iterations[i].Start, iterations[j].Start
+		switch {
+		case a == nil || b == nil:
+			return a != nil
+		default:
+			return a.Before(*b)
+		}
+	})
+	return iterations
+}
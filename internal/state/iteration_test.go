@@ -0,0 +1,44 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectIterationsFromItems(t *testing.T) {
+	start1 := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	start2 := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	items := []Item{
+		{ID: "PVTI_1", IterationID: "it-2", IterationName: "Sprint 2", IterationStart: &start2, IterationDurationDays: 14},
+		{ID: "PVTI_2", IterationID: "it-1", IterationName: "Sprint 1", IterationStart: &start1, IterationDurationDays: 14},
+		{ID: "PVTI_3", IterationID: "it-2", IterationName: "Sprint 2", IterationStart: &start2, IterationDurationDays: 14},
+		{ID: "PVTI_4"},
+	}
+
+	iterations := ProjectIterations(Project{}, items)
+	if len(iterations) != 2 || iterations[0].ID != "it-1" || iterations[1].ID != "it-2" {
+		t.Fatalf("expected two distinct iterations ordered by start, got %+v", iterations)
+	}
+	if got := iterations[0].DateRange(); got != "Oct 5 – Oct 18" {
+		t.Fatalf("unexpected date range %q", got)
+	}
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	if iterations[0].Phase(now) != IterationCurrent || iterations[1].Phase(now) != IterationUpcoming {
+		t.Fatalf("unexpected phases %q %q", iterations[0].Phase(now), iterations[1].Phase(now))
+	}
+	if iterations[0].Phase(start2) != IterationPast {
+		t.Fatalf("expected sprint 1 to be past once sprint 2 starts")
+	}
+	if completed := (Timeline{Start: &start2, Completed: true}); completed.Phase(now) != IterationPast {
+		t.Fatalf("expected completed iterations to be past regardless of dates")
+	}
+
+	moved := SetItemIteration(items[3], &iterations[1])
+	if moved.IterationID != "it-2" || moved.IterationDurationDays != 14 {
+		t.Fatalf("expected item moved to sprint 2, got %+v", moved)
+	}
+	if cleared := SetItemIteration(moved, nil); cleared.IterationID != "" || cleared.IterationStart != nil {
+		t.Fatalf("expected iteration cleared, got %+v", cleared)
+	}
+}
@@ -0,0 +1,233 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MutationKind identifies which GitHub write a Mutation performs.
+type MutationKind string
+
+const (
+	MutationStatus    MutationKind = "status"
+	MutationField     MutationKind = "field"
+	MutationValue     MutationKind = "value"
+	MutationIteration MutationKind = "iteration"
+	MutationItem      MutationKind = "item"
+	MutationAssignees MutationKind = "assignees"
+	MutationLabels    MutationKind = "labels"
+	MutationMilestone MutationKind = "milestone"
+	MutationBody      MutationKind = "body"
+	MutationComment   MutationKind = "comment"
+)
+
+// Mutation is a single edit to a project item. It carries everything needed to
+// replay the write later, plus a snapshot of the item taken when it was made.
+type Mutation struct {
+	ID         string       `json:"id"`
+	Kind       MutationKind `json:"kind"`
+	ProjectID  string       `json:"projectId"`
+	Owner      string       `json:"owner"`
+	Before     Item         `json:"before"`
+	FieldID    string       `json:"fieldId,omitempty"`
+	FieldName  string       `json:"fieldName,omitempty"`
+	OptionID   string       `json:"optionId,omitempty"`
+	OptionName string       `json:"optionName,omitempty"`
+	FieldType  FieldType    `json:"fieldType,omitempty"`
+	Iteration  *Timeline    `json:"iteration,omitempty"` // Nil clears the iteration
+	Values     []string     `json:"values,omitempty"`
+	Title      string       `json:"title,omitempty"`
+	Text       string       `json:"text,omitempty"`
+	ClearText  bool         `json:"clearText,omitempty"` // An item edit empties the description; Text is empty
+	QueuedAt   time.Time    `json:"queuedAt"`
+}
+
+// MutationConflict is a queued mutation that could not be replayed as-is.
+type MutationConflict struct {
+	Mutation Mutation
+	Remote   Item   // The item as GitHub currently reports it
+	Reason   string // Why the mutation was held back
+}
+
+// ItemID returns the project item the mutation targets.
+func (m Mutation) ItemID() string {
+	return m.Before.ID
+}
+
+// Apply returns item with the mutation's change applied locally.
+func (m Mutation) Apply(item Item) Item {
+	switch m.Kind {
+	case MutationStatus:
+		item.Status = m.OptionName
+	case MutationField:
+		item = setFieldValue(item, m.FieldName, m.OptionName)
+	case MutationValue:
+		item = setFieldValue(item, m.FieldName, m.Text)
+	case MutationIteration:
+		item = SetItemIteration(item, m.Iteration)
+	case MutationItem:
+		if m.Title != "" {
+			item.Title = m.Title
+		}
+		if m.SetsText() {
+			item.Description = m.Text
+		}
+	case MutationAssignees:
+		item.Assignees = append([]string(nil), m.Values...)
+	case MutationLabels:
+		item.Labels = append([]string(nil), m.Values...)
+	case MutationMilestone:
+		item.Milestone = m.Text
+	case MutationBody:
+		item.Description = m.Text
+	case MutationComment:
+		at := m.QueuedAt
+		item.Comments = append(append([]Comment(nil), item.Comments...), Comment{Author: "you (queued)", Body: m.Text, CreatedAt: &at})
+	}
+	return item
+}
+
+// SetsText reports whether an item edit changes the description: to Text,
+// or to nothing when ClearText is set. An empty Text alone leaves it as is.
+func (m Mutation) SetsText() bool {
+	return m.Text != "" || m.ClearText
+}
+
+// Restore copies the fields the mutation touches from source onto item,
+// leaving every other field as it is.
+func (m Mutation) Restore(item Item, source Item) Item {
+	switch m.Kind {
+	case MutationStatus:
+		item.Status = source.Status
+	case MutationField, MutationValue:
+		item = setFieldValue(item, m.FieldName, fieldValue(source, m.FieldName))
+	case MutationIteration:
+		item = SetItemIteration(item, ItemIteration(source))
+	case MutationItem:
+		if m.Title != "" {
+			item.Title = source.Title
+		}
+		if m.SetsText() {
+			item.Description = source.Description
+		}
+	case MutationAssignees:
+		item.Assignees = append([]string(nil), source.Assignees...)
+	case MutationLabels:
+		item.Labels = append([]string(nil), source.Labels...)
+	case MutationMilestone:
+		item.Milestone = source.Milestone
+	case MutationBody:
+		item.Description = source.Description
+	case MutationComment:
+		item.Comments = append([]Comment(nil), source.Comments...)
+	}
+	return item
+}
+
+// Value renders the field the mutation touches on item, for comparisons and display.
+func (m Mutation) Value(item Item) string {
+	switch m.Kind {
+	case MutationStatus:
+		return item.Status
+	case MutationField, MutationValue:
+		return fieldValue(item, m.FieldName)
+	case MutationIteration:
+		return item.IterationName
+	case MutationItem:
+		if m.SetsText() {
+			return item.Title + "\n" + item.Description
+		}
+		return item.Title
+	case MutationAssignees:
+		return strings.Join(item.Assignees, ", ")
+	case MutationLabels:
+		return strings.Join(item.Labels, ", ")
+	case MutationMilestone:
+		return item.Milestone
+	case MutationBody:
+		return item.Description
+	default:
+		return ""
+	}
+}
+
+// Conflicts reports whether remote changed the mutated field since the mutation was made.
+func (m Mutation) Conflicts(remote Item) bool {
+	return m.Value(remote) != m.Value(m.Before)
+}
+
+// Describe summarizes the mutation for notifications and the conflict panel.
+func (m Mutation) Describe() string {
+	target := m.Before.Title
+	if m.Before.Number > 0 {
+		target = fmt.Sprintf("#%d %s", m.Before.Number, target)
+	}
+	switch m.Kind {
+	case MutationStatus:
+		return fmt.Sprintf("%s: status → %s", target, m.OptionName)
+	case MutationField:
+		return fmt.Sprintf("%s: %s → %s", target, m.FieldName, m.OptionName)
+	case MutationValue:
+		if m.Text == "" {
+			return fmt.Sprintf("%s: %s cleared", target, m.FieldName)
+		}
+		return fmt.Sprintf("%s: %s → %s", target, m.FieldName, m.Text)
+	case MutationIteration:
+		if m.Iteration == nil {
+			return fmt.Sprintf("%s: iteration cleared", target)
+		}
+		return fmt.Sprintf("%s: iteration → %s", target, m.Iteration.Name)
+	case MutationItem:
+		return fmt.Sprintf("%s: title → %s", target, m.Title)
+	case MutationAssignees:
+		return fmt.Sprintf("%s: assignees → %s", target, strings.Join(m.Values, ", "))
+	case MutationLabels:
+		return fmt.Sprintf("%s: labels → %s", target, strings.Join(m.Values, ", "))
+	case MutationMilestone:
+		return fmt.Sprintf("%s: milestone → %s", target, m.Text)
+	case MutationBody:
+		return fmt.Sprintf("%s: description edited", target)
+	case MutationComment:
+		return fmt.Sprintf("%s: comment added", target)
+	default:
+		return target
+	}
+}
+
+func fieldValue(item Item, fieldName string) string {
+	switch strings.ToLower(strings.TrimSpace(fieldName)) {
+	case "status":
+		return item.Status
+	case "priority":
+		return item.Priority
+	case "milestone":
+		return item.Milestone
+	}
+	return strings.Join(item.FieldValues[fieldName], ", ")
+}
+
+func setFieldValue(item Item, fieldName string, value string) Item {
+	switch strings.ToLower(strings.TrimSpace(fieldName)) {
+	case "status":
+		item.Status = value
+		return item
+	case "priority":
+		item.Priority = value
+		return item
+	case "milestone":
+		item.Milestone = value
+		return item
+	}
+	values := make(map[string][]string, len(item.FieldValues)+1)
+	for k, v := range item.FieldValues {
+		values[k] = v
+	}
+	if value == "" {
+		delete(values, fieldName)
+	} else {
+		values[fieldName] = []string{value}
+	}
+	item.FieldValues = values
+	return item
+}
@@ -0,0 +1,60 @@
+package state
+
+import "testing"
+
+func TestMutationApplyAndRestore(t *testing.T) {
+	before := Item{ID: "PVTI_1", Title: "Login", Status: "Todo", Labels: []string{"bug"}, Priority: "P2"}
+
+	tests := []struct {
+		name  string
+		m     Mutation
+		check func(Item) bool
+	}{
+		{name: "status", m: Mutation{Kind: MutationStatus, OptionName: "Done"}, check: func(it Item) bool { return it.Status == "Done" }},
+		{name: "priority field", m: Mutation{Kind: MutationField, FieldName: "Priority", OptionName: "P0"}, check: func(it Item) bool { return it.Priority == "P0" }},
+		{name: "custom field", m: Mutation{Kind: MutationField, FieldName: "Team", OptionName: "Core"}, check: func(it Item) bool { return len(it.FieldValues["Team"]) == 1 && it.FieldValues["Team"][0] == "Core" }},
+		{name: "date value", m: Mutation{Kind: MutationValue, FieldName: "Target date", FieldType: FieldTypeDate, Text: "2026-11-02"}, check: func(it Item) bool {
+			return len(it.FieldValues["Target date"]) == 1 && it.FieldValues["Target date"][0] == "2026-11-02"
+		}},
+		{name: "iteration", m: Mutation{Kind: MutationIteration, Iteration: &Timeline{ID: "it-2", Name: "Sprint 2"}}, check: func(it Item) bool { return it.IterationID == "it-2" && it.IterationName == "Sprint 2" }},
+		{name: "labels", m: Mutation{Kind: MutationLabels, Values: []string{"ui", "p1"}}, check: func(it Item) bool { return len(it.Labels) == 2 && it.Labels[1] == "p1" }},
+		{name: "title", m: Mutation{Kind: MutationItem, Title: "Sign in"}, check: func(it Item) bool { return it.Title == "Sign in" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.m.Before = before
+			applied := tt.m.Apply(before)
+			if !tt.check(applied) {
+				t.Fatalf("expected change to be applied, got %+v", applied)
+			}
+			if tt.m.Value(applied) == tt.m.Value(before) {
+				t.Fatalf("expected touched value to differ after apply")
+			}
+			applied.Repository = "acme/app"
+			restored := tt.m.Restore(applied, before)
+			if tt.m.Value(restored) != tt.m.Value(before) {
+				t.Fatalf("expected touched value restored, got %q", tt.m.Value(restored))
+			}
+			if restored.Repository != "acme/app" {
+				t.Fatalf("expected untouched fields to be kept, got %+v", restored)
+			}
+		})
+	}
+}
+
+func TestMutationConflicts(t *testing.T) {
+	m := Mutation{Kind: MutationStatus, OptionName: "Done", Before: Item{ID: "PVTI_1", Status: "Todo", Title: "Login"}}
+
+	if m.Conflicts(Item{ID: "PVTI_1", Status: "Todo", Title: "Renamed"}) {
+		t.Fatalf("expected changes to other fields not to conflict")
+	}
+	if !m.Conflicts(Item{ID: "PVTI_1", Status: "In Progress", Title: "Login"}) {
+		t.Fatalf("expected remote status change to conflict")
+	}
+
+	comment := Mutation{Kind: MutationComment, Text: "hi", Before: Item{ID: "PVTI_1", Description: "a"}}
+	if comment.Conflicts(Item{ID: "PVTI_1", Description: "b"}) {
+		t.Fatalf("expected comments never to conflict")
+	}
+}
@@ -0,0 +1,128 @@
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EstimateFieldName is the number field sprint planning adds up.
+const EstimateFieldName = "Estimate"
+
+// UnassignedLoad labels the share of planned work nobody is assigned to.
+const UnassignedLoad = "(unassigned)"
+
+// SprintPlan splits a project's unfinished work into a backlog and the items
+// already planned for the next iteration.
+type SprintPlan struct {
+	Next     *Timeline      // Nil when the project has no upcoming iteration
+	Backlog  []Item         // Unfinished items outside any iteration
+	Planned  []Item         // Items in the next iteration
+	Estimate float64        // Sum of the planned items' estimates
+	Load     []AssigneeLoad // Every person assigned in the project, planned work or not
+}
+
+// AssigneeLoad is one person's share of the planned estimate. Work shared by
+// several assignees is split evenly between them.
+type AssigneeLoad struct {
+	Assignee string
+	Estimate float64
+}
+
+// ItemEstimate returns the item's Estimate field, or 0 when it has none.
+func ItemEstimate(item Item) float64 {
+	values := item.FieldValues[EstimateFieldName]
+	if len(values) == 0 {
+		return 0
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// NextIteration returns the earliest iteration that has not started at now,
+// the one "@next" refers to when planning.
+func NextIteration(iterations []Timeline, now time.Time) *Timeline {
+	var next *Timeline
+	for i := range iterations {
+		t := iterations[i]
+		if t.Completed || t.Start == nil || !now.Before(*t.Start) {
+			continue
+		}
+		if next == nil || t.Start.Before(*next.Start) {
+			next = &t
+		}
+	}
+	return next
+}
+
+// BuildSprintPlan gathers the backlog and the next iteration's items from the
+// project's iterations. Everyone assigned to an item of the project gets a
+// load, so people with nothing planned yet still count towards capacity.
+func BuildSprintPlan(project Project, items []Item, now time.Time) SprintPlan {
+	plan := SprintPlan{Next: NextIteration(ProjectIterations(project, items), now)}
+	load := map[string]float64{}
+	for _, item := range items {
+		for _, assignee := range item.Assignees {
+			if _, ok := load[assignee]; !ok {
+				load[assignee] = 0
+			}
+		}
+		switch {
+		case plan.Next != nil && item.IterationID == plan.Next.ID:
+			plan.Planned = append(plan.Planned, item)
+			estimate := ItemEstimate(item)
+			plan.Estimate += estimate
+			if len(item.Assignees) == 0 {
+				load[UnassignedLoad] += estimate
+				continue
+			}
+			for _, assignee := range item.Assignees {
+				load[assignee] += estimate / float64(len(item.Assignees))
+			}
+		case item.IterationID == "" && !item.Closed():
+			plan.Backlog = append(plan.Backlog, item)
+		}
+	}
+	for assignee, estimate := range load {
+		plan.Load = append(plan.Load, AssigneeLoad{Assignee: assignee, Estimate: estimate})
+	}
+	sort.Slice(plan.Load, func(i, j int) bool {
+		a, b := plan.Load[i].Assignee, plan.Load[j].Assignee
+		if (a == UnassignedLoad) != (b == UnassignedLoad) {
+			return b == UnassignedLoad
+		}
+		return a < b
+	})
+	return plan
+}
+
+// Capacity returns how much the people assigned in the project can take on
+// at perPerson each.
+func (p SprintPlan) Capacity(perPerson float64) float64 {
+	people := 0
+	for _, l := range p.Load {
+		if l.Assignee != UnassignedLoad {
+			people++
+		}
+	}
+	return perPerson * float64(people)
+}
+
+// CarryOverItems returns the unfinished items of the current iteration, as
+// the "@current" iteration filter matches them.
+func CarryOverItems(items []Item, now time.Time) []Item {
+	var carry []Item
+	for _, item := range items {
+		if item.Closed() {
+			continue
+		}
+		if MatchesIterationFilters(item, []string{"@current"}, now) {
+			carry = append(carry, item)
+		}
+	}
+	return carry
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildSprintPlan(t *testing.T) {
+	day := func(d int) *time.Time {
+		v := time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	project := Project{Iterations: []Timeline{
+		{ID: "it-1", Name: "Sprint 1", Start: day(5), End: day(19)},
+		{ID: "it-3", Name: "Sprint 3", Start: day(26)},
+		{ID: "it-2", Name: "Sprint 2", Start: day(19), End: day(26)},
+	}}
+	estimate := func(v string) map[string][]string { return map[string][]string{EstimateFieldName: {v}} }
+	items := []Item{
+		{ID: "PVTI_1", Status: "Todo", IterationID: "it-2", Assignees: []string{"alice"}, FieldValues: estimate("5")},
+		{ID: "PVTI_2", Status: "Todo", IterationID: "it-2", Assignees: []string{"alice", "bob"}, FieldValues: estimate("4")},
+		{ID: "PVTI_3", Status: "Todo", IterationID: "it-2", FieldValues: estimate("1.5")},
+		{ID: "PVTI_4", Status: "Todo", Assignees: []string{"carol"}},
+		{ID: "PVTI_5", Status: "Shipped", State: "CLOSED"},
+		{ID: "PVTI_6", Status: "In Progress", IterationID: "it-1", IterationStart: day(5), IterationDurationDays: 14},
+		{ID: "PVTI_7", Status: "Shipped", State: "MERGED", IterationID: "it-1", IterationStart: day(5), IterationDurationDays: 14},
+	}
+
+	plan := BuildSprintPlan(project, items, now)
+	if plan.Next == nil || plan.Next.ID != "it-2" {
+		t.Fatalf("expected Sprint 2 to be next, got %+v", plan.Next)
+	}
+	if len(plan.Planned) != 3 || len(plan.Backlog) != 1 || plan.Backlog[0].ID != "PVTI_4" {
+		t.Fatalf("unexpected split: planned=%d backlog=%+v", len(plan.Planned), plan.Backlog)
+	}
+	if plan.Estimate != 10.5 {
+		t.Fatalf("expected estimates summed, got %v", plan.Estimate)
+	}
+	want := []AssigneeLoad{{"alice", 7}, {"bob", 2}, {"carol", 0}, {UnassignedLoad, 1.5}}
+	if len(plan.Load) != len(want) {
+		t.Fatalf("unexpected load: %+v", plan.Load)
+	}
+	for i := range want {
+		if plan.Load[i] != want[i] {
+			t.Fatalf("load %d: got %+v, want %+v", i, plan.Load[i], want[i])
+		}
+	}
+	if got := plan.Capacity(8); got != 24 {
+		t.Fatalf("expected capacity for three people, got %v", got)
+	}
+
+	carry := CarryOverItems(items, now)
+	if len(carry) != 1 || carry[0].ID != "PVTI_6" {
+		t.Fatalf("expected only the unfinished current item to carry over, got %+v", carry)
+	}
+}
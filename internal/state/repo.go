@@ -0,0 +1,97 @@
+package state
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RepoMetadataTTL is how long the labels, milestones and assignable users of
+// a repository are used before they are fetched again.
+const RepoMetadataTTL = time.Hour
+
+// Label is a repository label. Color is the hex colour GitHub shows it in,
+// without the leading '#'.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// RepoMetadata is what a repository offers when editing its issues: its
+// labels, open milestones and the users who can be assigned.
+type RepoMetadata struct {
+	Labels     []Label   `json:"labels,omitempty"`
+	Milestones []string  `json:"milestones,omitempty"`
+	Assignees  []string  `json:"assignees,omitempty"`
+	FetchedAt  time.Time `json:"fetchedAt"`
+}
+
+// Stale reports whether the metadata should be fetched again.
+func (r RepoMetadata) Stale(now time.Time) bool {
+	return now.Sub(r.FetchedAt) > RepoMetadataTTL
+}
+
+// Values returns the label names, assignee logins or milestone titles, as
+// kind asks.
+func (r RepoMetadata) Values(kind MutationKind) []string {
+	switch kind {
+	case MutationLabels:
+		names := make([]string, 0, len(r.Labels))
+		for _, l := range r.Labels {
+			names = append(names, l.Name)
+		}
+		return names
+	case MutationAssignees:
+		return r.Assignees
+	case MutationMilestone:
+		return r.Milestones
+	}
+	return nil
+}
+
+// LabelColor returns the colour of the named label, or "" when the
+// repository has no such label.
+func (r RepoMetadata) LabelColor(name string) string {
+	for _, l := range r.Labels {
+		if strings.EqualFold(l.Name, name) {
+			return l.Color
+		}
+	}
+	return ""
+}
+
+// RepoValues merges the values of kind offered by every repository, sorted
+// and without duplicates. Where repositories differ only in case, the first
+// repository by name wins.
+func RepoValues(repos map[string]RepoMetadata, kind MutationKind) []string {
+	names := make([]string, 0, len(repos))
+	for name := range repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var out []string
+	for _, name := range names {
+		for _, v := range repos[name].Values(kind) {
+			if !containsFold(out, v) {
+				out = append(out, v)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i]) < strings.ToLower(out[j])
+	})
+	return out
+}
+
+// Repositories lists the repositories of items in the order they first appear.
+func Repositories(items []Item) []string {
+	var repos []string
+	seen := map[string]bool{}
+	for _, item := range items {
+		if item.Repository != "" && !seen[item.Repository] {
+			seen[item.Repository] = true
+			repos = append(repos, item.Repository)
+		}
+	}
+	return repos
+}
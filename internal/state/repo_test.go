@@ -0,0 +1,31 @@
+package state
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepoMetadata(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	repos := map[string]RepoMetadata{
+		"acme/app": {Labels: []Label{{Name: "bug", Color: "d73a4a"}, {Name: "UI", Color: "a2eeef"}}, Assignees: []string{"alice"}, FetchedAt: now.Add(-2 * time.Hour)},
+		"acme/web": {Labels: []Label{{Name: "ui"}, {Name: "docs"}}, Milestones: []string{"v1.0"}, FetchedAt: now},
+	}
+	if got := strings.Join(RepoValues(repos, MutationLabels), ","); got != "bug,docs,UI" {
+		t.Fatalf("unexpected merged labels %s", got)
+	}
+	if got := RepoValues(repos, MutationMilestone); len(got) != 1 || got[0] != "v1.0" {
+		t.Fatalf("unexpected milestones %v", got)
+	}
+	if c := repos["acme/app"].LabelColor("ui"); c != "a2eeef" {
+		t.Fatalf("expected the label colour looked up by name, got %q", c)
+	}
+	if !repos["acme/app"].Stale(now) || repos["acme/web"].Stale(now) {
+		t.Fatalf("expected only metadata older than the TTL to be stale")
+	}
+	items := []Item{{Repository: "acme/web"}, {}, {Repository: "acme/app"}, {Repository: "acme/web"}}
+	if got := strings.Join(Repositories(items), ","); got != "acme/web,acme/app" {
+		t.Fatalf("unexpected repositories %s", got)
+	}
+}
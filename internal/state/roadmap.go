@@ -0,0 +1,161 @@
+package state
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RoadmapZoom sets how much time the roadmap view fits on screen.
+type RoadmapZoom string
+
+const (
+	RoadmapWeek    RoadmapZoom = "week"
+	RoadmapMonth   RoadmapZoom = "month"
+	RoadmapQuarter RoadmapZoom = "quarter"
+)
+
+var roadmapZooms = []RoadmapZoom{RoadmapWeek, RoadmapMonth, RoadmapQuarter}
+
+// ColumnsPerDay returns how many terminal columns one day takes up. Unknown
+// zoom levels fall back to the month zoom.
+func (z RoadmapZoom) ColumnsPerDay() float64 {
+	switch z {
+	case RoadmapWeek:
+		return 3
+	case RoadmapQuarter:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+// StepDays returns how many days one horizontal scroll step moves.
+func (z RoadmapZoom) StepDays() int {
+	switch z {
+	case RoadmapWeek:
+		return 7
+	case RoadmapQuarter:
+		return 28
+	default:
+		return 14
+	}
+}
+
+// ZoomIn returns the next closer zoom level, stopping at week.
+func (z RoadmapZoom) ZoomIn() RoadmapZoom {
+	return z.shift(-1)
+}
+
+// ZoomOut returns the next wider zoom level, stopping at quarter.
+func (z RoadmapZoom) ZoomOut() RoadmapZoom {
+	return z.shift(1)
+}
+
+func (z RoadmapZoom) shift(delta int) RoadmapZoom {
+	idx := 1
+	for i, zoom := range roadmapZooms {
+		if zoom == z {
+			idx = i
+		}
+	}
+	idx += delta
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(roadmapZooms) {
+		idx = len(roadmapZooms) - 1
+	}
+	return roadmapZooms[idx]
+}
+
+// RoadmapSpan is the stretch of days an item covers; End is exclusive.
+type RoadmapSpan struct {
+	Start time.Time
+	End   time.Time
+}
+
+// RoadmapRow is one item on the roadmap. Span is nil for undated items.
+type RoadmapRow struct {
+	Item Item
+	Span *RoadmapSpan
+}
+
+// RoadmapDateFields returns the names of the project's start and target date
+// fields, matched the way GitHub names them ("Start date", "Target date").
+// Either name is empty when the project has no such field.
+func RoadmapDateFields(fields []Field) (start, target string) {
+	for _, f := range fields {
+		if f.Type != FieldTypeDate && f.Type != "" {
+			continue
+		}
+		name := strings.ToLower(f.Name)
+		switch {
+		case start == "" && strings.Contains(name, "start"):
+			start = f.Name
+		case target == "" && (strings.Contains(name, "target") || strings.Contains(name, "end") || strings.Contains(name, "due")):
+			target = f.Name
+		}
+	}
+	return start, target
+}
+
+// ItemSpan returns the days an item covers on the roadmap: its iteration when
+// it has one with dates, otherwise its start and target date fields. An item
+// with only one of the two dates covers that single day.
+func ItemSpan(item Item, fields []Field) (RoadmapSpan, bool) {
+	if t := ItemIteration(item); t != nil && t.Start != nil && t.End != nil {
+		return RoadmapSpan{Start: *t.Start, End: *t.End}, true
+	}
+
+	startField, targetField := RoadmapDateFields(fields)
+	start, hasStart := itemDate(item, startField)
+	target, hasTarget := itemDate(item, targetField)
+	switch {
+	case hasStart && hasTarget && !target.Before(start):
+		return RoadmapSpan{Start: start, End: target.AddDate(0, 0, 1)}, true
+	case hasStart:
+		return RoadmapSpan{Start: start, End: start.AddDate(0, 0, 1)}, true
+	case hasTarget:
+		return RoadmapSpan{Start: target, End: target.AddDate(0, 0, 1)}, true
+	}
+	return RoadmapSpan{}, false
+}
+
+func itemDate(item Item, field string) (time.Time, bool) {
+	if field == "" {
+		return time.Time{}, false
+	}
+	values := item.FieldValues[field]
+	if len(values) == 0 {
+		return time.Time{}, false
+	}
+	d, err := time.Parse(DateLayout, strings.TrimSpace(values[0]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return d, true
+}
+
+// BuildRoadmap orders items by the day their span starts. Undated items keep
+// their order and follow the dated ones.
+func BuildRoadmap(items []Item, fields []Field) []RoadmapRow {
+	rows := make([]RoadmapRow, 0, len(items))
+	for _, item := range items {
+		row := RoadmapRow{Item: item}
+		if span, ok := ItemSpan(item, fields); ok {
+			row.Span = &span
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].Span, rows[j].Span
+		switch {
+		case a == nil || b == nil:
+			return a != nil
+		default:
+			return a.Start.Before(b.Start)
+		}
+	})
+	return rows
+}
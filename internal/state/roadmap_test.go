@@ -0,0 +1,54 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildRoadmap(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	start := day(5)
+	fields := []Field{
+		{Name: "Status", Type: FieldTypeSingleSelect},
+		{Name: "Start date", Type: FieldTypeDate},
+		{Name: "Target date", Type: FieldTypeDate},
+	}
+	items := []Item{
+		{ID: "undated"},
+		{ID: "dates", FieldValues: map[string][]string{"Start date": {"2026-10-20"}, "Target date": {"2026-10-22"}}},
+		{ID: "iteration", IterationID: "it-1", IterationStart: &start, IterationDurationDays: 14, FieldValues: map[string][]string{"Start date": {"2026-10-01"}}},
+		{ID: "target-only", FieldValues: map[string][]string{"Target date": {"2026-10-10"}}},
+	}
+
+	rows := BuildRoadmap(items, fields)
+	wantOrder := []string{"iteration", "target-only", "dates", "undated"}
+	for i, id := range wantOrder {
+		if rows[i].Item.ID != id {
+			t.Fatalf("row %d: got %s, want %s", i, rows[i].Item.ID, id)
+		}
+	}
+	if s := rows[0].Span; !s.Start.Equal(day(5)) || !s.End.Equal(day(19)) {
+		t.Fatalf("expected the iteration to win over date fields, got %+v", s)
+	}
+	if s := rows[1].Span; !s.Start.Equal(day(10)) || !s.End.Equal(day(11)) {
+		t.Fatalf("expected a single day for a lone target date, got %+v", s)
+	}
+	if s := rows[2].Span; !s.Start.Equal(day(20)) || !s.End.Equal(day(23)) {
+		t.Fatalf("expected the target date to be included, got %+v", s)
+	}
+	if rows[3].Span != nil {
+		t.Fatalf("expected no span for an undated item, got %+v", rows[3].Span)
+	}
+}
+
+func TestRoadmapZoomStopsAtEnds(t *testing.T) {
+	if got := RoadmapWeek.ZoomIn(); got != RoadmapWeek {
+		t.Fatalf("expected week to stay week, got %s", got)
+	}
+	if got := RoadmapMonth.ZoomOut(); got != RoadmapQuarter {
+		t.Fatalf("expected quarter after month, got %s", got)
+	}
+	if got := RoadmapQuarter.ZoomOut(); got != RoadmapQuarter {
+		t.Fatalf("expected quarter to stay quarter, got %s", got)
+	}
+}
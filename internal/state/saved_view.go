@@ -0,0 +1,128 @@
+package state
+
+import "strings"
+
+// SavedView is a named filter and layout that can be switched to at once.
+type SavedView struct {
+	Name                string
+	Query               string // Filter query as typed in filter mode
+	View                ViewType
+	TableSort           TableSort
+	TableGroupBy        string
+	CardFieldVisibility CardFieldVisibility
+}
+
+// FindSavedView returns the view with the given name, ignoring case.
+func FindSavedView(views []SavedView, name string) (SavedView, bool) {
+	for _, v := range views {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return SavedView{}, false
+}
+
+// PutSavedView returns views with v added, replacing any view of the same
+// name in place.
+func PutSavedView(views []SavedView, v SavedView) []SavedView {
+	out := append([]SavedView(nil), views...)
+	for i := range out {
+		if strings.EqualFold(out[i].Name, v.Name) {
+			out[i] = v
+			return out
+		}
+	}
+	return append(out, v)
+}
+
+// ImportedFilter is the filter of a view saved on GitHub, adapted to run
+// here.
+type ImportedFilter struct {
+	Query      string   // The filter with the terms nothing can evaluate dropped
+	ServerOnly []string // Terms kept that only GitHub evaluates
+	Dropped    []string // Terms only GitHub can evaluate, found where it is not asked to
+}
+
+// ImportGitHubFilter adapts the filter of a view saved on GitHub. Terms only
+// GitHub can evaluate, such as assignee:@me or reason:completed, are kept
+// where every item must match them, since the fetch sends those to GitHub.
+// OR groups and negated groups are evaluated here only, so such terms are
+// dropped from them. A query that does not parse is returned as it is.
+func ImportGitHubFilter(query string) ImportedFilter {
+	out := ImportedFilter{Query: query}
+	fs := ParseFilter(query)
+	if fs.Err != nil || fs.Expr == nil {
+		return out
+	}
+	conjuncts := []FilterExpr{fs.Expr}
+	if and, ok := fs.Expr.(FilterAnd); ok {
+		conjuncts = and
+	}
+	var kept []string
+	for _, e := range conjuncts {
+		if serverOnlyExpr(e) {
+			out.ServerOnly = append(out.ServerOnly, e.String())
+			kept = append(kept, e.String())
+			continue
+		}
+		if e = dropServerOnly(e, &out.Dropped); e != nil {
+			kept = append(kept, e.String())
+		}
+	}
+	if len(out.Dropped) == 0 {
+		return out
+	}
+	if fs.GroupBy != "" {
+		kept = append(kept, "group:"+QuoteFilterValue(fs.GroupBy))
+	}
+	out.Query = strings.Join(kept, " ")
+	return out
+}
+
+func serverOnlyExpr(e FilterExpr) bool {
+	if n, ok := e.(FilterNot); ok {
+		e = n.Expr
+	}
+	t, ok := e.(FilterTerm)
+	return ok && t.ServerOnly()
+}
+
+// dropServerOnly returns e without the terms only GitHub can evaluate, adding
+// them to dropped, or nil when nothing is left.
+func dropServerOnly(e FilterExpr, dropped *[]string) FilterExpr {
+	switch e := e.(type) {
+	case FilterTerm:
+		if e.ServerOnly() {
+			*dropped = append(*dropped, e.String())
+			return nil
+		}
+		return e
+	case FilterNot:
+		inner := dropServerOnly(e.Expr, dropped)
+		if inner == nil {
+			return nil
+		}
+		return FilterNot{Expr: inner}
+	case FilterAnd:
+		return joinKept(e, dropped, func(kept []FilterExpr) FilterExpr { return FilterAnd(kept) })
+	case FilterOr:
+		return joinKept(e, dropped, func(kept []FilterExpr) FilterExpr { return FilterOr(kept) })
+	}
+	return e
+}
+
+func joinKept(operands []FilterExpr, dropped *[]string, join func([]FilterExpr) FilterExpr) FilterExpr {
+	var kept []FilterExpr
+	for _, operand := range operands {
+		if operand = dropServerOnly(operand, dropped); operand != nil {
+			kept = append(kept, operand)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return nil
+	case 1:
+		return kept[0]
+	}
+	return join(kept)
+}
@@ -0,0 +1,24 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportGitHubFilter(t *testing.T) {
+	tests := []struct {
+		query string
+		want  ImportedFilter
+	}{
+		{"label:bug is:open", ImportedFilter{Query: "label:bug is:open"}},
+		{"assignee:@me -reason:completed label:bug", ImportedFilter{Query: "assignee:@me -reason:completed label:bug", ServerOnly: []string{"assignee:@me", "-reason:completed"}}},
+		{"label:bug (assignee:@me OR status:Todo) group:status", ImportedFilter{Query: "label:bug status:Todo group:status", Dropped: []string{"assignee:@me"}}},
+		{"-(reason:completed is:closed)", ImportedFilter{Query: "-is:closed", Dropped: []string{"reason:completed"}}},
+		{"(label:bug", ImportedFilter{Query: "(label:bug"}},
+	}
+	for _, tt := range tests {
+		if got := ImportGitHubFilter(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ImportGitHubFilter(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// snippetContext is how many characters of context a search snippet keeps on
+// each side of the match.
+const snippetContext = 30
+
+// SearchResult is an item matching a full-text search, with the text that
+// matched best.
+type SearchResult struct {
+	Item      Item
+	Field     string // Where the match is: title, number, repository, label, body or comment
+	Snippet   string // The matching text, shortened around the match
+	Highlight []int  // Rune offsets in Snippet of the matched characters
+	Score     int
+}
+
+// Search ranks items by how well query matches their title, number (#123),
+// repository, labels, body and comments, best match first. Short fields
+// match fuzzily; the body and comments must contain the query. Comments come
+// from the item itself and from comments, which holds comments fetched
+// separately by item ID.
+func Search(items []Item, comments map[string][]Comment, query string) []SearchResult {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	var results []SearchResult
+	for _, item := range items {
+		if r, ok := searchItem(item, comments[item.ID], query); ok {
+			results = append(results, r)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+func searchItem(item Item, fetched []Comment, query string) (SearchResult, bool) {
+	best := SearchResult{Item: item}
+	found := false
+	consider := func(r SearchResult, ok bool) {
+		if ok && (!found || r.Score > best.Score) {
+			r.Item = item
+			best, found = r, true
+		}
+	}
+
+	if item.Number > 0 && strings.HasPrefix(query, "#") {
+		number := "#" + strconv.Itoa(item.Number)
+		if strings.HasPrefix(number, query) {
+			score := 100 + 10*len(query)
+			if number == query {
+				score += 20
+			}
+			consider(SearchResult{Field: "number", Snippet: number, Highlight: runeRange(0, len(query)), Score: score}, true)
+		}
+	}
+	consider(fuzzyField("title", item.Title, query, 0))
+	consider(fuzzyField("repository", item.Repository, query, -5))
+	for _, label := range item.Labels {
+		consider(fuzzyField("label", label, query, -5))
+	}
+	consider(textField("body", item.Description, query, -10))
+	seen := make(map[string]bool)
+	for _, c := range append(append([]Comment(nil), item.Comments...), fetched...) {
+		if seen[c.Body] {
+			continue
+		}
+		seen[c.Body] = true
+		consider(textField("comment", c.Body, query, -15))
+	}
+	return best, found
+}
+
+// fuzzyField matches query against a short field, preferring a plain
+// substring match over scattered letters.
+func fuzzyField(field, value, query string, bonus int) (SearchResult, bool) {
+	if value == "" {
+		return SearchResult{}, false
+	}
+	if r, ok := textField(field, value, query, bonus+20); ok {
+		return r, true
+	}
+	score, ok := FuzzyScore(query, value)
+	if !ok {
+		return SearchResult{}, false
+	}
+	return SearchResult{Field: field, Snippet: value, Highlight: fuzzyPositions(query, value), Score: score + bonus}, true
+}
+
+// textField matches a field that contains query, ignoring case, and cuts a
+// snippet around the first occurrence.
+func textField(field, value, query string, bonus int) (SearchResult, bool) {
+	text := []rune(strings.Join(strings.Fields(value), " "))
+	q := []rune(strings.ToLower(query))
+	at := indexFold(text, q)
+	if at < 0 {
+		return SearchResult{}, false
+	}
+	start := max(at-snippetContext, 0)
+	end := min(at+len(q)+snippetContext, len(text))
+	snippet := string(text[start:end])
+	offset := at - start
+	if start > 0 {
+		snippet = "…" + snippet
+		offset++
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	score := 50 + 5*len(q) + bonus
+	if at == 0 || !unicode.IsLetter(text[at-1]) && !unicode.IsDigit(text[at-1]) {
+		score += 10
+	}
+	return SearchResult{Field: field, Snippet: snippet, Highlight: runeRange(offset, offset+len(q)), Score: score}, true
+}
+
+// indexFold returns the rune index of the first occurrence of lower-case
+// query in text, ignoring case, or -1.
+func indexFold(text, query []rune) int {
+	if len(query) == 0 {
+		return -1
+	}
+	for i := 0; i+len(query) <= len(text); i++ {
+		match := true
+		for j, r := range query {
+			if unicode.ToLower(text[i+j]) != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// fuzzyPositions returns the rune offsets FuzzyScore matches in candidate.
+func fuzzyPositions(query, candidate string) []int {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	var positions []int
+	qi := 0
+	for ci, r := range []rune(strings.ToLower(candidate)) {
+		if qi < len(q) && r == q[qi] {
+			positions = append(positions, ci)
+			qi++
+		}
+	}
+	return positions
+}
+
+func runeRange(from, to int) []int {
+	out := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		out = append(out, i)
+	}
+	return out
+}
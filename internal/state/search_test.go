@@ -0,0 +1,46 @@
+package state
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchMatchesBodyCommentsAndNumbers(t *testing.T) {
+	items := []Item{
+		{ID: "0", Title: "Unrelated", Number: 120},
+		{ID: "1", Title: "Fix login redirect", Number: 12, Repository: "acme/app"},
+		{ID: "2", Title: "Tidy up", Number: 123, Description: "The session token expires before the login redirect completes, so users land on an error page."},
+		{ID: "3", Title: "Docs", Number: 7, Labels: []string{"documentation"}},
+	}
+	comments := map[string][]Comment{"3": {{Author: "bob", Body: "Mention the OAuth callback here"}}}
+
+	results := Search(items, comments, "login redirect")
+	if len(results) != 2 || results[0].Item.ID != "1" || results[1].Field != "body" {
+		t.Fatalf("expected the title match ahead of the body match, got %+v", results)
+	}
+	body := results[1]
+	if !strings.HasPrefix(body.Snippet, "…") || !strings.HasSuffix(body.Snippet, "…") {
+		t.Fatalf("expected a shortened snippet, got %q", body.Snippet)
+	}
+	runes := []rune(body.Snippet)
+	var highlighted []rune
+	for _, i := range body.Highlight {
+		highlighted = append(highlighted, runes[i])
+	}
+	if string(highlighted) != "login redirect" {
+		t.Fatalf("expected the match highlighted, got %q", string(highlighted))
+	}
+
+	if results := Search(items, comments, "oauth"); len(results) != 1 || results[0].Item.ID != "3" || results[0].Field != "comment" {
+		t.Fatalf("expected a match in fetched comments, got %+v", results)
+	}
+	if results := Search(items, comments, "#12"); len(results) != 3 || results[0].Item.Number != 12 || results[0].Field != "number" {
+		t.Fatalf("expected #12 to rank the exact number first, got %+v", results)
+	}
+	if results := Search(items, comments, "dcmnt"); len(results) != 1 || results[0].Field != "label" {
+		t.Fatalf("expected a fuzzy label match, got %+v", results)
+	}
+	if results := Search(items, comments, " "); results != nil {
+		t.Fatalf("expected no results for an empty query, got %+v", results)
+	}
+}
@@ -3,138 +3,280 @@ package state
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
-func ApplyTableSort(items []Item, ts TableSort) []Item {
+// SortKey orders items by one field.
+type SortKey struct {
+	Field string // A built-in column such as Status or UpdatedAt, or a project field name
+	Asc   bool
+}
+
+// Keys returns the sort keys in the order they apply: the primary field,
+// then the tie-breakers.
+func (ts TableSort) Keys() []SortKey {
 	if ts.Field == "" {
+		return nil
+	}
+	return append([]SortKey{{Field: ts.Field, Asc: ts.Asc}}, ts.Then...)
+}
+
+// String formats the sort keys the way ParseTableSort reads them, e.g.
+// "Status, -Priority, -UpdatedAt".
+func (ts TableSort) String() string {
+	var parts []string
+	for _, k := range ts.Keys() {
+		if k.Asc {
+			parts = append(parts, k.Field)
+		} else {
+			parts = append(parts, "-"+k.Field)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseTableSort reads comma-separated sort keys, each a field name that
+// sorts ascending or, prefixed with -, descending. Empty keys are skipped.
+func ParseTableSort(spec string) TableSort {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		asc := true
+		switch {
+		case strings.HasPrefix(part, "-"):
+			asc = false
+			part = strings.TrimSpace(part[1:])
+		case strings.HasPrefix(part, "+"):
+			part = strings.TrimSpace(part[1:])
+		}
+		if part == "" {
+			continue
+		}
+		keys = append(keys, SortKey{Field: part, Asc: asc})
+	}
+	if len(keys) == 0 {
+		return TableSort{}
+	}
+	ts := TableSort{Field: keys[0].Field, Asc: keys[0].Asc}
+	if len(keys) > 1 {
+		ts.Then = keys[1:]
+	}
+	return ts
+}
+
+// builtinSortFields are the item properties sortable without a project field.
+var builtinSortFields = []string{"Title", "Status", "Repository", "Labels", "Assignees", "Milestone", "Priority", "SubIssueProgress", "Number", "CreatedAt", "UpdatedAt"}
+
+// SortFields lists the names ApplyTableSort understands: the built-in
+// properties followed by the project's other fields.
+func SortFields(fields []Field) []string {
+	names := append([]string(nil), builtinSortFields...)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		seen[strings.ToLower(name)] = true
+	}
+	for _, f := range fields {
+		if key := strings.ToLower(f.Name); f.Name != "" && !seen[key] {
+			seen[key] = true
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// ApplyTableSort orders items by each sort key in turn, later keys breaking
+// ties of earlier ones. Single-select fields such as Status follow the order
+// of their options in the project; Priority falls back to high, medium, low
+// when the project has no such field. Items without a value sort last in
+// either direction.
+func ApplyTableSort(items []Item, fields []Field, ts TableSort) []Item {
+	keys := ts.Keys()
+	if len(keys) == 0 {
 		return items
 	}
-	switch ts.Field {
-	case "Title":
-		sort.SliceStable(items, func(i, j int) bool {
-			if ts.Asc {
-				return items[i].Title < items[j].Title
-			}
-			return items[i].Title > items[j].Title
-		})
-	case "Status":
-		sort.SliceStable(items, func(i, j int) bool {
-			if ts.Asc {
-				return items[i].Status < items[j].Status
-			}
-			return items[i].Status > items[j].Status
-		})
-	case "Repository":
-		sort.SliceStable(items, func(i, j int) bool {
-			if ts.Asc {
-				return items[i].Repository < items[j].Repository
-			}
-			return items[i].Repository > items[j].Repository
-		})
-	case "Labels":
-		sort.SliceStable(items, func(i, j int) bool {
-			iLabels := strings.Join(items[i].Labels, ",")
-			jLabels := strings.Join(items[j].Labels, ",")
-			if ts.Asc {
-				return iLabels < jLabels
-			}
-			return iLabels > jLabels
-		})
-	case "Milestone":
-		sort.SliceStable(items, func(i, j int) bool {
-			if ts.Asc {
-				return items[i].Milestone < items[j].Milestone
-			}
-			return items[i].Milestone > items[j].Milestone
-		})
-	case "SubIssueProgress":
-		parseRatio := func(s string) float64 {
-			if s == "" {
-				return 0.0
-			}
-			parts := strings.Split(s, "/")
-			if len(parts) != 2 {
-				return 0.0
-			}
-			var a, b float64
-			if _, err := fmt.Sscan(parts[0], &a); err != nil {
-				return 0.0
-			}
-			if _, err := fmt.Sscan(parts[1], &b); err != nil || b == 0 {
-				return 0.0
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, k := range keys {
+			if c := compareBy(items[i], items[j], k, fields); c != 0 {
+				return c < 0
 			}
-			return a / b
 		}
-		sort.SliceStable(items, func(i, j int) bool {
-			ri := parseRatio(items[i].SubIssueProgress)
-			rj := parseRatio(items[j].SubIssueProgress)
-			if ts.Asc {
-				return ri < rj
-			}
-			return ri > rj
-		})
-	case "Priority":
-		priorityRank := func(p string) int {
-			switch strings.ToLower(p) {
-			case "high":
-				return 3
-			case "medium":
-				return 2
-			case "low":
-				return 1
-			default:
-				return 0
-			}
+		return false
+	})
+	return items
+}
+
+// sortValue is an item's value for a sort key: a number when the field is
+// ordered numerically, otherwise text.
+type sortValue struct {
+	missing bool
+	numeric bool
+	num     float64
+	text    string
+}
+
+func compareBy(a, b Item, key SortKey, fields []Field) int {
+	va, vb := itemSortValue(a, key.Field, fields), itemSortValue(b, key.Field, fields)
+	if va.missing || vb.missing {
+		switch {
+		case va.missing == vb.missing:
+			return 0
+		case va.missing:
+			return 1
+		default:
+			return -1
+		}
+	}
+	c := 0
+	switch {
+	case va.numeric && vb.numeric:
+		switch {
+		case va.num < vb.num:
+			c = -1
+		case va.num > vb.num:
+			c = 1
+		}
+	case va.numeric != vb.numeric:
+		c = 1
+		if va.numeric {
+			c = -1
 		}
-		sort.SliceStable(items, func(i, j int) bool {
-			if ts.Asc {
-				return priorityRank(items[i].Priority) < priorityRank(items[j].Priority)
-			}
-			return priorityRank(items[i].Priority) > priorityRank(items[j].Priority)
-		})
 	default:
-		if ts.Field == "Assignees" {
-			sort.SliceStable(items, func(i, j int) bool {
-				iAssignees := strings.Join(items[i].Assignees, ",")
-				jAssignees := strings.Join(items[j].Assignees, ",")
-				if ts.Asc {
-					return iAssignees < jAssignees
-				}
-				return iAssignees > jAssignees
-			})
+		c = strings.Compare(va.text, vb.text)
+	}
+	if !key.Asc {
+		c = -c
+	}
+	return c
+}
+
+func itemSortValue(item Item, name string, fields []Field) sortValue {
+	switch strings.ToLower(name) {
+	case "title":
+		return textSortValue(item.Title)
+	case "status":
+		return optionSortValue(item.Status, fields, "Status")
+	case "repository":
+		return textSortValue(item.Repository)
+	case "labels":
+		return textSortValue(strings.Join(item.Labels, ","))
+	case "assignees":
+		return textSortValue(strings.Join(item.Assignees, ","))
+	case "milestone":
+		return textSortValue(item.Milestone)
+	case "priority":
+		if f, ok := fieldByName(fields, "Priority"); ok && len(f.Options) > 0 {
+			return optionSortValue(item.Priority, fields, "Priority")
+		}
+		return legacyPrioritySortValue(item.Priority)
+	case "subissueprogress":
+		if item.SubIssueProgress == "" {
+			return sortValue{missing: true}
+		}
+		return sortValue{numeric: true, num: progressRatio(item.SubIssueProgress)}
+	case "number":
+		if item.Number <= 0 {
+			return sortValue{missing: true}
+		}
+		return sortValue{numeric: true, num: float64(item.Number)}
+	case "createdat":
+		return timeSortValue(item.CreatedAt)
+	case "updatedat":
+		return timeSortValue(item.UpdatedAt)
+	}
+
+	var value string
+	for fieldName, values := range item.FieldValues {
+		if strings.EqualFold(fieldName, name) && len(values) > 0 {
+			value = strings.TrimSpace(values[0])
 			break
 		}
-		switch ts.Field {
-		case "Number":
-			sort.SliceStable(items, func(i, j int) bool {
-				if ts.Asc {
-					return items[i].Number < items[j].Number
-				}
-				return items[i].Number > items[j].Number
-			})
-		case "CreatedAt":
-			sort.SliceStable(items, func(i, j int) bool {
-				if items[i].CreatedAt == nil || items[j].CreatedAt == nil {
-					return items[i].CreatedAt == nil
-				}
-				if ts.Asc {
-					return items[i].CreatedAt.Before(*items[j].CreatedAt)
-				}
-				return items[j].CreatedAt.Before(*items[i].CreatedAt)
-			})
-		case "UpdatedAt":
-			sort.SliceStable(items, func(i, j int) bool {
-				if items[i].UpdatedAt == nil || items[j].UpdatedAt == nil {
-					return items[i].UpdatedAt == nil
-				}
-				if ts.Asc {
-					return items[i].UpdatedAt.Before(*items[j].UpdatedAt)
-				}
-				return items[j].UpdatedAt.Before(*items[i].UpdatedAt)
-			})
-		default:
+	}
+	field, _ := fieldByName(fields, name)
+	switch field.Type {
+	case FieldTypeSingleSelect:
+		return optionSortValue(value, fields, name)
+	case FieldTypeDate:
+		if day, err := parseFilterDate(value, time.UTC); err == nil {
+			return sortValue{numeric: true, num: float64(day.Unix())}
+		}
+	case FieldTypeNumber, "":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return sortValue{numeric: true, num: n}
 		}
 	}
-	return items
+	return textSortValue(value)
+}
+
+func textSortValue(s string) sortValue {
+	if strings.TrimSpace(s) == "" {
+		return sortValue{missing: true}
+	}
+	return sortValue{text: s}
+}
+
+func timeSortValue(t *time.Time) sortValue {
+	if t == nil {
+		return sortValue{missing: true}
+	}
+	return sortValue{numeric: true, num: float64(t.UnixNano())}
+}
+
+// optionSortValue ranks value by its position among the options of the
+// project field named name. Values the field does not list follow its
+// options alphabetically; without options the value sorts as text.
+func optionSortValue(value string, fields []Field, name string) sortValue {
+	if strings.TrimSpace(value) == "" {
+		return sortValue{missing: true}
+	}
+	field, ok := fieldByName(fields, name)
+	if !ok || len(field.Options) == 0 {
+		return textSortValue(value)
+	}
+	for i, opt := range field.Options {
+		if strings.EqualFold(opt.Name, value) {
+			return sortValue{numeric: true, num: float64(i), text: value}
+		}
+	}
+	return sortValue{text: value}
+}
+
+func legacyPrioritySortValue(p string) sortValue {
+	switch strings.ToLower(p) {
+	case "high":
+		return sortValue{numeric: true, num: 3}
+	case "medium":
+		return sortValue{numeric: true, num: 2}
+	case "low":
+		return sortValue{numeric: true, num: 1}
+	case "":
+		return sortValue{missing: true}
+	}
+	return sortValue{numeric: true, num: 0}
+}
+
+func fieldByName(fields []Field, name string) (Field, bool) {
+	for _, f := range fields {
+		if strings.EqualFold(strings.TrimSpace(f.Name), strings.TrimSpace(name)) {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// progressRatio parses sub-issue progress such as "2/5".
+func progressRatio(s string) float64 {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0
+	}
+	var done, total float64
+	if _, err := fmt.Sscan(parts[0], &done); err != nil {
+		return 0
+	}
+	if _, err := fmt.Sscan(parts[1], &total); err != nil || total == 0 {
+		return 0
+	}
+	return done / total
 }
@@ -0,0 +1,78 @@
+package state
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func sortedIDs(items []Item) string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestApplyTableSortByMultipleKeys(t *testing.T) {
+	fields := []Field{
+		{Name: "Status", Type: FieldTypeSingleSelect, Options: []Option{{Name: "Todo"}, {Name: "In Progress"}, {Name: "Done"}}},
+	}
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	items := []Item{
+		{ID: "1", Status: "Done", Priority: "High", UpdatedAt: day(1)},
+		{ID: "2", Status: "Todo", Priority: "Low", UpdatedAt: day(2)},
+		{ID: "3", Status: "Todo", Priority: "High", UpdatedAt: day(3)},
+		{ID: "4", Status: "In Progress", Priority: "Medium"},
+		{ID: "5", Status: "Todo", Priority: "High", UpdatedAt: day(5)},
+		{ID: "6", Priority: "High"},
+	}
+
+	got := ApplyTableSort(items, fields, ParseTableSort("Status, -Priority, -UpdatedAt"))
+	if ids := sortedIDs(got); ids != "5,3,2,4,1,6" {
+		t.Fatalf("expected status option order, then priority, then newest first, got %s", ids)
+	}
+	got = ApplyTableSort(items, fields, ParseTableSort("-Status"))
+	if ids := sortedIDs(got); !strings.HasPrefix(ids, "1,4,") || !strings.HasSuffix(ids, ",6") {
+		t.Fatalf("expected descending status with the empty status last, got %s", ids)
+	}
+}
+
+func TestApplyTableSortByProjectFields(t *testing.T) {
+	fields := []Field{
+		{Name: "Size", Type: FieldTypeSingleSelect, Options: []Option{{Name: "S"}, {Name: "M"}, {Name: "L"}}},
+		{Name: "Estimate", Type: FieldTypeNumber},
+	}
+	items := []Item{
+		{ID: "1", FieldValues: map[string][]string{"Size": {"L"}, "Estimate": {"10"}}},
+		{ID: "2", FieldValues: map[string][]string{"Size": {"S"}, "Estimate": {"2"}}},
+		{ID: "3", FieldValues: map[string][]string{"Size": {"M"}}},
+		{ID: "4", FieldValues: map[string][]string{"Estimate": {"3"}}},
+	}
+
+	if ids := sortedIDs(ApplyTableSort(items, fields, ParseTableSort("Size"))); ids != "2,3,1,4" {
+		t.Fatalf("expected size option order, got %s", ids)
+	}
+	if ids := sortedIDs(ApplyTableSort(items, fields, ParseTableSort("-estimate"))); ids != "1,4,2,3" {
+		t.Fatalf("expected estimates compared as numbers, got %s", ids)
+	}
+}
+
+func TestParseTableSortRoundTrip(t *testing.T) {
+	ts := ParseTableSort(" Status ,-Priority, +Estimate,, ")
+	if ts.Field != "Status" || !ts.Asc || len(ts.Then) != 2 || ts.Then[0] != (SortKey{Field: "Priority"}) || ts.Then[1] != (SortKey{Field: "Estimate", Asc: true}) {
+		t.Fatalf("unexpected sort %+v", ts)
+	}
+	if got := ts.String(); got != "Status, -Priority, Estimate" {
+		t.Fatalf("unexpected string %q", got)
+	}
+	if ts := ParseTableSort("-Title"); ts.Field != "Title" || ts.Asc || ts.Then != nil {
+		t.Fatalf("unexpected single key sort %+v", ts)
+	}
+	if ts := ParseTableSort(" , "); ts.Field != "" || ts.String() != "" {
+		t.Fatalf("expected no sort, got %+v", ts)
+	}
+}
@@ -37,6 +37,14 @@ const (
 	ModeCreateIssueRepo  ViewMode = "createIssueRepo"
 	ModeCreateIssueTitle ViewMode = "createIssueTitle"
 	ModeCreateIssueBody  ViewMode = "createIssueBody"
+	ModeConflicts        ViewMode = "conflicts"
+	ModeFieldEdit        ViewMode = "fieldEdit"
+	ModeIterationSelect  ViewMode = "iterationSelect"
+	ModeChecklist        ViewMode = "checklist"
+	ModeViewPicker       ViewMode = "viewPicker"
+	ModeSaveView         ViewMode = "saveView"
+	ModeSearch           ViewMode = "search"
+	ModeSortInput        ViewMode = "sortInput"
 )
 
 // ViewType represents the active view.
@@ -46,6 +54,10 @@ const (
 	ViewBoard    ViewType = "board"
 	ViewTable    ViewType = "table"
 	ViewSettings ViewType = "settings"
+	ViewDigest   ViewType = "digest"
+	ViewPlanning ViewType = "planning"
+	ViewRoadmap  ViewType = "roadmap"
+	ViewInsights ViewType = "insights"
 )
 
 // FilterState captures parsed filter tokens and raw query.
@@ -58,21 +70,26 @@ type FilterState struct {
 	Iterations   []string
 	GroupBy      string
 	FieldFilters map[string][]string
+	Expr         FilterExpr // Parsed query; nil when it has no terms
+	Err          error      // Why Raw could not be parsed
 }
 
-// TableSort captures table ordering preferences.
+// TableSort captures table ordering preferences: the primary field and the
+// keys that break its ties.
 type TableSort struct {
 	Field string
 	Asc   bool
+	Then  []SortKey
 }
 
 // Timeline represents an iteration or timebox.
 type Timeline struct {
-	ID       string
-	Name     string
-	Start    *time.Time
-	End      *time.Time
-	Progress string
+	ID        string
+	Name      string
+	Start     *time.Time
+	End       *time.Time // Exclusive: the day after the timebox's last day
+	Progress  string
+	Completed bool // GitHub lists the iteration as completed
 }
 
 // Item is a single project card/issue in any view.
@@ -83,6 +100,7 @@ type Item struct {
 	Title                 string
 	Description           string
 	Status                string
+	State                 string // OPEN, CLOSED or MERGED for issues and pull requests; empty for drafts
 	Repository            string
 	Number                int    // Issue or PR number
 	URL                   string // URL to the issue or PR
@@ -91,7 +109,7 @@ type Item struct {
 	Milestone             string
 	Priority              string
 	CreatedAt             *time.Time
-	UpdatedAt             *time.Time
+	UpdatedAt             *time.Time // When the item or its issue or pull request last changed
 	Due                   *time.Time
 	IterationID           string
 	IterationName         string
@@ -105,6 +123,12 @@ type Item struct {
 	FieldValues           map[string][]string
 }
 
+// Closed reports whether the item's issue or pull request is closed or
+// merged. Draft issues are never closed.
+func (i Item) Closed() bool {
+	return i.State == "CLOSED" || i.State == "MERGED"
+}
+
 // Project metadata and available capabilities.
 type Project struct {
 	ID         string
@@ -114,14 +138,29 @@ type Project struct {
 	Views      []ViewType
 	Filters    []string
 	Iterations []Timeline
-	Fields     []Field // Added to store project fields like "Status"
+	Fields     []Field     // Added to store project fields like "Status"
+	SavedViews []SavedView // Views saved on the project in GitHub, with their filters
 	UpdatedAt  *time.Time
 }
 
+// FieldType is a project field's data type as GitHub reports it.
+type FieldType string
+
+const (
+	FieldTypeSingleSelect FieldType = "SINGLE_SELECT"
+	FieldTypeIteration    FieldType = "ITERATION"
+	FieldTypeDate         FieldType = "DATE"
+	FieldTypeNumber       FieldType = "NUMBER"
+	FieldTypeText         FieldType = "TEXT"
+	FieldTypeLabels       FieldType = "LABELS"
+	FieldTypeMilestone    FieldType = "MILESTONE"
+)
+
 // Field represents a project field (e.g., "Status").
 type Field struct {
 	ID      string
 	Name    string
+	Type    FieldType // Empty when the backend did not report it
 	Options []Option
 }
 
@@ -142,7 +181,8 @@ const (
 	ColumnParentIssue      = 6
 	ColumnPriority         = 7
 	ColumnAssignees        = 8
-	ColumnCount            = 9
+	ColumnIteration        = 9
+	ColumnCount            = 10
 )
 
 // ViewContext holds transient UI state.
@@ -156,6 +196,17 @@ type ViewContext struct {
 	TableSort           TableSort
 	TableGroupBy        string
 	CardFieldVisibility CardFieldVisibility
+	ConflictIndex       int // Selected entry in the conflict resolution panel
+	DigestIndex         int // Selected entry in the digest view
+	PlanningPane        int // 0 for the backlog, 1 for the next iteration
+	PlanningIndex       int // Selected item in the focused planning pane
+	RoadmapZoom         RoadmapZoom
+	RoadmapOffset       int             // Days the roadmap is scrolled from today
+	RoadmapIndex        int             // Selected row in the roadmap view
+	Selection           map[string]bool // Item IDs picked for bulk edits
+	SelectionAnchor     string          // Item a range selection extends from
+	ViewPickerIndex     int             // Selected entry in the saved view picker
+	SearchIndex         int             // Selected result in search mode
 }
 
 // Notification represents a non-blocking message to the user.
@@ -174,6 +225,7 @@ type CardFieldVisibility struct {
 	ShowSubIssueProgress bool
 	ShowParentIssue      bool
 	ShowLabels           bool
+	ShowIteration        bool
 }
 
 // DefaultCardFieldVisibility returns the default visibility settings.
@@ -184,6 +236,7 @@ func DefaultCardFieldVisibility() CardFieldVisibility {
 		ShowSubIssueProgress: false,
 		ShowParentIssue:      false,
 		ShowLabels:           true,
+		ShowIteration:        false,
 	}
 }
 
@@ -199,6 +252,7 @@ type Card struct {
 	Repository       string
 	SubIssueProgress string // e.g., "2/5" showing completed/total sub-issues
 	ParentIssue      string // Parent issue title or reference
+	Iteration        string
 }
 
 // Column represents a column in the Kanban board.
@@ -219,4 +273,31 @@ type Model struct {
 	SuppressHints       bool
 	ExcludeDone         bool
 	CreateIssueRepoMode CreateIssueRepoMode
+	CachePath           string     // Where fetched snapshots are persisted; empty disables caching
+	Stale               bool       // Items come from the on-disk cache and have not been refreshed yet
+	CachedAt            *time.Time // When the cached snapshot was saved
+	Loading             bool       // A refresh is in flight
+	ItemQueryRejected   bool       // GitHub does not accept the items query; every filter term runs locally
+	ItemsNarrowed       bool       // Items were fetched with a filter query and are not the whole project
+	JournalPath         string     // Where queued offline mutations are persisted
+	HistoryPath         string     // Where refresh samples for the insights view are persisted
+	Offline             bool       // GitHub is unreachable; edits are queued instead of sent
+	OfflineSince        *time.Time // When connectivity was lost
+	Syncing             bool       // A queued mutation is being replayed
+	PendingMutations    []Mutation
+	Conflicts           []MutationConflict
+	UndoStack           []UndoEntry             // Edits that can be undone, most recent last
+	RedoStack           []UndoEntry             // Undone edits that can be redone, most recent last
+	RefreshInterval     time.Duration           // How often to refresh automatically; zero disables
+	Highlights          map[string]ChangeKind   // Items changed by the latest refresh, by item ID
+	Baseline            []Item                  // Items as the previous session left them, from the cache
+	BaselineAt          *time.Time              // When the previous session last saved its snapshot
+	Digest              []DigestEntry           // Changes since the baseline
+	DigestComments      map[string][]Comment    // Comments fetched for the digest, by item ID
+	SearchComments      map[string][]Comment    // Comments fetched for full-text search, by item ID; nil while in flight
+	PlanningCapacity    float64                 // Estimate each person can take on per iteration; zero hides capacity
+	History             []StatusSample          // One sample per day of refreshes, oldest first
+	Repos               map[string]RepoMetadata // Labels, milestones and assignable users by repository
+	SavedViews          []SavedView             // Named views from the config file
+	PendingView         string                  // View asked for on the command line, opened once the project's GitHub views are fetched
 }
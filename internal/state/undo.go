@@ -0,0 +1,119 @@
+package state
+
+import "fmt"
+
+// MaxUndoEntries bounds how many edits can be undone.
+const MaxUndoEntries = 100
+
+// UndoEntry is one edit that can be undone or redone: a single mutation, or
+// every mutation of a bulk edit. Each mutation keeps the item as it was before
+// the edit; Apply gives the item as the edit left it.
+type UndoEntry struct {
+	Mutations []Mutation
+}
+
+// PushUndo adds entry on top of stack, forgetting the oldest entries beyond
+// MaxUndoEntries.
+func PushUndo(stack []UndoEntry, entry UndoEntry) []UndoEntry {
+	stack = append(stack, entry)
+	if len(stack) > MaxUndoEntries {
+		stack = append([]UndoEntry(nil), stack[len(stack)-MaxUndoEntries:]...)
+	}
+	return stack
+}
+
+// DropUndo removes the mutation with the given ID from stack, along with
+// entries it leaves empty. Edits that never reached GitHub are not undone.
+func DropUndo(stack []UndoEntry, id string) []UndoEntry {
+	var kept []UndoEntry
+	for _, entry := range stack {
+		var mutations []Mutation
+		for _, m := range entry.Mutations {
+			if m.ID != id {
+				mutations = append(mutations, m)
+			}
+		}
+		if len(mutations) > 0 {
+			kept = append(kept, UndoEntry{Mutations: mutations})
+		}
+	}
+	return kept
+}
+
+// Reversible reports whether the change can be taken back. GitHub offers no
+// way to remove a comment once it is posted.
+func (m Mutation) Reversible() bool {
+	return m.Kind != MutationComment
+}
+
+// Inverse returns the mutation that puts back the value m replaced, made on
+// current, the item as it is now. The caller assigns the ID and QueuedAt.
+// Single-select values are matched by name against the options of fields, so
+// a value that is no longer an option cannot be restored.
+func (m Mutation) Inverse(current Item, fields []Field) (Mutation, error) {
+	if !m.Reversible() {
+		return Mutation{}, fmt.Errorf("comments cannot be removed")
+	}
+	inv := m
+	inv.Before = current
+	switch m.Kind {
+	case MutationStatus, MutationField, MutationValue:
+		return inv.restoreField(fields, m.Value(m.Before))
+	case MutationIteration:
+		inv.Iteration = ItemIteration(m.Before)
+	case MutationItem:
+		inv.Title = m.Before.Title
+		inv.Text, inv.ClearText = "", false
+		if m.SetsText() {
+			inv.Text = m.Before.Description
+			inv.ClearText = inv.Text == ""
+		}
+	case MutationAssignees:
+		inv.Values = append([]string(nil), m.Before.Assignees...)
+	case MutationLabels:
+		inv.Values = append([]string(nil), m.Before.Labels...)
+	case MutationMilestone:
+		inv.Text = m.Before.Milestone
+	case MutationBody:
+		inv.Text = m.Before.Description
+	}
+	return inv, nil
+}
+
+// restoreField turns m into the write that sets its field to value: an option
+// for single-select fields, a plain value otherwise, and a clear when value is
+// empty.
+func (m Mutation) restoreField(fields []Field, value string) (Mutation, error) {
+	var field Field
+	for _, f := range fields {
+		if f.ID == m.FieldID {
+			field = f
+			break
+		}
+	}
+	if field.ID == "" && m.Kind != MutationValue {
+		return Mutation{}, fmt.Errorf("the field no longer exists")
+	}
+	if m.FieldName == "" {
+		m.FieldName = field.Name
+	}
+
+	if value == "" || len(field.Options) == 0 {
+		m.Kind = MutationValue
+		m.Text = value
+		m.OptionID, m.OptionName = "", ""
+		return m, nil
+	}
+	for _, opt := range field.Options {
+		if opt.Name == value {
+			m.Kind = MutationField
+			if m.FieldName == "Status" {
+				m.Kind = MutationStatus
+			}
+			m.OptionID, m.OptionName = opt.ID, opt.Name
+			m.Text = ""
+			return m, nil
+		}
+	}
+	return Mutation{}, fmt.Errorf("%s %q is no longer an option", m.FieldName, value)
+}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMutationInverse(t *testing.T) {
+	fields := []Field{
+		{ID: "F_status", Name: "Status", Type: FieldTypeSingleSelect, Options: []Option{{ID: "opt-todo", Name: "Todo"}, {ID: "opt-done", Name: "Done"}}},
+		{ID: "F_due", Name: "Due", Type: FieldTypeDate},
+	}
+	before := Item{ID: "PVTI_1", Status: "Todo", Labels: []string{"bug"}, FieldValues: map[string][]string{"Due": {"2026-10-01"}}}
+
+	status := Mutation{Kind: MutationStatus, Before: before, FieldID: "F_status", OptionID: "opt-done", OptionName: "Done"}
+	inv, err := status.Inverse(status.Apply(before), fields)
+	if err != nil || inv.Kind != MutationStatus || inv.OptionID != "opt-todo" || inv.Before.Status != "Done" {
+		t.Fatalf("expected a status change back to Todo, got %+v (%v)", inv, err)
+	}
+	if redo, _ := inv.Inverse(inv.Apply(inv.Before), fields); redo.OptionID != "opt-done" {
+		t.Fatalf("expected the inverse of the inverse to redo the change, got %+v", redo)
+	}
+
+	unset := status
+	unset.Before.Status = ""
+	if inv, _ := unset.Inverse(unset.Apply(unset.Before), fields); inv.Kind != MutationValue || inv.Text != "" || inv.FieldName != "Status" {
+		t.Fatalf("expected a status that was empty to be cleared, got %+v", inv)
+	}
+
+	removed := status
+	removed.Before.Status = "Archived"
+	if _, err := removed.Inverse(removed.Apply(removed.Before), fields); err == nil || !strings.Contains(err.Error(), "no longer an option") {
+		t.Fatalf("expected an error for a removed option, got %v", err)
+	}
+
+	due := Mutation{Kind: MutationValue, Before: before, FieldID: "F_due", FieldName: "Due", FieldType: FieldTypeDate, Text: "2026-11-01"}
+	if inv, _ := due.Inverse(due.Apply(before), fields); inv.Text != "2026-10-01" {
+		t.Fatalf("expected the previous date restored, got %+v", inv)
+	}
+
+	labels := Mutation{Kind: MutationLabels, Before: before, Values: []string{"bug", "ui"}}
+	if inv, _ := labels.Inverse(labels.Apply(before), fields); strings.Join(inv.Values, ",") != "bug" {
+		t.Fatalf("expected the previous labels restored, got %v", inv.Values)
+	}
+
+	edit := Mutation{Kind: MutationItem, Before: Item{ID: "PVTI_1", Title: "Draft"}, Title: "Draft", Text: "Body"}
+	edited := edit.Apply(edit.Before)
+	inv, err = edit.Inverse(edited, fields)
+	if err != nil || !inv.ClearText || inv.Text != "" || !inv.SetsText() {
+		t.Fatalf("expected an edit of an empty description to clear it on undo, got %+v (%v)", inv, err)
+	}
+	if restored := inv.Apply(edited); restored.Description != "" || restored.Title != "Draft" {
+		t.Fatalf("expected the description emptied again, got %+v", restored)
+	}
+	if redo, _ := inv.Inverse(inv.Apply(edited), fields); redo.ClearText || redo.Text != "Body" {
+		t.Fatalf("expected redo to set the description again, got %+v", redo)
+	}
+	retitle := Mutation{Kind: MutationItem, Before: Item{ID: "PVTI_1", Title: "Draft", Description: "Body"}, Title: "Renamed"}
+	if inv, _ := retitle.Inverse(retitle.Apply(retitle.Before), fields); inv.SetsText() || inv.Title != "Draft" {
+		t.Fatalf("expected a title-only edit to leave the description alone on undo, got %+v", inv)
+	}
+
+	comment := Mutation{Kind: MutationComment, Before: before, Text: "LGTM"}
+	if _, err := comment.Inverse(before, fields); err == nil {
+		t.Fatalf("expected comments to be irreversible")
+	}
+}
+
+func TestPushAndDropUndo(t *testing.T) {
+	var stack []UndoEntry
+	for i := 0; i < MaxUndoEntries+5; i++ {
+		stack = PushUndo(stack, UndoEntry{Mutations: []Mutation{{ID: string(rune('a' + i%26))}}})
+	}
+	if len(stack) != MaxUndoEntries {
+		t.Fatalf("expected the stack capped at %d, got %d", MaxUndoEntries, len(stack))
+	}
+
+	stack = []UndoEntry{{Mutations: []Mutation{{ID: "a"}, {ID: "b"}}}, {Mutations: []Mutation{{ID: "c"}}}}
+	stack = DropUndo(stack, "c")
+	stack = DropUndo(stack, "a")
+	if len(stack) != 1 || len(stack[0].Mutations) != 1 || stack[0].Mutations[0].ID != "b" {
+		t.Fatalf("unexpected stack after drops: %+v", stack)
+	}
+}
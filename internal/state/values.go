@@ -0,0 +1,46 @@
+package state
+
+import "strings"
+
+// DiffValues compares a multi-valued field such as labels or assignees and
+// returns what must be added to current and removed from it to reach desired.
+// Names compare case-insensitively, as GitHub treats them.
+func DiffValues(current, desired []string) (add, remove []string) {
+	for _, v := range desired {
+		if !containsFold(current, v) && !containsFold(add, v) {
+			add = append(add, v)
+		}
+	}
+	for _, v := range current {
+		if !containsFold(desired, v) {
+			remove = append(remove, v)
+		}
+	}
+	return add, remove
+}
+
+// PatchValues returns values without those in remove and with those in add
+// appended, keeping the order of what stays.
+func PatchValues(values, add, remove []string) []string {
+	var out []string
+	for _, v := range values {
+		if !containsFold(remove, v) && !containsFold(out, v) {
+			out = append(out, v)
+		}
+	}
+	for _, v := range add {
+		if !containsFold(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func containsFold(values []string, v string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package state
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	add, remove := DiffValues([]string{"bug", "UI", "p1"}, []string{"ui", "docs", "docs"})
+	if strings.Join(add, ",") != "docs" || strings.Join(remove, ",") != "bug,p1" {
+		t.Fatalf("unexpected diff: add=%v remove=%v", add, remove)
+	}
+	if add, remove := DiffValues([]string{"alice"}, []string{"Alice"}); len(add) != 0 || len(remove) != 0 {
+		t.Fatalf("expected no change for a case difference, got add=%v remove=%v", add, remove)
+	}
+}
+
+func TestPatchValues(t *testing.T) {
+	got := PatchValues([]string{"bug", "ui", "p1"}, []string{"docs", "UI"}, []string{"bug"})
+	if strings.Join(got, ",") != "ui,p1,docs" {
+		t.Fatalf("unexpected patched values %v", got)
+	}
+}
@@ -22,12 +22,16 @@ func SaveCardFieldVisibility(vis state.CardFieldVisibility) error {
 		DefaultExcludeDone:      existing.DefaultExcludeDone,
 		CreateIssueRepoMode:     existing.CreateIssueRepoMode,
 		DefaultIterationFilters: existing.DefaultIterationFilters,
+		RefreshInterval:         existing.RefreshInterval,
+		PlanningCapacity:        existing.PlanningCapacity,
+		SavedViews:              existing.SavedViews,
 		CardFieldVisibility: config.CardFieldVisibility{
 			ShowMilestone:        vis.ShowMilestone,
 			ShowRepository:       vis.ShowRepository,
 			ShowSubIssueProgress: vis.ShowSubIssueProgress,
 			ShowParentIssue:      vis.ShowParentIssue,
 			ShowLabels:           vis.ShowLabels,
+			ShowIteration:        vis.ShowIteration,
 		},
 	}
 	return config.Save(configPath, cfg)
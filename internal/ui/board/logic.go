@@ -48,6 +48,7 @@ func groupItemsByStatus(items []state.Item, fields []state.Field) []state.Column
 				Repository:       item.Repository,
 				SubIssueProgress: item.SubIssueProgress,
 				ParentIssue:      item.ParentIssue,
+				Iteration:        item.IterationName,
 			}
 			statusCardMap[status] = append(statusCardMap[status], card)
 		}
@@ -17,6 +17,9 @@ type BoardModel struct {
 	ColumnOffset       int
 	CardOffset         int
 	FieldVisibility    state.CardFieldVisibility
+	Highlights         map[string]state.ChangeKind   // Cards changed by the latest refresh
+	Selected           map[string]bool               // Cards picked for bulk edits
+	Repos              map[string]state.RepoMetadata // Label colours by repository
 }
 
 func NewBoardModel(items []state.Item, fields []state.Field, filter state.FilterState, focusedItemID string, fieldVisibility state.CardFieldVisibility) BoardModel {
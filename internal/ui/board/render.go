@@ -21,6 +21,10 @@ func (m BoardModel) renderCard(c state.Card, isSelected bool) string {
 	style := components.CardBaseStyle.Copy()
 	if isSelected {
 		style = components.CardSelectedStyle.Copy()
+	} else if m.Selected[c.ID] {
+		style = style.BorderForeground(components.ColorPurple400)
+	} else if kind, ok := m.Highlights[c.ID]; ok {
+		style = style.BorderForeground(components.ChangeColor(kind))
 	}
 	contentWidth := m.ColumnWidth - style.GetHorizontalFrameSize()
 	if contentWidth < 12 {
@@ -48,7 +52,11 @@ func (m BoardModel) renderCard(c state.Card, isSelected bool) string {
 		return clampRenderedLines(padded, maxLines, contentWidth)
 	}
 
-	title := wrapTitle(c.Title, maxTitleLines)
+	cardTitle := c.Title
+	if m.Selected[c.ID] {
+		cardTitle = "✔ " + cardTitle
+	}
+	title := wrapTitle(cardTitle, maxTitleLines)
 
 	var contentBlocks []string
 	if title != "" {
@@ -63,7 +71,7 @@ func (m BoardModel) renderCard(c state.Card, isSelected bool) string {
 	}
 
 	if m.FieldVisibility.ShowLabels && len(c.Labels) > 0 {
-		labels := wrap("["+strings.Join(c.Labels, ", ")+"]", maxMetaLines, isSelected)
+		labels := wrap(m.renderLabels(c, cardBg), maxMetaLines, isSelected)
 		if labels != "" {
 			contentBlocks = append(contentBlocks, labels)
 		}
@@ -115,6 +123,13 @@ func (m BoardModel) renderCard(c state.Card, isSelected bool) string {
 		}
 	}
 
+	if m.FieldVisibility.ShowIteration && c.Iteration != "" {
+		iteration := wrap("I: "+c.Iteration, maxMetaLines, isSelected)
+		if iteration != "" {
+			contentBlocks = append(contentBlocks, iteration)
+		}
+	}
+
 	if len(contentBlocks) == 0 {
 		contentBlocks = append(contentBlocks, "(no title)")
 	}
@@ -159,3 +174,19 @@ func truncateLineWithEllipsis(line string, width int) string {
 	}
 	return trimmed + ellipsis
 }
+
+// renderLabels draws the labels of c as chips in their repository colours.
+// Until the colours are known they are listed as plain text.
+func (m BoardModel) renderLabels(c state.Card, cardBg lipgloss.Color) string {
+	meta, ok := m.Repos[c.Repository]
+	if !ok || len(meta.Labels) == 0 {
+		return "[" + strings.Join(c.Labels, ", ") + "]"
+	}
+	gap := lipgloss.NewStyle().Background(cardBg).Render(" ")
+	chips := make([]string, 0, len(c.Labels))
+	for _, name := range c.Labels {
+		style := components.LabelStyle(meta.LabelColor(name))
+		chips = append(chips, style.Render(" "+name+" "))
+	}
+	return strings.Join(chips, gap)
+}
@@ -604,3 +604,17 @@ func TestRenderColumnHeaderFitsColumnWidth(t *testing.T) {
 		t.Fatalf("expected rendered header width %d, got %d", board.ColumnWidth, got)
 	}
 }
+
+func TestRenderLabelsUsesRepositoryColours(t *testing.T) {
+	card := state.Card{Repository: "acme/app", Labels: []string{"bug", "docs"}}
+	m := BoardModel{}
+	if got := m.renderLabels(card, lipgloss.Color("#000000")); got != "[bug, docs]" {
+		t.Fatalf("expected plain labels without colours, got %q", got)
+	}
+
+	m.Repos = map[string]state.RepoMetadata{"acme/app": {Labels: []state.Label{{Name: "bug", Color: "d73a4a"}}}}
+	got := m.renderLabels(card, lipgloss.Color("#000000"))
+	if strings.Contains(got, "[") || !strings.Contains(got, " bug ") || !strings.Contains(got, " docs ") {
+		t.Fatalf("expected label chips, got %q", got)
+	}
+}
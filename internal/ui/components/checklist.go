@@ -0,0 +1,308 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"project-hub/internal/state"
+)
+
+// ChecklistConfirmedMsg is sent when a checklist is saved or closed. Add and
+// Remove hold the values whose box was ticked or cleared; values left as they
+// were are in neither.
+type ChecklistConfirmedMsg struct {
+	Kind     state.MutationKind
+	Add      []string
+	Remove   []string
+	Canceled bool
+}
+
+type checkState int
+
+const (
+	checkNone checkState = iota
+	checkAll
+	checkSome // Set on some of the edited items only
+)
+
+// checklistVisibleRows bounds how many options are listed at once.
+const checklistVisibleRows = 12
+
+var checklistHintStyle = lipgloss.NewStyle().Foreground(ColorGray500)
+
+// ChecklistModel edits a multi-valued field such as labels or assignees of one
+// or more items. Values set on only some of the items start partially
+// checked and are left alone unless toggled.
+type ChecklistModel struct {
+	Kind state.MutationKind
+
+	title   string
+	options []string
+	initial map[string]checkState // Keyed by lower-case value
+	checks  map[string]checkState
+	pending map[string]bool // Repositories whose values are still loading
+	errs    []string
+	query   string // Typed to narrow the options by fuzzy match
+	cursor  int
+	width   int
+}
+
+// NewChecklistModel creates a checklist for the items whose current values
+// are given, one slice per item. The values of each repository in repos are
+// expected through Loaded; until then only the current values are listed.
+func NewChecklistModel(kind state.MutationKind, title string, values [][]string, repos []string, width int) ChecklistModel {
+	m := ChecklistModel{
+		Kind:    kind,
+		title:   title,
+		initial: map[string]checkState{},
+		checks:  map[string]checkState{},
+		pending: map[string]bool{},
+		width:   width,
+	}
+	counts := map[string]int{}
+	for _, itemValues := range values {
+		seen := map[string]bool{}
+		for _, v := range itemValues {
+			key := strings.ToLower(v)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if counts[key] == 0 {
+				m.options = append(m.options, v)
+			}
+			counts[key]++
+		}
+	}
+	for key, n := range counts {
+		check := checkSome
+		if n == len(values) {
+			check = checkAll
+		}
+		m.initial[key] = check
+		m.checks[key] = check
+	}
+	for _, repo := range repos {
+		m.pending[repo] = true
+	}
+	m.sortOptions()
+	return m
+}
+
+// Loaded adds the values available in repo to the list, or records why they
+// could not be fetched. Results for repositories the checklist is not waiting
+// on are ignored.
+func (m ChecklistModel) Loaded(repo string, values []string, err error) ChecklistModel {
+	if !m.pending[repo] {
+		return m
+	}
+	delete(m.pending, repo)
+	if err != nil {
+		m.errs = append(m.errs, fmt.Sprintf("%s: %v", repo, err))
+		return m
+	}
+	current := m.Current()
+	for _, v := range values {
+		key := strings.ToLower(v)
+		if _, ok := m.checks[key]; ok {
+			continue
+		}
+		m.options = append(m.options, v)
+		m.checks[key] = checkNone
+	}
+	m.sortOptions()
+	for i, v := range m.visible() {
+		if v == current {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
+// Loading reports whether values are still being fetched.
+func (m ChecklistModel) Loading() bool {
+	return len(m.pending) > 0
+}
+
+// Current returns the highlighted value.
+func (m ChecklistModel) Current() string {
+	visible := m.visible()
+	if m.cursor < 0 || m.cursor >= len(visible) {
+		return ""
+	}
+	return visible[m.cursor]
+}
+
+// visible returns the options matching the typed query, best match first.
+func (m ChecklistModel) visible() []string {
+	if m.query == "" {
+		return m.options
+	}
+	return state.FuzzyRank(m.query, m.options)
+}
+
+// Changes returns the values ticked and cleared since the checklist opened.
+func (m ChecklistModel) Changes() (add, remove []string) {
+	for _, v := range m.options {
+		key := strings.ToLower(v)
+		if m.checks[key] == m.initial[key] {
+			continue
+		}
+		switch m.checks[key] {
+		case checkAll:
+			add = append(add, v)
+		case checkNone:
+			remove = append(remove, v)
+		}
+	}
+	return add, remove
+}
+
+func (m *ChecklistModel) sortOptions() {
+	sort.SliceStable(m.options, func(i, j int) bool {
+		return strings.ToLower(m.options[i]) < strings.ToLower(m.options[j])
+	})
+}
+
+// toggle cycles the highlighted value between checked and unchecked, passing
+// through its original partial state when it started as one.
+func (m *ChecklistModel) toggle() {
+	key := strings.ToLower(m.Current())
+	if key == "" {
+		return
+	}
+	switch m.checks[key] {
+	case checkAll:
+		m.checks[key] = checkNone
+	case checkNone:
+		if m.initial[key] == checkSome {
+			m.checks[key] = checkSome
+		} else {
+			m.checks[key] = checkAll
+		}
+	default:
+		m.checks[key] = checkAll
+	}
+}
+
+func (m ChecklistModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ChecklistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "ctrl+n":
+		if m.cursor < len(m.visible())-1 {
+			m.cursor++
+		}
+	case "tab":
+		m.toggle()
+	case " ":
+		if m.query == "" {
+			m.toggle()
+		} else {
+			m.query += " "
+			m.cursor = 0
+		}
+	case "backspace":
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.cursor = 0
+		}
+	case "enter":
+		kind := m.Kind
+		add, remove := m.Changes()
+		return m, func() tea.Msg {
+			return ChecklistConfirmedMsg{Kind: kind, Add: add, Remove: remove}
+		}
+	case "esc":
+		if m.query != "" {
+			m.query = ""
+			m.cursor = 0
+			return m, nil
+		}
+		kind := m.Kind
+		return m, func() tea.Msg {
+			return ChecklistConfirmedMsg{Kind: kind, Canceled: true}
+		}
+	default:
+		if keyMsg.Type == tea.KeyRunes {
+			m.query += string(keyMsg.Runes)
+			m.cursor = 0
+		}
+	}
+	return m, nil
+}
+
+func (m ChecklistModel) View() string {
+	var s strings.Builder
+	s.WriteString(m.title + "\n")
+	if m.query != "" {
+		s.WriteString("Filter: " + m.query + "\n")
+	}
+	s.WriteString("\n")
+
+	options := m.visible()
+
+	start := 0
+	if m.cursor >= checklistVisibleRows {
+		start = m.cursor - checklistVisibleRows + 1
+	}
+	end := start + checklistVisibleRows
+	if end > len(options) {
+		end = len(options)
+	}
+	for i := start; i < end; i++ {
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+		box := "[ ]"
+		switch m.checks[strings.ToLower(options[i])] {
+		case checkAll:
+			box = "[x]"
+		case checkSome:
+			box = "[-]"
+		}
+		s.WriteString(fmt.Sprintf("%s %s %s\n", cursor, box, options[i]))
+	}
+	if len(options) > checklistVisibleRows {
+		s.WriteString(checklistHintStyle.Render(fmt.Sprintf("%d–%d of %d", start+1, end, len(options))) + "\n")
+	}
+
+	switch {
+	case m.Loading():
+		s.WriteString(checklistHintStyle.Render("Loading…") + "\n")
+	case len(m.options) == 0:
+		s.WriteString(checklistHintStyle.Render("Nothing to choose from") + "\n")
+	case len(options) == 0:
+		s.WriteString(checklistHintStyle.Render("No match for "+m.query) + "\n")
+	}
+	for _, e := range m.errs {
+		s.WriteString(lipgloss.NewStyle().Foreground(ColorRed400).Render("Could not load "+e) + "\n")
+	}
+	s.WriteString("\n" + checklistHintStyle.Render("type:filter ↑/↓:move tab/space:toggle enter:save esc:cancel"))
+
+	width := m.width / 3
+	if width < 40 {
+		width = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(width).
+		Render(s.String())
+}
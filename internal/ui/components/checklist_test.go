@@ -0,0 +1,75 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+func TestChecklistTogglesAndReportsChanges(t *testing.T) {
+	values := [][]string{{"bug", "ui"}, {"bug"}}
+	m := NewChecklistModel(state.MutationLabels, "Labels of 2 selected items:", values, []string{"acme/app", "acme/web"}, 100)
+	m = m.Loaded("acme/app", []string{"docs", "Bug"}, nil)
+	m = m.Loaded("acme/web", nil, errors.New("not found"))
+
+	view := m.View()
+	for _, want := range []string{"[x] bug", "[ ] docs", "[-] ui", "Could not load acme/web: not found"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in view, got %q", want, view)
+		}
+	}
+
+	press := func(keys ...string) {
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case " ":
+				msg = tea.KeyMsg{Type: tea.KeySpace}
+			case "down":
+				msg = tea.KeyMsg{Type: tea.KeyDown}
+			}
+			model, _ := m.Update(msg)
+			m = model.(ChecklistModel)
+		}
+	}
+	// bug off, docs on, ui through checked and unchecked back to partial.
+	press(" ", "down", " ", "down", " ", " ", " ")
+	add, remove := m.Changes()
+	if strings.Join(add, ",") != "docs" || strings.Join(remove, ",") != "bug" {
+		t.Fatalf("unexpected changes add=%v remove=%v", add, remove)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(ChecklistConfirmedMsg)
+	if !ok || msg.Kind != state.MutationLabels || msg.Canceled || len(msg.Add) != 1 || len(msg.Remove) != 1 {
+		t.Fatalf("unexpected confirmation %+v", msg)
+	}
+}
+
+func TestChecklistFiltersByTypedQuery(t *testing.T) {
+	m := NewChecklistModel(state.MutationLabels, "Labels:", [][]string{{}}, []string{"acme/app"}, 100)
+	m = m.Loaded("acme/app", []string{"bug", "documentation", "good first issue"}, nil)
+
+	for _, r := range "doc" {
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = model.(ChecklistModel)
+	}
+	if m.Current() != "documentation" || strings.Contains(m.View(), "bug") {
+		t.Fatalf("expected only documentation listed, got %q", m.View())
+	}
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = model.(ChecklistModel)
+	if add, _ := m.Changes(); strings.Join(add, ",") != "documentation" {
+		t.Fatalf("expected tab to tick the match, got %v", add)
+	}
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(ChecklistModel)
+	if cmd != nil || !strings.Contains(m.View(), "bug") {
+		t.Fatalf("expected esc to clear the filter first, got %q", m.View())
+	}
+}
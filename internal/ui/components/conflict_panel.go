@@ -0,0 +1,53 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+// RenderConflictPanel lists queued mutations that could not be replayed as-is,
+// showing the queued value next to what GitHub currently reports.
+func RenderConflictPanel(conflicts []state.MutationConflict, selected int, width int) string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("Conflicting changes (%d):\n\n", len(conflicts)))
+
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	for i, c := range conflicts {
+		cursor := " "
+		if i == selected {
+			cursor = ">"
+		}
+		s.WriteString(fmt.Sprintf("%s %s\n", cursor, c.Mutation.Describe()))
+		if c.Mutation.Kind != state.MutationComment {
+			s.WriteString(muted.Render(fmt.Sprintf("    mine: %s", displayValue(c.Mutation.Value(c.Mutation.Apply(c.Remote))))))
+			s.WriteString("\n")
+			s.WriteString(muted.Render(fmt.Sprintf("    theirs: %s", displayValue(c.Mutation.Value(c.Remote)))))
+			s.WriteString("\n")
+		}
+		s.WriteString(muted.Render("    " + c.Reason))
+		s.WriteString("\n")
+	}
+
+	panelWidth := width * 2 / 3
+	if panelWidth < 40 {
+		panelWidth = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorRed400).
+		Padding(1, 2).
+		Width(panelWidth).
+		Render(strings.TrimRight(s.String(), "\n"))
+}
+
+func displayValue(v string) string {
+	v = strings.ReplaceAll(v, "\n", " ")
+	if strings.TrimSpace(v) == "" {
+		return "(empty)"
+	}
+	return v
+}
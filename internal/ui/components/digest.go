@@ -0,0 +1,96 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+var digestMarkers = map[state.DigestChangeKind]string{
+	state.DigestNew:       "+",
+	state.DigestStatus:    "→",
+	state.DigestClosed:    "✓",
+	state.DigestAssignees: "@",
+	state.DigestComment:   "»",
+}
+
+// RenderDigest lists the changes since the previous session grouped by item,
+// scrolled so the selected entry stays within height lines.
+func RenderDigest(entries []state.DigestEntry, selected int, since *time.Time, width, height int) string {
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	if since == nil {
+		return muted.Render("No snapshot from a previous session yet. Changes will be listed here next time.")
+	}
+
+	title := fmt.Sprintf("Changes since %s", since.Local().Format("Mon Jan 2 15:04"))
+	if len(entries) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, HeaderProjectStyle.Render(title), "", muted.Render("Nothing changed."))
+	}
+	title = fmt.Sprintf("%s (%d items)", title, len(entries))
+
+	var lines []string
+	selectedTop, selectedBottom := 0, 0
+	for i, entry := range entries {
+		if i == selected {
+			selectedTop = len(lines)
+		}
+		lines = append(lines, renderDigestItem(entry.Item, i == selected, width))
+		for _, change := range entry.Changes {
+			color := ColorGray300
+			switch change.Kind {
+			case state.DigestNew:
+				color = ColorGreen400
+			case state.DigestClosed:
+				color = ColorPurple400
+			case state.DigestStatus:
+				color = ColorBlue400
+			}
+			line := fmt.Sprintf("    %s %s", digestMarkers[change.Kind], change.Summary)
+			lines = append(lines, lipgloss.NewStyle().Foreground(color).MaxWidth(width).Render(line))
+		}
+		if i == selected {
+			selectedBottom = len(lines)
+		}
+		lines = append(lines, "")
+	}
+
+	visible := height - 2 // title and blank line
+	if visible < 1 {
+		visible = 1
+	}
+	offset := 0
+	if selectedBottom > visible {
+		offset = selectedBottom - visible
+	}
+	if selectedTop < offset {
+		offset = selectedTop
+	}
+	end := offset + visible
+	if end > len(lines) {
+		end = len(lines)
+	}
+	body := strings.Join(lines[offset:end], "\n")
+	return lipgloss.JoinVertical(lipgloss.Left, HeaderProjectStyle.Render(title), "", body)
+}
+
+func renderDigestItem(item state.Item, selected bool, width int) string {
+	cursor := "  "
+	style := lipgloss.NewStyle().Foreground(ColorGray300).Bold(true)
+	if selected {
+		cursor = "> "
+		style = style.Foreground(ColorYellow400)
+	}
+	label := item.Title
+	if item.Number > 0 {
+		label = fmt.Sprintf("#%d %s", item.Number, item.Title)
+	}
+	line := cursor + label
+	if item.Repository != "" {
+		line += lipgloss.NewStyle().Foreground(ColorGray500).Render("  " + item.Repository)
+	}
+	return style.MaxWidth(width).Render(line)
+}
@@ -0,0 +1,263 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"project-hub/internal/state"
+)
+
+// FieldEditedMsg is sent when a field value is confirmed or the editor is closed.
+// An empty Value clears the field.
+type FieldEditedMsg struct {
+	ItemID   string
+	Field    state.Field
+	Value    string
+	Canceled bool
+}
+
+var (
+	fieldEditorSelectedStyle = lipgloss.NewStyle().Reverse(true)
+	fieldEditorTodayStyle    = lipgloss.NewStyle().Underline(true)
+	fieldEditorErrorStyle    = lipgloss.NewStyle().Foreground(ColorRed400)
+	fieldEditorHintStyle     = lipgloss.NewStyle().Foreground(ColorGray500)
+)
+
+// FieldEditorModel edits one date, number or text field of an item: a
+// calendar for dates and a validated text input otherwise. Given several
+// fields it first asks which one to edit.
+type FieldEditorModel struct {
+	Origin state.ViewMode // Mode to return to when the editor closes
+
+	item    state.Item
+	fields  []state.Field
+	current int // Index of the field being edited, -1 while picking
+	cursor  int
+	input   textinput.Model
+	date    time.Time
+	today   time.Time
+	err     string
+	width   int
+}
+
+// NewFieldEditorModel creates an editor for item's fields. today anchors the
+// calendar when a date field is empty.
+func NewFieldEditorModel(item state.Item, fields []state.Field, today time.Time, width int) FieldEditorModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 1024
+	m := FieldEditorModel{
+		item:    item,
+		fields:  fields,
+		current: -1,
+		input:   input,
+		today:   time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC),
+		width:   width,
+	}
+	if len(fields) == 1 {
+		m.choose(0)
+	}
+	return m
+}
+
+// Field returns the field being edited, if one has been chosen.
+func (m FieldEditorModel) Field() (state.Field, bool) {
+	if m.current < 0 || m.current >= len(m.fields) {
+		return state.Field{}, false
+	}
+	return m.fields[m.current], true
+}
+
+// Date returns the day highlighted in the calendar.
+func (m FieldEditorModel) Date() time.Time {
+	return m.date
+}
+
+func (m FieldEditorModel) value(field state.Field) string {
+	return strings.Join(m.item.FieldValues[field.Name], ", ")
+}
+
+func (m *FieldEditorModel) choose(i int) {
+	m.current = i
+	m.err = ""
+	field := m.fields[i]
+	m.date = m.today
+	if d, err := time.Parse(state.DateLayout, m.value(field)); err == nil {
+		m.date = d
+	}
+	m.input.SetValue(m.value(field))
+	m.input.CursorEnd()
+	switch field.Type {
+	case state.FieldTypeNumber:
+		m.input.Placeholder = "Enter a number..."
+	default:
+		m.input.Placeholder = "Enter text..."
+	}
+	m.input.Width = m.width/2 - 8
+	if m.input.Width < 20 {
+		m.input.Width = 20
+	}
+	if field.Type != state.FieldTypeDate {
+		m.input.Focus()
+	}
+}
+
+func (m FieldEditorModel) Init() tea.Cmd {
+	if field, ok := m.Field(); ok && field.Type != state.FieldTypeDate {
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (m FieldEditorModel) done(value string) tea.Cmd {
+	field, _ := m.Field()
+	itemID := m.item.ID
+	return func() tea.Msg {
+		return FieldEditedMsg{ItemID: itemID, Field: field, Value: value}
+	}
+}
+
+func canceled() tea.Msg {
+	return FieldEditedMsg{Canceled: true}
+}
+
+func (m FieldEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	field, chosen := m.Field()
+	if keyMsg.String() == "esc" {
+		return m, canceled
+	}
+
+	switch {
+	case !chosen:
+		switch keyMsg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.fields)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if len(m.fields) == 0 {
+				return m, canceled
+			}
+			m.choose(m.cursor)
+			return m, m.Init()
+		}
+		return m, nil
+	case field.Type == state.FieldTypeDate:
+		switch keyMsg.String() {
+		case "h", "left":
+			m.date = m.date.AddDate(0, 0, -1)
+		case "l", "right":
+			m.date = m.date.AddDate(0, 0, 1)
+		case "k", "up":
+			m.date = m.date.AddDate(0, 0, -7)
+		case "j", "down":
+			m.date = m.date.AddDate(0, 0, 7)
+		case "[", "H":
+			m.date = m.date.AddDate(0, -1, 0)
+		case "]", "L":
+			m.date = m.date.AddDate(0, 1, 0)
+		case "t":
+			m.date = m.today
+		case "x", "backspace", "delete":
+			return m, m.done("")
+		case "enter":
+			return m, m.done(m.date.Format(state.DateLayout))
+		}
+		return m, nil
+	default:
+		if keyMsg.String() == "enter" {
+			value, err := state.NormalizeFieldValue(field.Type, m.input.Value())
+			if err != nil {
+				m.err = err.Error()
+				return m, nil
+			}
+			return m, m.done(value)
+		}
+		m.err = ""
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+}
+
+func (m FieldEditorModel) View() string {
+	var s strings.Builder
+	field, chosen := m.Field()
+	if !chosen {
+		s.WriteString(fmt.Sprintf("Edit field of '%s':\n\n", m.item.Title))
+		for i, f := range m.fields {
+			cursor := " "
+			if m.cursor == i {
+				cursor = ">"
+			}
+			value := m.value(f)
+			if value == "" {
+				value = "-"
+			}
+			s.WriteString(fmt.Sprintf("%s %s: %s\n", cursor, f.Name, value))
+		}
+		s.WriteString("\n" + fieldEditorHintStyle.Render("j/k:move enter:edit esc:cancel"))
+	} else {
+		s.WriteString(fmt.Sprintf("Set %s for '%s':\n\n", field.Name, m.item.Title))
+		if field.Type == state.FieldTypeDate {
+			s.WriteString(m.calendar())
+			s.WriteString("\n" + fieldEditorHintStyle.Render("h/l:day j/k:week [/]:month t:today x:clear enter:save esc:cancel"))
+		} else {
+			s.WriteString(m.input.View())
+			s.WriteString("\n")
+			if m.err != "" {
+				s.WriteString(fieldEditorErrorStyle.Render(m.err))
+			}
+			s.WriteString("\n" + fieldEditorHintStyle.Render("enter:save (empty clears) esc:cancel"))
+		}
+	}
+
+	width := m.width / 2
+	if width < 36 {
+		width = 36
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(width).
+		Render(s.String())
+}
+
+// calendar renders the month around the highlighted day, weeks starting on Monday.
+func (m FieldEditorModel) calendar() string {
+	var s strings.Builder
+	first := time.Date(m.date.Year(), m.date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	s.WriteString(fmt.Sprintf("%s  (%s)\n", first.Format("January 2006"), m.date.Format(state.DateLayout)))
+	s.WriteString("Mo Tu We Th Fr Sa Su\n")
+	offset := (int(first.Weekday()) + 6) % 7
+	s.WriteString(strings.Repeat("   ", offset))
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		switch {
+		case day.Equal(m.date):
+			cell = fieldEditorSelectedStyle.Render(cell)
+		case day.Equal(m.today):
+			cell = fieldEditorTodayStyle.Render(cell)
+		}
+		s.WriteString(cell)
+		if day.Weekday() == time.Sunday {
+			s.WriteString("\n")
+		} else {
+			s.WriteString(" ")
+		}
+	}
+	return strings.TrimRight(s.String(), " \n") + "\n"
+}
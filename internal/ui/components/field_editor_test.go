@@ -0,0 +1,92 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+func pressKeys(t *testing.T, m FieldEditorModel, keys ...string) (FieldEditorModel, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		var model tea.Model
+		model, cmd = m.Update(msg)
+		m = model.(FieldEditorModel)
+	}
+	return m, cmd
+}
+
+func TestFieldEditorDatePicker(t *testing.T) {
+	field := state.Field{ID: "PVTF_target", Name: "Target date", Type: state.FieldTypeDate}
+	item := state.Item{ID: "PVTI_1", Title: "Launch", FieldValues: map[string][]string{"Target date": {"2026-10-30"}}}
+	today := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+
+	m := NewFieldEditorModel(item, []state.Field{field}, today, 100)
+	if got := m.Date().Format(state.DateLayout); got != "2026-10-30" {
+		t.Fatalf("expected calendar to start on the current value, got %s", got)
+	}
+	if view := m.View(); !strings.Contains(view, "October 2026") || !strings.Contains(view, "Mo Tu We") {
+		t.Fatalf("expected month calendar, got %q", view)
+	}
+
+	m, cmd := pressKeys(t, m, "l", "l", "j", "enter")
+	if got := m.Date().Format(state.DateLayout); got != "2026-11-08" {
+		t.Fatalf("expected two days and a week later, got %s", got)
+	}
+	msg, ok := cmd().(FieldEditedMsg)
+	if !ok || msg.ItemID != "PVTI_1" || msg.Field.ID != "PVTF_target" || msg.Value != "2026-11-08" {
+		t.Fatalf("unexpected edit message: %+v", msg)
+	}
+
+	m, _ = pressKeys(t, m, "t")
+	if !m.Date().Equal(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected t to jump to today, got %s", m.Date())
+	}
+	_, cmd = pressKeys(t, m, "x")
+	if msg := cmd().(FieldEditedMsg); msg.Value != "" || msg.Canceled {
+		t.Fatalf("expected x to clear the date, got %+v", msg)
+	}
+}
+
+func TestFieldEditorValidatesNumbers(t *testing.T) {
+	fields := []state.Field{
+		{ID: "PVTF_notes", Name: "Notes", Type: state.FieldTypeText},
+		{ID: "PVTF_estimate", Name: "Estimate", Type: state.FieldTypeNumber},
+	}
+	m := NewFieldEditorModel(state.Item{ID: "PVTI_1", Title: "Launch"}, fields, time.Now(), 100)
+	if _, ok := m.Field(); ok {
+		t.Fatalf("expected a field to be picked first when several are editable")
+	}
+
+	m, _ = pressKeys(t, m, "j", "enter")
+	if field, ok := m.Field(); !ok || field.Name != "Estimate" {
+		t.Fatalf("expected Estimate to be chosen, got %+v", field)
+	}
+	m, cmd := pressKeys(t, m, "t", "w", "o", "enter")
+	if cmd != nil {
+		t.Fatalf("expected invalid number to keep the editor open")
+	}
+	if !strings.Contains(m.View(), "invalid number") {
+		t.Fatalf("expected validation error in view, got %q", m.View())
+	}
+
+	m.input.SetValue("")
+	_, cmd = pressKeys(t, m, "3", ".", "5", "0", "enter")
+	if msg := cmd().(FieldEditedMsg); msg.Field.ID != "PVTF_estimate" || msg.Value != "3.5" {
+		t.Fatalf("expected normalized number, got %+v", msg)
+	}
+}
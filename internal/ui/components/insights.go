@@ -0,0 +1,239 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+// flowColors tell the statuses of the cumulative flow apart; closed statuses
+// are always green.
+var flowColors = []lipgloss.Color{ColorBlue400, ColorYellow400, ColorPurple400, ColorCyan400, ColorRed400, ColorGray400}
+
+// RenderInsights draws the burndown of the current iteration above the
+// cumulative flow of statuses, each taking half of the height.
+func RenderInsights(burndown state.Burndown, flow state.CumulativeFlow, width, height int) string {
+	top := height / 2
+	return lipgloss.JoinVertical(lipgloss.Left,
+		renderBurndown(burndown, width, top),
+		renderCumulativeFlow(flow, width, height-top),
+	)
+}
+
+func renderBurndown(b state.Burndown, width, height int) string {
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	if b.Iteration == nil {
+		return lipgloss.JoinVertical(lipgloss.Left, HeaderProjectStyle.Render("Burndown"), muted.Render("No iteration is running."))
+	}
+	unit := "items"
+	if b.Points {
+		unit = "points"
+	}
+	title := fmt.Sprintf("Burndown · %s (%s) · %s remaining", b.Iteration.Name, b.Iteration.DateRange(), unit)
+	if len(b.Ideal) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, HeaderProjectStyle.Render(title), muted.Render("No refresh recorded during this iteration yet."))
+	}
+
+	series := []chartSeries{
+		{Name: "ideal", Values: b.Ideal, Color: ColorGray500},
+		{Name: "remaining", Values: b.Remaining, Color: ColorBlue400},
+	}
+	return renderChart(title, series, b.Days, width, height)
+}
+
+func renderCumulativeFlow(c state.CumulativeFlow, width, height int) string {
+	title := "Cumulative flow · items per status"
+	if len(c.Days) < 2 {
+		muted := lipgloss.NewStyle().Foreground(ColorGray400)
+		return lipgloss.JoinVertical(lipgloss.Left, HeaderProjectStyle.Render(title), muted.Render("Not enough history yet. A sample is kept for each day the project is refreshed."))
+	}
+
+	// Bands stack from the last status up, so each line is the top of its band.
+	series := make([]chartSeries, len(c.Statuses))
+	for i, status := range c.Statuses {
+		color := flowColors[i%len(flowColors)]
+		if c.Closed[i] {
+			color = ColorGreen400
+		}
+		series[i] = chartSeries{Name: status, Values: make([]float64, len(c.Days)), Color: color}
+	}
+	for day, counts := range c.Counts {
+		total := 0.0
+		for i := len(counts) - 1; i >= 0; i-- {
+			total += float64(counts[i])
+			series[i].Values[day] = total
+		}
+	}
+	return renderChart(title, series, c.Days, width, height)
+}
+
+type chartSeries struct {
+	Name   string
+	Values []float64 // NaN leaves a gap
+	Color  lipgloss.Color
+}
+
+// renderChart draws series as braille lines from zero to the largest value,
+// with the first and last day under the x axis and a legend.
+func renderChart(title string, series []chartSeries, days []time.Time, width, height int) string {
+	maxY := 1.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			if !math.IsNaN(v) && v > maxY {
+				maxY = v
+			}
+		}
+	}
+	maxY = math.Ceil(maxY)
+
+	top, bottom := formatEstimate(maxY), "0"
+	gutter := max(len(top), len(bottom))
+	plotWidth := width - gutter - 2
+	if plotWidth < 10 {
+		plotWidth = 10
+	}
+	plotHeight := height - 4 // title, axis, dates and legend
+	if plotHeight < 3 {
+		plotHeight = 3
+	}
+
+	canvas := newBrailleCanvas(plotWidth, plotHeight)
+	for _, s := range series {
+		canvas.plot(s.Values, maxY, s.Color)
+	}
+
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	lines := []string{HeaderProjectStyle.Render(title)}
+	for i, row := range canvas.rows() {
+		label := ""
+		switch i {
+		case 0:
+			label = top
+		case plotHeight - 1:
+			label = bottom
+		}
+		lines = append(lines, muted.Render(fmt.Sprintf("%*s │", gutter, label))+row)
+	}
+	lines = append(lines, muted.Render(strings.Repeat(" ", gutter)+" └"+strings.Repeat("─", plotWidth)))
+
+	if len(days) > 0 {
+		first, last := days[0].Format("Jan 2"), days[len(days)-1].Format("Jan 2")
+		gap := plotWidth - len(first) - len(last)
+		if gap < 1 {
+			gap = 1
+		}
+		lines = append(lines, muted.Render(strings.Repeat(" ", gutter+2)+first+strings.Repeat(" ", gap)+last))
+	}
+
+	var legend []string
+	for _, s := range series {
+		legend = append(legend, lipgloss.NewStyle().Foreground(s.Color).Render("━ "+s.Name))
+	}
+	lines = append(lines, strings.Repeat(" ", gutter+2)+strings.Join(legend, "  "))
+	return strings.Join(lines, "\n")
+}
+
+// brailleDots maps a dot's row and column inside a cell to its braille bit.
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// brailleCanvas plots at two dots across and four down per terminal cell.
+type brailleCanvas struct {
+	width, height int // In cells
+	cells         [][]rune
+	colors        [][]lipgloss.Color
+}
+
+func newBrailleCanvas(width, height int) *brailleCanvas {
+	c := &brailleCanvas{width: width, height: height}
+	c.cells = make([][]rune, height)
+	c.colors = make([][]lipgloss.Color, height)
+	for i := range c.cells {
+		c.cells[i] = make([]rune, width)
+		c.colors[i] = make([]lipgloss.Color, width)
+	}
+	return c
+}
+
+func (c *brailleCanvas) set(x, y int, color lipgloss.Color) {
+	if x < 0 || y < 0 || x >= c.width*2 || y >= c.height*4 {
+		return
+	}
+	c.cells[y/4][x/2] |= brailleDots[y%4][x%2]
+	c.colors[y/4][x/2] = color
+}
+
+func (c *brailleCanvas) line(x0, y0, x1, y1 int, color lipgloss.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// plot spreads values evenly across the canvas and joins neighbouring points.
+func (c *brailleCanvas) plot(values []float64, maxY float64, color lipgloss.Color) {
+	dotsX, dotsY := c.width*2-1, c.height*4-1
+	prevX, prevY, hasPrev := 0, 0, false
+	for i, v := range values {
+		if math.IsNaN(v) {
+			hasPrev = false
+			continue
+		}
+		x := 0
+		if len(values) > 1 {
+			x = i * dotsX / (len(values) - 1)
+		}
+		y := dotsY - int(math.Round(v/maxY*float64(dotsY)))
+		if hasPrev {
+			c.line(prevX, prevY, x, y, color)
+		} else {
+			c.set(x, y, color)
+		}
+		prevX, prevY, hasPrev = x, y, true
+	}
+}
+
+func (c *brailleCanvas) rows() []string {
+	rows := make([]string, c.height)
+	for i, cells := range c.cells {
+		var b strings.Builder
+		for j, bits := range cells {
+			if bits == 0 {
+				b.WriteByte(' ')
+				continue
+			}
+			b.WriteString(lipgloss.NewStyle().Foreground(c.colors[i][j]).Render(string(0x2800 + bits)))
+		}
+		rows[i] = b.String()
+	}
+	return rows
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"project-hub/internal/state"
+)
+
+func TestRenderInsightsWithoutHistory(t *testing.T) {
+	out := ansi.Strip(RenderInsights(state.Burndown{}, state.CumulativeFlow{}, 80, 20))
+	if !strings.Contains(out, "No iteration is running.") || !strings.Contains(out, "Not enough history yet.") {
+		t.Fatalf("expected empty states for both charts, got:\n%s", out)
+	}
+}
+
+func TestRenderChartScalesToSize(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	series := []chartSeries{{Name: "remaining", Values: []float64{8, 6, math.NaN(), 2}, Color: ColorBlue400}}
+	out := ansi.Strip(renderChart("Burndown", series, []time.Time{day(12), day(13), day(14), day(15)}, 40, 10))
+	lines := strings.Split(out, "\n")
+	if len(lines) != 10 {
+		t.Fatalf("expected the chart to fill 10 lines, got %d:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[1], "8 │⠉") {
+		t.Fatalf("expected the first value at the top left, got %q", lines[1])
+	}
+	if !strings.Contains(lines[8], "Oct 12") || !strings.HasSuffix(lines[8], "Oct 15") {
+		t.Fatalf("expected first and last day under the axis, got %q", lines[8])
+	}
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w > 40 {
+			t.Fatalf("line wider than the chart: %d %q", w, line)
+		}
+	}
+}
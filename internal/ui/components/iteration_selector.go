@@ -0,0 +1,145 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"project-hub/internal/state"
+)
+
+// IterationSelectedMsg is sent when an iteration is picked or the selector is
+// closed. A nil Iteration clears the item's iteration.
+type IterationSelectedMsg struct {
+	ItemID    string
+	Iteration *state.Timeline
+	Canceled  bool
+}
+
+var (
+	iterationHeaderStyle = lipgloss.NewStyle().Foreground(ColorGray500).Bold(true)
+	iterationRangeStyle  = lipgloss.NewStyle().Foreground(ColorGray500)
+)
+
+var iterationPhaseLabels = map[state.IterationPhase]string{
+	state.IterationPast:     "Past",
+	state.IterationCurrent:  "Current",
+	state.IterationUpcoming: "Upcoming",
+}
+
+// IterationSelectorModel lists a project's iterations grouped into past,
+// current and upcoming, plus an entry that clears the assignment.
+type IterationSelectorModel struct {
+	Origin state.ViewMode // Mode to return to when the selector closes
+
+	item       state.Item
+	iterations []state.Timeline
+	now        time.Time
+	cursor     int // 0 is "No iteration", i+1 is iterations[i]
+	width      int
+}
+
+// NewIterationSelectorModel creates a selector for item, starting on its
+// iteration or else the current one. iterations should be ordered by start
+// date; now decides which group each one falls into.
+func NewIterationSelectorModel(item state.Item, iterations []state.Timeline, now time.Time, width int) IterationSelectorModel {
+	m := IterationSelectorModel{item: item, iterations: iterations, now: now, width: width}
+	for i, t := range iterations {
+		if item.IterationID != "" && t.ID == item.IterationID {
+			m.cursor = i + 1
+			return m
+		}
+	}
+	for i, t := range iterations {
+		if t.Phase(now) == state.IterationCurrent {
+			m.cursor = i + 1
+			break
+		}
+	}
+	return m
+}
+
+// Selected returns the highlighted iteration, nil for "No iteration".
+func (m IterationSelectorModel) Selected() *state.Timeline {
+	if m.cursor <= 0 || m.cursor > len(m.iterations) {
+		return nil
+	}
+	t := m.iterations[m.cursor-1]
+	return &t
+}
+
+func (m IterationSelectorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m IterationSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.iterations) {
+			m.cursor++
+		}
+	case "enter":
+		itemID := m.item.ID
+		selected := m.Selected()
+		return m, func() tea.Msg {
+			return IterationSelectedMsg{ItemID: itemID, Iteration: selected}
+		}
+	case "esc":
+		return m, func() tea.Msg {
+			return IterationSelectedMsg{Canceled: true}
+		}
+	}
+	return m, nil
+}
+
+func (m IterationSelectorModel) View() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("Move '%s' to iteration:\n\n", m.item.Title))
+
+	line := func(index int, label string) {
+		cursor := " "
+		if m.cursor == index {
+			cursor = ">"
+		}
+		s.WriteString(fmt.Sprintf("%s %s\n", cursor, label))
+	}
+	line(0, "No iteration")
+
+	var phase state.IterationPhase
+	for i, t := range m.iterations {
+		if p := t.Phase(m.now); p != phase {
+			phase = p
+			s.WriteString("\n" + iterationHeaderStyle.Render(iterationPhaseLabels[p]) + "\n")
+		}
+		label := t.Name
+		if r := t.DateRange(); r != "" {
+			label += "  " + iterationRangeStyle.Render(r)
+		}
+		if t.ID != "" && t.ID == m.item.IterationID {
+			label += " ✓"
+		}
+		line(i+1, label)
+	}
+	s.WriteString("\n" + iterationRangeStyle.Render("j/k:move enter:select esc:cancel"))
+
+	width := m.width / 3
+	if width < 40 {
+		width = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Width(width).
+		Render(s.String())
+}
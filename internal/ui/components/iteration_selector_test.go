@@ -0,0 +1,53 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"project-hub/internal/state"
+)
+
+func TestIterationSelectorGroupsAndSelects(t *testing.T) {
+	day := func(d int) *time.Time {
+		v := time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	iterations := []state.Timeline{
+		{ID: "it-1", Name: "Sprint 1", Start: day(5), End: day(12)},
+		{ID: "it-2", Name: "Sprint 2", Start: day(12), End: day(19)},
+		{ID: "it-3", Name: "Sprint 3", Start: day(19), End: day(26)},
+	}
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	m := NewIterationSelectorModel(state.Item{ID: "PVTI_1", Title: "Launch"}, iterations, now, 100)
+	if got := m.Selected(); got == nil || got.ID != "it-2" {
+		t.Fatalf("expected an unassigned item to start on the current iteration, got %+v", got)
+	}
+	view := m.View()
+	for _, want := range []string{"No iteration", "Past", "Current", "Upcoming", "Oct 12 – Oct 18"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in view, got %q", want, view)
+		}
+	}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = model.(IterationSelectorModel)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(IterationSelectedMsg)
+	if !ok || msg.ItemID != "PVTI_1" || msg.Iteration == nil || msg.Iteration.ID != "it-3" {
+		t.Fatalf("unexpected selection: %+v", msg)
+	}
+
+	m = NewIterationSelectorModel(state.Item{ID: "PVTI_1", IterationID: "it-1"}, iterations, now, 100)
+	for i := 0; i < 2; i++ {
+		model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+		m = model.(IterationSelectorModel)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg := cmd().(IterationSelectedMsg); msg.Iteration != nil || msg.Canceled {
+		t.Fatalf("expected No iteration to clear, got %+v", msg)
+	}
+}
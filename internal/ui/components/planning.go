@@ -0,0 +1,146 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+// RenderPlanning draws the backlog next to the next iteration, with the
+// planned estimate per assignee measured against perPerson capacity. A zero
+// perPerson leaves capacity out.
+func RenderPlanning(plan state.SprintPlan, pane, selected int, perPerson float64, width, height int) string {
+	paneWidth := (width - 1) / 2
+	if paneWidth < 20 {
+		paneWidth = 20
+	}
+
+	backlogSelected, plannedSelected := -1, -1
+	if pane == 1 {
+		plannedSelected = selected
+	} else {
+		backlogSelected = selected
+	}
+
+	backlogTitle := fmt.Sprintf("Backlog (%d)", len(plan.Backlog))
+	backlog := renderPlanningPane(backlogTitle, plan.Backlog, backlogSelected, pane == 0, nil, paneWidth, height)
+
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	var next string
+	if plan.Next == nil {
+		next = lipgloss.JoinVertical(lipgloss.Left, HeaderProjectStyle.Render("Next iteration"), "", muted.Render("No upcoming iteration."))
+		next = lipgloss.NewStyle().Width(paneWidth).Render(next)
+	} else {
+		title := fmt.Sprintf("%s (%d)", plan.Next.Name, len(plan.Planned))
+		if r := plan.Next.DateRange(); r != "" {
+			title = fmt.Sprintf("%s · %s (%d)", plan.Next.Name, r, len(plan.Planned))
+		}
+		summary := renderPlanningLoad(plan, perPerson)
+		next = renderPlanningPane(title, plan.Planned, plannedSelected, pane == 1, summary, paneWidth, height)
+	}
+
+	divider := lipgloss.NewStyle().Foreground(ColorGray500).Render(strings.Repeat("│\n", max(height-1, 1)) + "│")
+	return lipgloss.JoinHorizontal(lipgloss.Top, backlog, divider, next)
+}
+
+// renderPlanningLoad summarizes the planned estimate in total and per person.
+func renderPlanningLoad(plan state.SprintPlan, perPerson float64) []string {
+	over := lipgloss.NewStyle().Foreground(ColorRed400)
+	normal := lipgloss.NewStyle().Foreground(ColorGray300)
+	line := func(label string, estimate, capacity float64) string {
+		text := fmt.Sprintf("%-14s %s", label, formatEstimate(estimate))
+		if capacity <= 0 {
+			return normal.Render(text)
+		}
+		text += " / " + formatEstimate(capacity)
+		if estimate > capacity {
+			return over.Render(text)
+		}
+		return normal.Render(text)
+	}
+
+	capacity := 0.0
+	if perPerson > 0 {
+		capacity = plan.Capacity(perPerson)
+	}
+	lines := []string{line("Planned", plan.Estimate, capacity)}
+	for _, l := range plan.Load {
+		c := perPerson
+		if l.Assignee == state.UnassignedLoad {
+			c = 0
+		}
+		lines = append(lines, line("  "+l.Assignee, l.Estimate, c))
+	}
+	return lines
+}
+
+func renderPlanningPane(title string, items []state.Item, selected int, focused bool, footer []string, width, height int) string {
+	titleStyle := HeaderProjectStyle
+	if !focused {
+		titleStyle = titleStyle.Copy().Foreground(ColorGray400)
+	}
+
+	var lines []string
+	for i, item := range items {
+		cursor := "  "
+		style := lipgloss.NewStyle().Foreground(ColorGray300)
+		if i == selected {
+			cursor = "> "
+			style = style.Foreground(ColorYellow400)
+		}
+		label := item.Title
+		if item.Number > 0 {
+			label = fmt.Sprintf("#%d %s", item.Number, item.Title)
+		}
+		estimate := ""
+		if e := state.ItemEstimate(item); e > 0 {
+			estimate = " " + formatEstimate(e)
+		}
+		room := width - lipgloss.Width(cursor) - lipgloss.Width(estimate) - 1
+		if room < 1 {
+			room = 1
+		}
+		label = lipgloss.NewStyle().MaxWidth(room).Render(label)
+		gap := width - lipgloss.Width(cursor+label+estimate)
+		if gap < 1 {
+			gap = 1
+		}
+		lines = append(lines, style.Render(cursor+label+strings.Repeat(" ", gap)+estimate))
+	}
+	if len(items) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray400).Render("  Nothing here."))
+	}
+
+	visible := height - 2 - len(footer) // title, blank line and footer
+	if len(footer) > 0 {
+		visible-- // blank line above the footer
+	}
+	if visible < 1 {
+		visible = 1
+	}
+	offset := 0
+	if selected >= visible {
+		offset = selected - visible + 1
+	}
+	end := offset + visible
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	parts := []string{titleStyle.Render(title), "", strings.Join(lines[offset:end], "\n")}
+	if len(footer) > 0 {
+		parts = append(parts, "", strings.Join(footer, "\n"))
+	}
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// formatEstimate prints n with at most one decimal, as shares of split work can
+// otherwise run long.
+func formatEstimate(n float64) string {
+	return strconv.FormatFloat(math.Round(n*10)/10, 'f', -1, 64)
+}
@@ -0,0 +1,183 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+const roadmapLabelWidth = 32
+
+// RenderRoadmap draws one row per item with a bar across the days it covers.
+// The timeline starts one scroll step before today, moved by offset days, and
+// marks today with a vertical line.
+func RenderRoadmap(rows []state.RoadmapRow, zoom state.RoadmapZoom, offset, selected int, now time.Time, width, height int) string {
+	labelWidth := roadmapLabelWidth
+	if labelWidth > width/3 {
+		labelWidth = width / 3
+	}
+	timelineWidth := width - labelWidth - 1
+	if timelineWidth < 10 {
+		timelineWidth = 10
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	origin := today.AddDate(0, 0, offset-zoom.StepDays())
+	col := func(d time.Time) int {
+		days := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC).Sub(origin).Hours() / 24
+		return int(math.Floor(days * zoom.ColumnsPerDay()))
+	}
+	todayCol := col(today)
+
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	zoomLabel := lipgloss.NewStyle().Width(labelWidth).Render(muted.Render("Zoom: " + string(zoom)))
+	lines := []string{
+		zoomLabel + " " + muted.Render(roadmapScale(origin, zoom, timelineWidth, col)),
+		strings.Repeat(" ", labelWidth+1) + roadmapTodayMarker(todayCol, timelineWidth),
+	}
+
+	if len(rows) == 0 {
+		lines = append(lines, "", muted.Render("  No items match the current filter."))
+		return strings.Join(lines, "\n")
+	}
+
+	visible := height - len(lines)
+	if visible < 1 {
+		visible = 1
+	}
+	start := 0
+	if selected >= visible {
+		start = selected - visible + 1
+	}
+	end := start + visible
+	if end > len(rows) {
+		end = len(rows)
+	}
+	for i := start; i < end; i++ {
+		row := rows[i]
+		lines = append(lines, renderRoadmapLabel(row.Item, i == selected, labelWidth)+" "+renderRoadmapBar(row, i == selected, todayCol, timelineWidth, col))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roadmapScale labels the start of each week, or of each month when zoomed out.
+func roadmapScale(origin time.Time, zoom state.RoadmapZoom, width int, col func(time.Time) int) string {
+	scale := []rune(strings.Repeat(" ", width))
+	next := 0
+	for d := origin; col(d) < width; d = d.AddDate(0, 0, 1) {
+		label := ""
+		switch zoom {
+		case state.RoadmapWeek:
+			if d.Weekday() == time.Monday {
+				label = d.Format("Jan 2")
+			}
+		case state.RoadmapQuarter:
+			if d.Day() == 1 {
+				label = d.Format("Jan")
+				if d.Month() == time.January {
+					label = d.Format("Jan 06")
+				}
+			}
+		default:
+			if d.Day() == 1 {
+				label = d.Format("Jan 2006")
+			}
+		}
+		c := col(d)
+		if label == "" || c < next || c+len(label)+1 > width {
+			continue
+		}
+		copy(scale[c:], []rune("|"+label))
+		next = c + len(label) + 1
+	}
+	return string(scale)
+}
+
+func roadmapTodayMarker(todayCol, width int) string {
+	if todayCol < 0 || todayCol >= width {
+		return ""
+	}
+	marker := "▼ today"
+	if todayCol+lipgloss.Width(marker) > width {
+		marker = "▼"
+	}
+	return strings.Repeat(" ", todayCol) + lipgloss.NewStyle().Foreground(ColorRed400).Render(marker)
+}
+
+func renderRoadmapLabel(item state.Item, selected bool, width int) string {
+	cursor := "  "
+	style := lipgloss.NewStyle().Foreground(ColorGray300)
+	if selected {
+		cursor = "> "
+		style = style.Foreground(ColorYellow400)
+	}
+	label := item.Title
+	if item.Number > 0 {
+		label = fmt.Sprintf("#%d %s", item.Number, item.Title)
+	}
+	room := width - lipgloss.Width(cursor)
+	if room < 1 {
+		room = 1
+	}
+	label = lipgloss.NewStyle().MaxWidth(room).Render(label)
+	return style.Width(width).Render(cursor + label)
+}
+
+// renderRoadmapBar draws the row's span across the timeline. Spans that lie
+// entirely off screen leave an arrow pointing towards them.
+func renderRoadmapBar(row state.RoadmapRow, selected bool, todayCol, width int, col func(time.Time) int) string {
+	cells := []rune(strings.Repeat(" ", width))
+	barFrom, barTo := -1, -1
+	if span := row.Span; span != nil {
+		from, to := col(span.Start), col(span.End)
+		if to <= from {
+			to = from + 1
+		}
+		switch {
+		case to <= 0:
+			cells[0] = '◀'
+		case from >= width:
+			cells[width-1] = '▶'
+		default:
+			barFrom, barTo = max(from, 0), min(to, width)
+			for c := barFrom; c < barTo; c++ {
+				cells[c] = '█'
+			}
+		}
+	}
+	if todayCol >= 0 && todayCol < width && cells[todayCol] == ' ' {
+		cells[todayCol] = '│'
+	}
+
+	barColor := ColorBlue400
+	switch {
+	case selected:
+		barColor = ColorYellow400
+	case row.Item.Closed():
+		barColor = ColorGray500
+	}
+	bar := lipgloss.NewStyle().Foreground(barColor)
+	marker := lipgloss.NewStyle().Foreground(ColorRed400)
+	arrow := lipgloss.NewStyle().Foreground(ColorGray400)
+
+	var b strings.Builder
+	for c, r := range cells {
+		switch {
+		case c == barFrom:
+			b.WriteString(bar.Render(string(cells[barFrom:barTo])))
+		case c > barFrom && c < barTo:
+		case r == '│':
+			b.WriteString(marker.Render(string(r)))
+		case r != ' ':
+			b.WriteString(arrow.Render(string(r)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"project-hub/internal/state"
+)
+
+func TestRenderRoadmapDrawsBarsAndToday(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	long := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	items := []state.Item{
+		{Title: "Next sprint", IterationID: "it-2", IterationStart: &start, IterationDurationDays: 7},
+		{Title: "Long ago", IterationID: "it-0", IterationStart: &long, IterationDurationDays: 7},
+		{Title: "Undated"},
+	}
+
+	out := ansi.Strip(RenderRoadmap(state.BuildRoadmap(items, nil), state.RoadmapMonth, 0, 0, now, 90, 10))
+	lines := strings.Split(out, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected scale, marker and three rows, got:\n%s", out)
+	}
+	todayCol := strings.Index(lines[1], "▼")
+	if todayCol < 0 {
+		t.Fatalf("expected a today marker, got:\n%s", out)
+	}
+
+	// Month zoom starts two weeks before today at one column per day.
+	next := []rune(lines[3])
+	if string(next[todayCol+2:todayCol+9]) != strings.Repeat("█", 7) {
+		t.Fatalf("expected a week-long bar from Oct 19, got %q", lines[3])
+	}
+	if !strings.Contains(lines[2], "◀") {
+		t.Fatalf("expected an arrow for an iteration off screen, got %q", lines[2])
+	}
+	if []rune(lines[4])[todayCol] != '│' {
+		t.Fatalf("expected the today line through undated rows, got %q", lines[4])
+	}
+}
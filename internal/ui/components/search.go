@@ -0,0 +1,99 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+// RenderSearchPanel shows the search input above the ranked results, each
+// with a snippet of the text that matched and the match highlighted. Only as
+// many results as fit in height are listed, scrolled to keep the selected one
+// in view. pending is the number of issues whose comments are still loading.
+func RenderSearchPanel(input string, results []state.SearchResult, selected int, pending int, width int, height int) string {
+	var s strings.Builder
+	s.WriteString(input)
+	s.WriteString("\n\n")
+
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	match := lipgloss.NewStyle().Foreground(ColorYellow400).Bold(true)
+	visible := (height - 8) / 2
+	if visible < 3 {
+		visible = 3
+	}
+	start := 0
+	if selected >= visible {
+		start = selected - visible + 1
+	}
+	end := min(start+visible, len(results))
+	for i := start; i < end; i++ {
+		r := results[i]
+		cursor := " "
+		if i == selected {
+			cursor = ">"
+		}
+		ref := r.Item.Repository
+		if r.Item.Number > 0 {
+			ref = fmt.Sprintf("%s#%d", ref, r.Item.Number)
+		}
+		s.WriteString(fmt.Sprintf("%s %s %s\n", cursor, r.Item.Title, muted.Render(ref)))
+		s.WriteString("    " + muted.Render(r.Field+": ") + highlightRunes(r.Snippet, r.Highlight, muted, match))
+		s.WriteString("\n")
+	}
+
+	var status []string
+	if strings.TrimSpace(input) != "" || len(results) > 0 {
+		status = append(status, fmt.Sprintf("%d results", len(results)))
+	}
+	if pending > 0 {
+		status = append(status, "loading comments…")
+	}
+	if len(status) > 0 {
+		s.WriteString("\n" + muted.Render(strings.Join(status, " · ")))
+	}
+
+	panelWidth := width * 2 / 3
+	if panelWidth < 40 {
+		panelWidth = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorYellow400).
+		Padding(1, 2).
+		Width(panelWidth).
+		Render(strings.TrimRight(s.String(), "\n"))
+}
+
+// highlightRunes renders the runes of text at the given offsets in match and
+// the rest in base.
+func highlightRunes(text string, offsets []int, base, match lipgloss.Style) string {
+	marked := make(map[int]bool, len(offsets))
+	for _, i := range offsets {
+		marked[i] = true
+	}
+	var out, run strings.Builder
+	inMatch := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if inMatch {
+			out.WriteString(match.Render(run.String()))
+		} else {
+			out.WriteString(base.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for i, r := range []rune(text) {
+		if marked[i] != inMatch {
+			flush()
+			inMatch = marked[i]
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return out.String()
+}
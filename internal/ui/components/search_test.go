@@ -0,0 +1,25 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"project-hub/internal/state"
+)
+
+func TestRenderSearchPanelScrollsToSelection(t *testing.T) {
+	var results []state.SearchResult
+	for _, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+		results = append(results, state.SearchResult{Item: state.Item{Title: title, Repository: "acme/app", Number: 1}, Field: "title", Snippet: title})
+	}
+
+	out := ansi.Strip(RenderSearchPanel("SEARCH o", results, 4, 2, 90, 14))
+	if strings.Contains(out, "One") || !strings.Contains(out, "> Five acme/app#1") {
+		t.Fatalf("expected the list scrolled to the selected result, got:\n%s", out)
+	}
+	if !strings.Contains(out, "5 results · loading comments…") {
+		t.Fatalf("expected the result count and loading status, got:\n%s", out)
+	}
+}
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -59,6 +60,20 @@ var (
 	HeaderViewUnselectedStyle = lipgloss.NewStyle().
 					Foreground(ColorGray500)
 
+	HeaderStaleStyle = lipgloss.NewStyle().
+				Foreground(ColorYellow400)
+
+	HeaderLoadingStyle = lipgloss.NewStyle().
+				Foreground(ColorCyan400)
+
+	HeaderOfflineStyle = lipgloss.NewStyle().
+				Foreground(ColorRed400).
+				Bold(true)
+
+	HeaderSelectionStyle = lipgloss.NewStyle().
+				Foreground(ColorPurple400).
+				Bold(true)
+
 	// Footer Styles
 	FooterStyle = lipgloss.NewStyle().
 			Foreground(ColorGray400).
@@ -182,6 +197,32 @@ func StatusDot(status string) string {
 	return lipgloss.NewStyle().Foreground(StatusColor(status)).Render("●")
 }
 
+// ChangeColor returns the highlight color for a card or row changed by a refresh.
+func ChangeColor(kind state.ChangeKind) lipgloss.Color {
+	switch kind {
+	case state.ChangeAdded:
+		return ColorGreen400
+	case state.ChangeMoved:
+		return ColorBlue400
+	default:
+		return ColorCyan400
+	}
+}
+
+// LabelStyle renders a label chip in its GitHub colour, given as six hex
+// digits, with black or white text depending on how light the colour is.
+func LabelStyle(hex string) lipgloss.Style {
+	var r, g, b int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r, &g, &b); err != nil {
+		return lipgloss.NewStyle().Foreground(ColorText).Background(ColorGray700)
+	}
+	fg := ColorWhite
+	if 299*r+587*g+114*b > 128*1000 {
+		fg = ColorBlack
+	}
+	return lipgloss.NewStyle().Foreground(fg).Background(lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r, g, b)))
+}
+
 var (
 	FrameStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -221,12 +262,30 @@ func InlineGap() string {
 	return lipgloss.NewStyle().PaddingRight(1).Render("")
 }
 
+// HeaderStatus carries data freshness indicators shown next to the project name.
+type HeaderStatus struct {
+	Loading   bool
+	Spinner   string // Current spinner frame shown while Loading
+	Stale     bool
+	CachedAt  *time.Time
+	Offline   bool
+	Syncing   bool
+	Pending   int // Mutations queued for replay
+	Conflicts int // Queued mutations awaiting manual resolution
+}
+
 // RenderHeader shows project name, view, and active filter/mode hints with badges.
-func RenderHeader(project state.Project, view state.ViewContext, width int) string {
+func RenderHeader(project state.Project, view state.ViewContext, width int, status HeaderStatus) string {
 	title := HeaderTitleStyle.Render("█ GitHub Projects TUI")
 	projectName := HeaderProjectStyle.Render("Project: " + project.Name)
 
 	leftContent := lipgloss.JoinHorizontal(lipgloss.Top, title, lipgloss.NewStyle().Foreground(ColorGray500).Render(" | "), projectName)
+	if indicator := renderHeaderStatus(status, time.Now()); indicator != "" {
+		leftContent = lipgloss.JoinHorizontal(lipgloss.Top, leftContent, " ", indicator)
+	}
+	if n := len(view.Selection); n > 0 {
+		leftContent = lipgloss.JoinHorizontal(lipgloss.Top, leftContent, " ", HeaderSelectionStyle.Render(fmt.Sprintf("%d selected", n)))
+	}
 
 	var middleContent string
 	if view.CurrentView == state.ViewBoard {
@@ -262,10 +321,47 @@ func RenderHeader(project state.Project, view state.ViewContext, width int) stri
 	return style.Render(content)
 }
 
+func renderHeaderStatus(status HeaderStatus, now time.Time) string {
+	var parts []string
+	if status.Loading {
+		parts = append(parts, status.Spinner+HeaderLoadingStyle.Render("loading"))
+	}
+	if status.Offline {
+		parts = append(parts, HeaderOfflineStyle.Render("⚠ offline"))
+	}
+	if status.Pending > 0 {
+		label := fmt.Sprintf("%d queued", status.Pending)
+		if status.Syncing {
+			label = fmt.Sprintf("syncing %d queued", status.Pending)
+		}
+		parts = append(parts, HeaderStaleStyle.Render(label))
+	}
+	if status.Conflicts > 0 {
+		parts = append(parts, HeaderOfflineStyle.Render(fmt.Sprintf("%d conflict(s), ! to resolve", status.Conflicts)))
+	}
+	if status.Stale {
+		label := "◌ stale"
+		if status.CachedAt != nil {
+			age := now.Sub(*status.CachedAt).Round(time.Minute)
+			if age < time.Minute {
+				label += " (cached just now)"
+			} else {
+				label += " (cached " + strings.TrimSuffix(age.String(), "0s") + " ago)"
+			}
+		}
+		parts = append(parts, HeaderStaleStyle.Render(label))
+	}
+	return strings.Join(parts, " ")
+}
+
 func renderViewTabs(currentView state.ViewType) string {
 	boardTab := HeaderViewUnselectedStyle.Render("[1:Board]")
 	tableTab := HeaderViewUnselectedStyle.Render("[2:Table]")
 	settingsTab := HeaderViewUnselectedStyle.Render("[3:Settings]")
+	digestTab := HeaderViewUnselectedStyle.Render("[4:Digest]")
+	planningTab := HeaderViewUnselectedStyle.Render("[5:Plan]")
+	roadmapTab := HeaderViewUnselectedStyle.Render("[6:Roadmap]")
+	insightsTab := HeaderViewUnselectedStyle.Render("[7:Insights]")
 
 	switch currentView {
 	case state.ViewBoard:
@@ -274,13 +370,33 @@ func renderViewTabs(currentView state.ViewType) string {
 		tableTab = HeaderViewSelectedStyle.Render("[2:Table]")
 	case state.ViewSettings:
 		settingsTab = HeaderViewSelectedStyle.Render("[3:Settings]")
+	case state.ViewDigest:
+		digestTab = HeaderViewSelectedStyle.Render("[4:Digest]")
+	case state.ViewPlanning:
+		planningTab = HeaderViewSelectedStyle.Render("[5:Plan]")
+	case state.ViewRoadmap:
+		roadmapTab = HeaderViewSelectedStyle.Render("[6:Roadmap]")
+	case state.ViewInsights:
+		insightsTab = HeaderViewSelectedStyle.Render("[7:Insights]")
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, boardTab, tableTab, settingsTab)
+	return lipgloss.JoinHorizontal(lipgloss.Top, boardTab, tableTab, settingsTab, digestTab, planningTab, roadmapTab, insightsTab)
 }
 
 func RenderFooter(mode, view string, width int, editTitle string, visibleCols []int) string {
-	keybinds := FooterKeybindsStyle.Render("j/k:move g/G:top/bottom i:edit c:create /:filter a:assign m:group o:detail O:open y:copy f:fields 1-3:view q:quit")
+	keybinds := FooterKeybindsStyle.Render("j/k:move g/G:top/bottom i:edit c:create /:filter a:assign e:field I:iteration space/V/A:select m:group o:detail O:open y:copy f:fields v:views ?:search u:undo 1-7:view q:quit")
+	if view == string(state.ViewDigest) {
+		keybinds = FooterKeybindsStyle.Render("j/k:move g/G:top/bottom o:detail R:refresh 1-7:view q:quit")
+	}
+	if view == string(state.ViewPlanning) {
+		keybinds = FooterKeybindsStyle.Render("h/l:pane j/k:move space:plan/unplan C:carry over o:detail R:refresh 1-7:view q:quit")
+	}
+	if view == string(state.ViewRoadmap) {
+		keybinds = FooterKeybindsStyle.Render("j/k:move h/l:scroll +/-:zoom T:today o:detail /:filter ?:search R:refresh 1-7:view q:quit")
+	}
+	if view == string(state.ViewInsights) {
+		keybinds = FooterKeybindsStyle.Render("x:export csv R:refresh 1-7:view q:quit")
+	}
 	var modeLabel string
 	modeStyle := FooterModeStyle
 	switch strings.ToLower(mode) {
@@ -339,9 +455,21 @@ func RenderFooter(mode, view string, width int, editTitle string, visibleCols []
 		} else {
 			modeLabel = "FILTER MODE"
 		}
+	case "filtering:error":
+		modeLabel = "FILTER MODE " + editTitle
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorRed400)
 
 	case "detail":
-		modeLabel = "DETAIL MODE (i:edit body a:comment esc/q:close)"
+		modeLabel = "DETAIL MODE (i:edit body a:comment e:field esc/q:close)"
+	case "fieldedit":
+		modeLabel = "FIELD EDIT MODE"
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorYellow400)
+	case "iterationselect":
+		modeLabel = "ITERATION SELECT MODE"
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorYellow400)
+	case "checklist":
+		modeLabel = "CHECKLIST MODE"
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorCyan400)
 	case "detailedit":
 		modeLabel = "DETAIL EDIT -- NORMAL -- (i/a:insert o:newline+insert g/G:top/bottom hjkl:move ctrl+u/d:5lines ctrl+s:save esc:cancel)"
 		modeStyle = FooterModeStyle.Copy().Foreground(ColorYellow400)
@@ -354,8 +482,23 @@ func RenderFooter(mode, view string, width int, editTitle string, visibleCols []
 	case "detailcomment:insert":
 		modeLabel = "DETAIL COMMENT -- INSERT -- (esc:normal ctrl+s:save)"
 		modeStyle = FooterModeStyle.Copy().Foreground(ColorGreen500)
+	case "conflicts":
+		modeLabel = "CONFLICTS (j/k:move m:keep mine t:keep theirs esc:close)"
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorRed400)
+	case "search":
+		modeLabel = "SEARCH (type:search ↑/↓:move enter:jump esc:close)"
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorYellow400)
+	case "viewpicker":
+		modeLabel = "VIEWS (j/k:move enter:apply n:save current d:delete i:import from GitHub esc:close)"
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorBlue400)
+	case "sortinput":
+		modeLabel = "SORT BY " + editTitle
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorBlue400)
+	case "saveview":
+		modeLabel = "SAVE VIEW AS " + editTitle
+		modeStyle = FooterModeStyle.Copy().Foreground(ColorGreen500)
 	case "fieldtoggle":
-		modeLabel = "FIELD TOGGLE MODE (m:milestone r:repository l:labels s:sub-issue p:parent esc:cancel)"
+		modeLabel = "FIELD TOGGLE MODE (m:milestone r:repository l:labels s:sub-issue p:parent i:iteration esc:cancel)"
 	case "sort":
 		parts := []string{"t:Title", "S:Status"}
 		has := func(col int) bool {
@@ -380,7 +523,7 @@ func RenderFooter(mode, view string, width int, editTitle string, visibleCols []
 			parts = append(parts, "a:Assignee")
 		}
 		// Do not include Priority/Number/CreatedAt/UpdatedAt unless they are mapped into visibleCols
-		modeLabel = "SORT MODE (" + strings.Join(parts, " ") + " esc:cancel)"
+		modeLabel = "SORT MODE (" + strings.Join(parts, " ") + " ::keys esc:cancel)"
 		modeStyle = FooterModeStyle.Copy().Foreground(ColorBlue400)
 	default:
 		modeLabel = "NORMAL MODE"
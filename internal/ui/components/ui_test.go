@@ -3,6 +3,7 @@ package components
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestStatusDotRendersDot(t *testing.T) {
@@ -24,3 +25,39 @@ func TestStatusDotRendersDot(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderHeaderStatus(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	cachedAt := now.Add(-12 * time.Minute)
+
+	if got := renderHeaderStatus(HeaderStatus{}, now); got != "" {
+		t.Fatalf("expected no indicator for fresh data, got %q", got)
+	}
+	got := renderHeaderStatus(HeaderStatus{Stale: true, CachedAt: &cachedAt}, now)
+	if !strings.Contains(got, "stale") || !strings.Contains(got, "12m ago") {
+		t.Fatalf("expected stale indicator with cache age, got %q", got)
+	}
+	got = renderHeaderStatus(HeaderStatus{Offline: true, Pending: 2, Conflicts: 1}, now)
+	if !strings.Contains(got, "offline") || !strings.Contains(got, "2 queued") || !strings.Contains(got, "1 conflict") {
+		t.Fatalf("expected offline, queue and conflict indicators, got %q", got)
+	}
+}
+
+func TestLabelStyleContrastsWithColour(t *testing.T) {
+	if fg := LabelStyle("d73a4a").GetForeground(); fg != ColorWhite {
+		t.Fatalf("expected white text on red, got %v", fg)
+	}
+	if fg := LabelStyle("#a2eeef").GetForeground(); fg != ColorBlack {
+		t.Fatalf("expected black text on light cyan, got %v", fg)
+	}
+	if bg := LabelStyle("nope").GetBackground(); bg != ColorGray700 {
+		t.Fatalf("expected a neutral chip for a bad colour, got %v", bg)
+	}
+}
+
+func TestRenderFooterShowsFilterError(t *testing.T) {
+	footer := RenderFooter("filtering:error", "board", 200, "label:bug)  ✗ unexpected )", nil)
+	if !strings.Contains(footer, "FILTER MODE label:bug)  ✗ unexpected )") {
+		t.Fatalf("expected the parse error in the footer, got %q", footer)
+	}
+}
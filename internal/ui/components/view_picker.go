@@ -0,0 +1,58 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"project-hub/internal/state"
+)
+
+// RenderViewPicker lists the views saved in the config file followed by the
+// project's GitHub views, with their filters.
+func RenderViewPicker(saved, github []state.SavedView, selected int, width int) string {
+	var s strings.Builder
+	s.WriteString("Saved views:\n\n")
+
+	muted := lipgloss.NewStyle().Foreground(ColorGray400)
+	row := func(i int, v state.SavedView) {
+		cursor := " "
+		if i == selected {
+			cursor = ">"
+		}
+		view := string(v.View)
+		if view == "" {
+			view = string(state.ViewBoard)
+		}
+		s.WriteString(fmt.Sprintf("%s %s %s\n", cursor, v.Name, muted.Render("("+view+")")))
+		if v.Query != "" {
+			s.WriteString(muted.Render("    " + v.Query))
+			s.WriteString("\n")
+		}
+	}
+	if len(saved) == 0 {
+		s.WriteString(muted.Render("  No saved views; press n to save the current one"))
+		s.WriteString("\n")
+	}
+	for i, v := range saved {
+		row(i, v)
+	}
+	if len(github) > 0 {
+		s.WriteString("\nOn GitHub:\n\n")
+		for i, v := range github {
+			row(len(saved)+i, v)
+		}
+	}
+
+	panelWidth := width * 2 / 3
+	if panelWidth < 40 {
+		panelWidth = 40
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue400).
+		Padding(1, 2).
+		Width(panelWidth).
+		Render(strings.TrimRight(s.String(), "\n"))
+}
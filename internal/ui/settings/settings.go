@@ -55,7 +55,7 @@ func New(projectID, owner string, disableNotifications bool, itemLimit int, excl
 	oi.Width = 50
 
 	li := textinput.New()
-	li.Placeholder = "Item limit (max 1000)"
+	li.Placeholder = "Item limit"
 	li.SetValue(fmt.Sprintf("%d", itemLimit))
 	li.CharLimit = 10
 	li.Width = 50
@@ -173,8 +173,8 @@ func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
 					itemLimit = 100
 				}
 			}
-			if itemLimit > 1000 {
-				itemLimit = 1000
+			if itemLimit <= 0 {
+				itemLimit = 100
 			}
 			excludeDone := m.excludeDoneInput.Value() == "y"
 			disableNotifications := m.disableNotificationsInput.Value() == "y"
@@ -334,8 +334,8 @@ func (m SettingsModel) GetValues() (projectID, owner string, suppressHints bool,
 			itemLimit = 100
 		}
 	}
-	if itemLimit > 1000 {
-		itemLimit = 1000
+	if itemLimit <= 0 {
+		itemLimit = 100
 	}
 	excludeDone = m.excludeDoneInput.Value() == "y"
 	suppressHints = m.disableNotificationsInput.Value() == "y"
@@ -17,7 +17,8 @@ type RenderResult struct {
 }
 
 // Render renders the table view using lipgloss, matching the moc.go layout.
-func Render(items []state.Item, focusedID string, focusedColIndex int, innerWidth int, fieldVisibility state.CardFieldVisibility) RenderResult {
+// Items in selected are marked for bulk edits.
+func Render(items []state.Item, focusedID string, focusedColIndex int, innerWidth int, fieldVisibility state.CardFieldVisibility, highlights map[string]state.ChangeKind, selected map[string]bool) RenderResult {
 	if innerWidth <= 0 {
 		innerWidth = 80
 	}
@@ -104,6 +105,8 @@ func Render(items []state.Item, focusedID string, focusedColIndex int, innerWidt
 		rowBaseStyle := cellStyle
 		if it.ID == focusedID {
 			rowBaseStyle = selectedStyle
+		} else if kind, ok := highlights[it.ID]; ok {
+			rowBaseStyle = cellStyle.Copy().Foreground(components.ChangeColor(kind))
 		}
 
 		cells := make([]string, len(cols))
@@ -117,6 +120,9 @@ func Render(items []state.Item, focusedID string, focusedColIndex int, innerWidt
 			if it.ID == focusedID && colIdx == focusedColIndex {
 				cellStyleToApply = focusedCellStyle.Copy()
 			}
+			if columns[colIdx].Key == state.ColumnTitle && selected[it.ID] {
+				val = "✔ " + val
+			}
 			if columns[colIdx].Key == state.ColumnStatus {
 				status := strings.TrimSpace(it.Status)
 				if status != "" {
@@ -170,6 +176,9 @@ func tableColumns(fieldVisibility state.CardFieldVisibility) []tableColumn {
 	if fieldVisibility.ShowParentIssue {
 		columns = append(columns, tableColumn{Key: state.ColumnParentIssue, Label: "Parent", Percent: 8})
 	}
+	if fieldVisibility.ShowIteration {
+		columns = append(columns, tableColumn{Key: state.ColumnIteration, Label: "Iteration", Percent: 8})
+	}
 
 	columns = append(columns, tableColumn{Key: state.ColumnAssignees, Label: "Assignees", Percent: 12})
 	return columns
@@ -195,6 +204,8 @@ func columnValue(item state.Item, columnKey int) string {
 		return item.Priority
 	case state.ColumnAssignees:
 		return strings.Join(item.Assignees, ",")
+	case state.ColumnIteration:
+		return item.IterationName
 	default:
 		return ""
 	}